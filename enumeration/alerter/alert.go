@@ -52,12 +52,37 @@ func (f *FakeAlert) Resource() *resource.Resource {
 	return nil
 }
 
+// ProviderMessageAlert is implemented by alerts carrying a hint from the provider, e.g. a link to the documentation
+type ProviderMessageAlert interface {
+	Alert
+	GetProviderMessage() string
+}
+
 type SerializableAlert struct {
 	Alert
 }
 
 type SerializedAlert struct {
-	Msg string `json:"message"`
+	Msg            string                         `json:"message"`
+	IgnoreResource bool                           `json:"should_ignore_resource,omitempty"`
+	Res            *resource.SerializableResource `json:"resource,omitempty"`
+	ProviderMsg    string                         `json:"provider_message,omitempty"`
+	res            *resource.Resource
+}
+
+func NewSerializedAlert(alert Alert) *SerializedAlert {
+	serialized := &SerializedAlert{
+		Msg:            alert.Message(),
+		IgnoreResource: alert.ShouldIgnoreResource(),
+	}
+	if res := alert.Resource(); res != nil {
+		serialized.Res = resource.NewSerializableResource(res)
+		serialized.res = res
+	}
+	if alert, ok := alert.(ProviderMessageAlert); ok {
+		serialized.ProviderMsg = alert.GetProviderMessage()
+	}
+	return serialized
 }
 
 func (u *SerializedAlert) Message() string {
@@ -65,11 +90,15 @@ func (u *SerializedAlert) Message() string {
 }
 
 func (u *SerializedAlert) ShouldIgnoreResource() bool {
-	return false
+	return u.IgnoreResource
 }
 
 func (s *SerializedAlert) Resource() *resource.Resource {
-	return nil
+	return s.res
+}
+
+func (s *SerializedAlert) GetProviderMessage() string {
+	return s.ProviderMsg
 }
 
 func (s *SerializableAlert) UnmarshalJSON(bytes []byte) error {
@@ -78,10 +107,17 @@ func (s *SerializableAlert) UnmarshalJSON(bytes []byte) error {
 	if err := json.Unmarshal(bytes, &res); err != nil {
 		return err
 	}
+	if res.Res != nil {
+		r, err := res.Res.Resource()
+		if err != nil {
+			return err
+		}
+		res.res = r
+	}
 	s.Alert = &res
 	return nil
 }
 
 func (s *SerializableAlert) MarshalJSON() ([]byte, error) {
-	return json.Marshal(SerializedAlert{Msg: s.Message()})
+	return json.Marshal(NewSerializedAlert(s.Alert))
 }
@@ -50,3 +50,15 @@ func (e *IamAccessKeyEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, nil
 }
+
+// Probe lists the access keys of at most one user
+func (e *IamAccessKeyEnumerator) Probe() error {
+	users, err := e.repository.ProbeUsers()
+	if err != nil {
+		return remoteerror.NewResourceListingErrorWithType(err, string(e.SupportedType()), resourceaws.AwsIamUserResourceType)
+	}
+	if _, err := e.repository.ListAllAccessKeys(users); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
@@ -44,3 +44,11 @@ func (e *IamGroupEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, err
 }
+
+// Probe lists at most one group
+func (e *IamGroupEnumerator) Probe() error {
+	if _, err := e.repository.ProbeGroups(); err != nil {
+		return remoteerror.NewResourceListingErrorWithType(err, string(e.SupportedType()), aws.AwsIamGroupResourceType)
+	}
+	return nil
+}
@@ -54,3 +54,15 @@ func (e *IamGroupPolicyAttachmentEnumerator) Enumerate() ([]*resource.Resource,
 
 	return results, nil
 }
+
+// Probe lists the policy attachments of at most one group
+func (e *IamGroupPolicyAttachmentEnumerator) Probe() error {
+	groups, err := e.repository.ProbeGroups()
+	if err != nil {
+		return remoteerror.NewResourceListingErrorWithType(err, string(e.SupportedType()), resourceaws.AwsIamGroupResourceType)
+	}
+	if _, err := e.repository.ListAllGroupPolicyAttachments(groups); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
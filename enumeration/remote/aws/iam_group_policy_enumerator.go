@@ -48,3 +48,15 @@ func (e *IamGroupPolicyEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, err
 }
+
+// Probe lists the policies of at most one group
+func (e *IamGroupPolicyEnumerator) Probe() error {
+	groups, err := e.repository.ProbeGroups()
+	if err != nil {
+		return remoteerror.NewResourceListingErrorWithType(err, string(e.SupportedType()), aws.AwsIamGroupResourceType)
+	}
+	if _, err := e.repository.ListAllGroupPolicies(groups); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
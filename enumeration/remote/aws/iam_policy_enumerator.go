@@ -45,3 +45,11 @@ func (e *IamPolicyEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, err
 }
+
+// Probe lists at most one policy
+func (e *IamPolicyEnumerator) Probe() error {
+	if _, err := e.repository.ProbePolicies(); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
@@ -63,3 +63,11 @@ func (e *IamRoleEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, nil
 }
+
+// Probe lists at most one role
+func (e *IamRoleEnumerator) Probe() error {
+	if _, err := e.repository.ProbeRoles(); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
@@ -67,3 +67,15 @@ func (e *IamRolePolicyAttachmentEnumerator) Enumerate() ([]*resource.Resource, e
 
 	return results, nil
 }
+
+// Probe lists the policy attachments of at most one role
+func (e *IamRolePolicyAttachmentEnumerator) Probe() error {
+	roles, err := e.repository.ProbeRoles()
+	if err != nil {
+		return remoteerror.NewResourceListingErrorWithType(err, string(e.SupportedType()), resourceaws.AwsIamRoleResourceType)
+	}
+	if _, err := e.repository.ListAllRolePolicyAttachments(roles); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
@@ -52,3 +52,15 @@ func (e *IamRolePolicyEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, nil
 }
+
+// Probe lists the policies of at most one role
+func (e *IamRolePolicyEnumerator) Probe() error {
+	roles, err := e.repository.ProbeRoles()
+	if err != nil {
+		return remoteerror.NewResourceListingErrorWithType(err, string(e.SupportedType()), resourceaws.AwsIamRoleResourceType)
+	}
+	if _, err := e.repository.ListAllRolePolicies(roles); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
@@ -45,3 +45,11 @@ func (e *IamUserEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, err
 }
+
+// Probe lists at most one user
+func (e *IamUserEnumerator) Probe() error {
+	if _, err := e.repository.ProbeUsers(); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
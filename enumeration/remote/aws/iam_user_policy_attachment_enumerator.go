@@ -53,3 +53,15 @@ func (e *IamUserPolicyAttachmentEnumerator) Enumerate() ([]*resource.Resource, e
 
 	return results, nil
 }
+
+// Probe lists the policy attachments of at most one user
+func (e *IamUserPolicyAttachmentEnumerator) Probe() error {
+	users, err := e.repository.ProbeUsers()
+	if err != nil {
+		return remoteerror.NewResourceListingErrorWithType(err, string(e.SupportedType()), resourceaws.AwsIamUserResourceType)
+	}
+	if _, err := e.repository.ListAllUserPolicyAttachments(users); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
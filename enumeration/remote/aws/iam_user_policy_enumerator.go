@@ -48,3 +48,15 @@ func (e *IamUserPolicyEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, err
 }
+
+// Probe lists the policies of at most one user
+func (e *IamUserPolicyEnumerator) Probe() error {
+	users, err := e.repository.ProbeUsers()
+	if err != nil {
+		return remoteerror.NewResourceListingErrorWithType(err, string(e.SupportedType()), aws.AwsIamUserResourceType)
+	}
+	if _, err := e.repository.ListAllUserPolicies(users); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
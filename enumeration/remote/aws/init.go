@@ -186,5 +186,7 @@ func Init(version string, alerter alerter.AlerterInterface, providerLibrary *ter
 
 	remoteLibrary.AddEnumerator(NewElastiCacheClusterEnumerator(elasticacheRepository, factory))
 
+	remoteLibrary.AddGenericDetailsFetchers(provider, resource.NewDeserializer(factory))
+
 	return nil
 }
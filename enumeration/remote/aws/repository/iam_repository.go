@@ -22,6 +22,10 @@ type IAMRepository interface {
 	ListAllGroups() ([]*iam.Group, error)
 	ListAllGroupPolicies([]*iam.Group) ([]string, error)
 	ListAllGroupPolicyAttachments([]*iam.Group) ([]*AttachedGroupPolicy, error)
+	ProbeUsers() ([]*iam.User, error)
+	ProbePolicies() ([]*iam.Policy, error)
+	ProbeRoles() ([]*iam.Role, error)
+	ProbeGroups() ([]*iam.Group, error)
 }
 
 type iamRepository struct {
@@ -365,3 +369,43 @@ type RolePolicy struct {
 	Policy   string
 	RoleName string
 }
+
+// ProbeUsers lists at most one user with a single call, enough to check the permissions of the calls made per user.
+// Results are not cached as they are not the full list.
+func (r *iamRepository) ProbeUsers() ([]*iam.User, error) {
+	res, err := r.client.ListUsers(&iam.ListUsersInput{MaxItems: aws.Int64(1)})
+	if err != nil {
+		return nil, err
+	}
+	return res.Users, nil
+}
+
+// ProbePolicies lists at most one customer managed policy with a single call
+func (r *iamRepository) ProbePolicies() ([]*iam.Policy, error) {
+	res, err := r.client.ListPolicies(&iam.ListPoliciesInput{
+		Scope:    aws.String(iam.PolicyScopeTypeLocal),
+		MaxItems: aws.Int64(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Policies, nil
+}
+
+// ProbeRoles lists at most one role with a single call, enough to check the permissions of the calls made per role
+func (r *iamRepository) ProbeRoles() ([]*iam.Role, error) {
+	res, err := r.client.ListRoles(&iam.ListRolesInput{MaxItems: aws.Int64(1)})
+	if err != nil {
+		return nil, err
+	}
+	return res.Roles, nil
+}
+
+// ProbeGroups lists at most one group with a single call, enough to check the permissions of the calls made per group
+func (r *iamRepository) ProbeGroups() ([]*iam.Group, error) {
+	res, err := r.client.ListGroups(&iam.ListGroupsInput{MaxItems: aws.Int64(1)})
+	if err != nil {
+		return nil, err
+	}
+	return res.Groups, nil
+}
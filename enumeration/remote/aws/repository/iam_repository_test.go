@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"github.com/pkg/errors"
 	"github.com/r3labs/diff/v2"
 	"github.com/stretchr/testify/assert"
 )
@@ -1098,3 +1099,64 @@ func Test_IAMRepository_ListAllGroupPolicies(t *testing.T) {
 		})
 	}
 }
+
+func Test_IAMRepository_ProbeUsers(t *testing.T) {
+	dummyError := errors.New("dummy error")
+
+	tests := []struct {
+		name    string
+		mocks   func(client *awstest.MockFakeIAM)
+		want    []*iam.User
+		wantErr error
+	}{
+		{
+			name: "List a single user",
+			mocks: func(client *awstest.MockFakeIAM) {
+				client.On("ListUsers", &iam.ListUsersInput{MaxItems: aws.Int64(1)}).Return(&iam.ListUsersOutput{
+					Users: []*iam.User{
+						{
+							UserName: aws.String("test-driftctl"),
+						},
+					},
+					IsTruncated: aws.Bool(true),
+				}, nil).Once()
+			},
+			want: []*iam.User{
+				{
+					UserName: aws.String("test-driftctl"),
+				},
+			},
+		},
+		{
+			name: "Access denied",
+			mocks: func(client *awstest.MockFakeIAM) {
+				client.On("ListUsers", &iam.ListUsersInput{MaxItems: aws.Int64(1)}).Return(nil, dummyError).Once()
+			},
+			wantErr: dummyError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := cache.New(1)
+			client := &awstest.MockFakeIAM{}
+			tt.mocks(client)
+			r := &iamRepository{
+				client: client,
+				cache:  store,
+			}
+			got, err := r.ProbeUsers()
+			assert.Equal(t, tt.wantErr, err)
+			assert.Nil(t, store.Get("iamListAllUsers"))
+
+			changelog, err := diff.Diff(got, tt.want)
+			assert.Nil(t, err)
+			if len(changelog) > 0 {
+				for _, change := range changelog {
+					t.Errorf("%s: %v -> %v", strings.Join(change.Path, "."), change.From, change.To)
+				}
+				t.Fail()
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
@@ -298,6 +298,110 @@ func (_m *MockIAMRepository) ListAllUsers() ([]*iam.User, error) {
 	return r0, r1
 }
 
+// ProbeGroups provides a mock function with given fields:
+func (_m *MockIAMRepository) ProbeGroups() ([]*iam.Group, error) {
+	ret := _m.Called()
+
+	var r0 []*iam.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*iam.Group, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*iam.Group); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*iam.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProbePolicies provides a mock function with given fields:
+func (_m *MockIAMRepository) ProbePolicies() ([]*iam.Policy, error) {
+	ret := _m.Called()
+
+	var r0 []*iam.Policy
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*iam.Policy, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*iam.Policy); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*iam.Policy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProbeRoles provides a mock function with given fields:
+func (_m *MockIAMRepository) ProbeRoles() ([]*iam.Role, error) {
+	ret := _m.Called()
+
+	var r0 []*iam.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*iam.Role, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*iam.Role); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*iam.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProbeUsers provides a mock function with given fields:
+func (_m *MockIAMRepository) ProbeUsers() ([]*iam.User, error) {
+	ret := _m.Called()
+
+	var r0 []*iam.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*iam.User, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*iam.User); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*iam.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewMockIAMRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -79,3 +79,15 @@ func (e *S3BucketAnalyticEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, err
 }
+
+// Probe reads the analytics configurations of the first bucket
+func (e *S3BucketAnalyticEnumerator) Probe() error {
+	bucket, region, err := probeS3Bucket(e.repository, e.SupportedType())
+	if err != nil || bucket == nil {
+		return err
+	}
+	if _, err := e.repository.ListBucketAnalyticsConfigurations(bucket, region); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
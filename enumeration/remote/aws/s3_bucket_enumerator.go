@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
 	"github.com/khulnasoft-lab/driftctl/enumeration/remote/alerts"
 	"github.com/khulnasoft-lab/driftctl/enumeration/remote/aws/repository"
@@ -67,3 +68,28 @@ func (e *S3BucketEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, err
 }
+
+// Probe lists the buckets and reads the location of the first one, the location of every bucket is read when
+// enumerating
+func (e *S3BucketEnumerator) Probe() error {
+	_, _, err := probeS3Bucket(e.repository, e.SupportedType())
+	return err
+}
+
+// probeS3Bucket returns the first bucket and its region, the bucket is nil when there is none. Enumerators of bucket
+// configurations probe that bucket only, and return the error reading its configuration where an enumeration alerts
+// and goes on with the next bucket.
+func probeS3Bucket(repo repository.S3Repository, ty resource.ResourceType) (*s3.Bucket, string, error) {
+	buckets, err := repo.ListAllBuckets()
+	if err != nil {
+		return nil, "", remoteerror.NewResourceListingErrorWithType(err, string(ty), aws.AwsS3BucketResourceType)
+	}
+	if len(buckets) == 0 {
+		return nil, "", nil
+	}
+	region, err := repo.GetBucketLocation(*buckets[0].Name)
+	if err != nil {
+		return nil, "", remoteerror.NewResourceListingError(err, string(ty))
+	}
+	return buckets[0], region, nil
+}
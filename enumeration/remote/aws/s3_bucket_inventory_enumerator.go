@@ -80,3 +80,15 @@ func (e *S3BucketInventoryEnumerator) Enumerate() ([]*resource.Resource, error)
 
 	return results, err
 }
+
+// Probe reads the inventory configurations of the first bucket
+func (e *S3BucketInventoryEnumerator) Probe() error {
+	bucket, region, err := probeS3Bucket(e.repository, e.SupportedType())
+	if err != nil || bucket == nil {
+		return err
+	}
+	if _, err := e.repository.ListBucketInventoryConfigurations(bucket, region); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
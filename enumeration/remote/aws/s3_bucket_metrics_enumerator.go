@@ -79,3 +79,15 @@ func (e *S3BucketMetricsEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, nil
 }
+
+// Probe reads the metrics configurations of the first bucket
+func (e *S3BucketMetricsEnumerator) Probe() error {
+	bucket, region, err := probeS3Bucket(e.repository, e.SupportedType())
+	if err != nil || bucket == nil {
+		return err
+	}
+	if _, err := e.repository.ListBucketMetricsConfigurations(bucket, region); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
@@ -82,3 +82,15 @@ func (e *S3BucketNotificationEnumerator) Enumerate() ([]*resource.Resource, erro
 
 	return results, err
 }
+
+// Probe reads the notification configuration of the first bucket
+func (e *S3BucketNotificationEnumerator) Probe() error {
+	bucket, region, err := probeS3Bucket(e.repository, e.SupportedType())
+	if err != nil || bucket == nil {
+		return err
+	}
+	if _, err := e.repository.GetBucketNotification(*bucket.Name, region); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
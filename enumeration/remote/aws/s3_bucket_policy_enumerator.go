@@ -76,3 +76,15 @@ func (e *S3BucketPolicyEnumerator) Enumerate() ([]*resource.Resource, error) {
 
 	return results, err
 }
+
+// Probe reads the policy of the first bucket
+func (e *S3BucketPolicyEnumerator) Probe() error {
+	bucket, region, err := probeS3Bucket(e.repository, e.SupportedType())
+	if err != nil || bucket == nil {
+		return err
+	}
+	if _, err := e.repository.GetBucketPolicy(*bucket.Name, region); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
@@ -80,3 +80,15 @@ func (e *S3BucketPublicAccessBlockEnumerator) Enumerate() ([]*resource.Resource,
 
 	return results, err
 }
+
+// Probe reads the public access block of the first bucket
+func (e *S3BucketPublicAccessBlockEnumerator) Probe() error {
+	bucket, region, err := probeS3Bucket(e.repository, e.SupportedType())
+	if err != nil || bucket == nil {
+		return err
+	}
+	if _, err := e.repository.GetBucketPublicAccessBlock(*bucket.Name, region); err != nil {
+		return remoteerror.NewResourceListingError(err, string(e.SupportedType()))
+	}
+	return nil
+}
@@ -1437,3 +1437,48 @@ func TestIamGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestIamAccessKey_Probe(t *testing.T) {
+	dummyError := awserr.NewRequestFailure(awserr.New("AccessDeniedException", "", errors.New("")), 403, "")
+	users := []*iam.User{{UserName: aws.String("test-driftctl")}}
+
+	tests := []struct {
+		test    string
+		mocks   func(*repository.MockIAMRepository)
+		wantErr error
+	}{
+		{
+			test: "access keys of a single user are listed",
+			mocks: func(repo *repository.MockIAMRepository) {
+				repo.On("ProbeUsers").Return(users, nil).Once()
+				repo.On("ListAllAccessKeys", users).Return([]*iam.AccessKeyMetadata{}, nil).Once()
+			},
+		},
+		{
+			test: "cannot list users",
+			mocks: func(repo *repository.MockIAMRepository) {
+				repo.On("ProbeUsers").Return(nil, dummyError).Once()
+			},
+			wantErr: remoteerr.NewResourceListingErrorWithType(dummyError, resourceaws.AwsIamAccessKeyResourceType, resourceaws.AwsIamUserResourceType),
+		},
+		{
+			test: "cannot list access keys",
+			mocks: func(repo *repository.MockIAMRepository) {
+				repo.On("ProbeUsers").Return(users, nil).Once()
+				repo.On("ListAllAccessKeys", users).Return(nil, dummyError).Once()
+			},
+			wantErr: remoteerr.NewResourceListingError(dummyError, resourceaws.AwsIamAccessKeyResourceType),
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.test, func(tt *testing.T) {
+			repo := &repository.MockIAMRepository{}
+			c.mocks(repo)
+
+			err := aws2.NewIamAccessKeyEnumerator(repo, nil).Probe()
+			assert.Equal(tt, c.wantErr, err)
+			repo.AssertExpectations(tt)
+		})
+	}
+}
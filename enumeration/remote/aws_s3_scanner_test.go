@@ -1212,3 +1212,60 @@ func TestS3AccountPublicAccessBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestS3BucketPolicy_Probe(t *testing.T) {
+	dummyError := awserr.NewRequestFailure(awserr.New("AccessDenied", "", errors.New("")), 403, "")
+
+	tests := []struct {
+		test    string
+		mocks   func(*repository.MockS3Repository)
+		wantErr error
+	}{
+		{
+			test: "policy of the first bucket is read",
+			mocks: func(repository *repository.MockS3Repository) {
+				repository.On("ListAllBuckets").Return([]*s3.Bucket{
+					{Name: awssdk.String("bucket-martin-test-drift")},
+					{Name: awssdk.String("bucket-martin-test-drift2")},
+				}, nil).Once()
+				repository.On("GetBucketLocation", "bucket-martin-test-drift").Return("eu-west-1", nil).Once()
+				repository.On("GetBucketPolicy", "bucket-martin-test-drift", "eu-west-1").Return(nil, nil).Once()
+			},
+		},
+		{
+			test: "no bucket",
+			mocks: func(repository *repository.MockS3Repository) {
+				repository.On("ListAllBuckets").Return([]*s3.Bucket{}, nil).Once()
+			},
+		},
+		{
+			test: "cannot list buckets",
+			mocks: func(repository *repository.MockS3Repository) {
+				repository.On("ListAllBuckets").Return(nil, dummyError).Once()
+			},
+			wantErr: remoteerr.NewResourceListingErrorWithType(dummyError, resourceaws.AwsS3BucketPolicyResourceType, resourceaws.AwsS3BucketResourceType),
+		},
+		{
+			test: "cannot read the policy",
+			mocks: func(repository *repository.MockS3Repository) {
+				repository.On("ListAllBuckets").Return([]*s3.Bucket{
+					{Name: awssdk.String("bucket-martin-test-drift")},
+				}, nil).Once()
+				repository.On("GetBucketLocation", "bucket-martin-test-drift").Return("eu-west-1", nil).Once()
+				repository.On("GetBucketPolicy", "bucket-martin-test-drift", "eu-west-1").Return(nil, dummyError).Once()
+			},
+			wantErr: remoteerr.NewResourceListingError(dummyError, resourceaws.AwsS3BucketPolicyResourceType),
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.test, func(tt *testing.T) {
+			repo := &repository.MockS3Repository{}
+			c.mocks(repo)
+
+			err := aws.NewS3BucketPolicyEnumerator(repo, nil, tf.TerraformProviderConfig{DefaultAlias: "eu-west-1"}, nil).Probe()
+			assert.Equal(tt, c.wantErr, err)
+			repo.AssertExpectations(tt)
+		})
+	}
+}
@@ -74,5 +74,7 @@ func Init(version string, alerter alerter.AlerterInterface, providerLibrary *ter
 	remoteLibrary.AddEnumerator(NewAzurermImageEnumerator(computeRepo, factory))
 	remoteLibrary.AddEnumerator(NewAzurermSSHPublicKeyEnumerator(computeRepo, factory))
 
+	remoteLibrary.AddGenericDetailsFetchers(provider, resource.NewDeserializer(factory))
+
 	return nil
 }
@@ -0,0 +1,67 @@
+package common
+
+import (
+	remoteerror "github.com/khulnasoft-lab/driftctl/enumeration/remote/error"
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/enumeration/terraform"
+	"github.com/sirupsen/logrus"
+)
+
+// DetailsFetcher reads the full set of attributes of an enumerated resource.
+// It is only used when driftctl runs in deep mode.
+type DetailsFetcher interface {
+	ReadDetails(*resource.Resource) (*resource.Resource, error)
+}
+
+// GenericDetailsFetcher reads resource details using the terraform provider ReadResource call
+type GenericDetailsFetcher struct {
+	resType      resource.ResourceType
+	reader       terraform.ResourceReader
+	deserializer *resource.Deserializer
+}
+
+func NewGenericDetailsFetcher(resType resource.ResourceType, provider terraform.ResourceReader, deserializer *resource.Deserializer) *GenericDetailsFetcher {
+	return &GenericDetailsFetcher{
+		resType:      resType,
+		reader:       provider,
+		deserializer: deserializer,
+	}
+}
+
+func (f *GenericDetailsFetcher) ReadDetails(res *resource.Resource) (*resource.Resource, error) {
+	ctyVal, err := f.reader.ReadResource(terraform.ReadResourceArgs{
+		Ty:         f.resType,
+		ID:         res.ResourceId(),
+		Attributes: readAttributes(res),
+	})
+	if err != nil {
+		return nil, remoteerror.NewResourceScanningError(err, res.ResourceType(), res.ResourceId())
+	}
+	if ctyVal == nil || ctyVal.IsNull() {
+		logrus.WithFields(logrus.Fields{
+			"type": f.resType,
+			"id":   res.ResourceId(),
+		}).Debug("Got null while reading resource details")
+		return nil, nil
+	}
+	deserializedRes, err := f.deserializer.DeserializeOne(string(f.resType), *ctyVal)
+	if err != nil {
+		return nil, err
+	}
+	return deserializedRes, nil
+}
+
+// readAttributes returns string attributes set by the enumerator (e.g. the provider alias or a parent id).
+// Terraform providers rely on those to be able to read the resource.
+func readAttributes(res *resource.Resource) map[string]string {
+	attributes := map[string]string{}
+	if res.Attributes() == nil {
+		return attributes
+	}
+	for key, value := range *res.Attributes() {
+		if str, ok := value.(string); ok {
+			attributes[key] = str
+		}
+	}
+	return attributes
+}
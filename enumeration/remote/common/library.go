@@ -2,6 +2,7 @@ package common
 
 import (
 	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/enumeration/terraform"
 )
 
 type Enumerator interface {
@@ -9,13 +10,21 @@ type Enumerator interface {
 	Enumerate() ([]*resource.Resource, error)
 }
 
+// Prober is implemented by enumerators able to check they can list their resources without going through all of them,
+// e.g. with a single page of results. Probe returns the errors Enumerate would return.
+type Prober interface {
+	Probe() error
+}
+
 type RemoteLibrary struct {
-	enumerators []Enumerator
+	enumerators     []Enumerator
+	detailsFetchers map[resource.ResourceType]DetailsFetcher
 }
 
 func NewRemoteLibrary() *RemoteLibrary {
 	return &RemoteLibrary{
 		make([]Enumerator, 0),
+		make(map[resource.ResourceType]DetailsFetcher),
 	}
 }
 
@@ -26,3 +35,23 @@ func (r *RemoteLibrary) AddEnumerator(enumerator Enumerator) {
 func (r *RemoteLibrary) Enumerators() []Enumerator {
 	return r.enumerators
 }
+
+func (r *RemoteLibrary) AddDetailsFetcher(ty resource.ResourceType, detailsFetcher DetailsFetcher) {
+	r.detailsFetchers[ty] = detailsFetcher
+}
+
+func (r *RemoteLibrary) GetDetailsFetcher(ty resource.ResourceType) DetailsFetcher {
+	return r.detailsFetchers[ty]
+}
+
+// AddGenericDetailsFetchers registers a GenericDetailsFetcher backed by the given provider
+// for every enumerated type that does not have a details fetcher yet
+func (r *RemoteLibrary) AddGenericDetailsFetchers(provider terraform.ResourceReader, deserializer *resource.Deserializer) {
+	for _, enumerator := range r.enumerators {
+		ty := enumerator.SupportedType()
+		if r.GetDetailsFetcher(ty) != nil {
+			continue
+		}
+		r.AddDetailsFetcher(ty, NewGenericDetailsFetcher(ty, provider, deserializer))
+	}
+}
@@ -40,5 +40,7 @@ func Init(version string, alerter alerter.AlerterInterface, providerLibrary *ter
 
 	remoteLibrary.AddEnumerator(NewGithubBranchProtectionEnumerator(repository, factory))
 
+	remoteLibrary.AddGenericDetailsFetchers(provider, resource.NewDeserializer(factory))
+
 	return nil
 }
@@ -97,5 +97,7 @@ func Init(version string, alerter alerter.AlerterInterface, providerLibrary *ter
 	remoteLibrary.AddEnumerator(NewGoogleComputeGlobalForwardingRuleEnumerator(assetRepository, factory))
 	remoteLibrary.AddEnumerator(NewGoogleComputeSslCertificateEnumerator(assetRepository, factory))
 
+	remoteLibrary.AddGenericDetailsFetchers(provider, resource.NewDeserializer(factory))
+
 	return nil
 }
@@ -89,3 +89,34 @@ func shouldHandleGoogleForbiddenError(err *remoteerror.ResourceScanningError) bo
 
 	return false
 }
+
+func HandleResourceDetailsFetchingError(err error, alerter alerter.AlerterInterface) error {
+	listError, ok := err.(*remoteerror.ResourceScanningError)
+	if !ok {
+		return err
+	}
+
+	rootCause := listError.RootCause()
+
+	if _, ok := rootCause.(interface{ GRPCStatus() *status.Status }); ok {
+		if status.Convert(rootCause).Code() == codes.PermissionDenied {
+			alerts.SendDetailsFetchingAlert(common.RemoteGoogleTerraform, alerter, listError)
+			return nil
+		}
+		return err
+	}
+
+	if shouldHandleGoogleForbiddenError(listError) {
+		alerts.SendDetailsFetchingAlert(common.RemoteGoogleTerraform, alerter, listError)
+		return nil
+	}
+
+	// This handles access denied errors like the following:
+	// aws_s3_bucket_policy: AccessDenied: Error listing bucket policy <policy_name>
+	if strings.Contains(rootCause.Error(), "AccessDenied") {
+		alerts.SendDetailsFetchingAlert(common.RemoteAWSTerraform, alerter, listError)
+		return nil
+	}
+
+	return err
+}
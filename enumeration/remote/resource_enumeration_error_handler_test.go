@@ -162,6 +162,51 @@ func TestHandleGoogleEnumerationErrors(t *testing.T) {
 	}
 }
 
+func TestHandleDetailsFetchingErrors(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		err        error
+		wantAlerts alerter.Alerts
+		wantErr    bool
+	}{
+		{
+			name:       "Handled AWS AccessDenied error",
+			err:        remoteerr.NewResourceScanningError(errors.New("Error: AccessDenied: 403 ..."), resourceaws.AwsS3BucketResourceType, "my-bucket"),
+			wantAlerts: alerter.Alerts{"aws_s3_bucket.my-bucket": []alerter.Alert{alerts.NewRemoteAccessDeniedAlert(common.RemoteAWSTerraform, remoteerr.NewResourceScanningError(errors.New("Error: AccessDenied: 403 ..."), "aws_s3_bucket", "my-bucket"), alerts.DetailsFetchingPhase)}},
+			wantErr:    false,
+		},
+		{
+			name:       "Handled Google permission denied error",
+			err:        remoteerr.NewResourceScanningError(status.Error(codes.PermissionDenied, "The caller does not have permission"), "google_storage_bucket", "my-bucket"),
+			wantAlerts: alerter.Alerts{"google_storage_bucket.my-bucket": []alerter.Alert{alerts.NewRemoteAccessDeniedAlert(common.RemoteGoogleTerraform, remoteerr.NewResourceScanningError(status.Error(codes.PermissionDenied, "The caller does not have permission"), "google_storage_bucket", "my-bucket"), alerts.DetailsFetchingPhase)}},
+			wantErr:    false,
+		},
+		{
+			name:       "Not Handled error type",
+			err:        errors.New("error"),
+			wantAlerts: map[string][]alerter.Alert{},
+			wantErr:    true,
+		},
+		{
+			name:       "Not Handled root error type",
+			err:        remoteerr.NewResourceScanningError(errors.New("error"), resourceaws.AwsS3BucketResourceType, "my-bucket"),
+			wantAlerts: map[string][]alerter.Alert{},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alertr := alerter.NewAlerter()
+			gotErr := HandleResourceDetailsFetchingError(tt.err, alertr)
+			assert.Equal(t, tt.wantErr, gotErr != nil)
+
+			retrieve := alertr.Retrieve()
+			assert.Equal(t, tt.wantAlerts, retrieve)
+		})
+	}
+}
+
 func TestEnumerationAccessDeniedAlert_GetProviderMessage(t *testing.T) {
 	tests := []struct {
 		name     string
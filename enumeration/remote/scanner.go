@@ -13,19 +13,31 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+type ScannerOptions struct {
+	Deep bool
+}
+
 type Scanner struct {
-	enumeratorRunner *parallel.ParallelRunner
-	remoteLibrary    *common.RemoteLibrary
-	alerter          alerter.AlerterInterface
-	filter           enumeration.Filter
+	enumeratorRunner     *parallel.ParallelRunner
+	detailsFetcherRunner *parallel.ParallelRunner
+	remoteLibrary        *common.RemoteLibrary
+	alerter              alerter.AlerterInterface
+	options              ScannerOptions
+	filter               enumeration.Filter
 }
 
 func NewScanner(remoteLibrary *common.RemoteLibrary, alerter alerter.AlerterInterface, filter enumeration.Filter) *Scanner {
+	return NewScannerWithOptions(remoteLibrary, alerter, ScannerOptions{}, filter)
+}
+
+func NewScannerWithOptions(remoteLibrary *common.RemoteLibrary, alerter alerter.AlerterInterface, options ScannerOptions, filter enumeration.Filter) *Scanner {
 	return &Scanner{
-		enumeratorRunner: parallel.NewParallelRunner(context.TODO(), 10),
-		remoteLibrary:    remoteLibrary,
-		alerter:          alerter,
-		filter:           filter,
+		enumeratorRunner:     parallel.NewParallelRunner(context.TODO(), 10),
+		detailsFetcherRunner: parallel.NewParallelRunner(context.TODO(), 10),
+		remoteLibrary:        remoteLibrary,
+		alerter:              alerter,
+		options:              options,
+		filter:               filter,
 	}
 }
 
@@ -87,7 +99,43 @@ func (s *Scanner) scan() ([]*resource.Resource, error) {
 		return nil, err
 	}
 
-	return enumerationResult, nil
+	if !s.options.Deep {
+		return enumerationResult, nil
+	}
+
+	return s.fetchDetails(enumerationResult)
+}
+
+// fetchDetails reads the full attributes of each enumerated resource.
+// When details cannot be read, the enumerated resource is kept as is.
+func (s *Scanner) fetchDetails(enumerationResult []*resource.Resource) ([]*resource.Resource, error) {
+	for _, res := range enumerationResult {
+		res := res
+		s.detailsFetcherRunner.Run(func() (interface{}, error) {
+			fetcher := s.remoteLibrary.GetDetailsFetcher(resource.ResourceType(res.ResourceType()))
+			if fetcher == nil {
+				return []*resource.Resource{res}, nil
+			}
+
+			resourceWithDetails, err := fetcher.ReadDetails(res)
+			if err != nil {
+				if err := HandleResourceDetailsFetchingError(err, s.alerter); err != nil {
+					return nil, err
+				}
+				return []*resource.Resource{res}, nil
+			}
+			if resourceWithDetails == nil {
+				logrus.WithFields(logrus.Fields{
+					"id":   res.ResourceId(),
+					"type": res.ResourceType(),
+				}).Debug("Resource details are empty, keeping enumerated resource")
+				return []*resource.Resource{res}, nil
+			}
+			return []*resource.Resource{resourceWithDetails}, nil
+		})
+	}
+
+	return s.retrieveRunnerResults(s.detailsFetcherRunner)
 }
 
 func (s *Scanner) Resources() ([]*resource.Resource, error) {
@@ -101,4 +149,5 @@ func (s *Scanner) Resources() ([]*resource.Resource, error) {
 func (s *Scanner) Stop() {
 	logrus.Debug("Stopping scanner")
 	s.enumeratorRunner.Stop(errors.New("interrupted"))
+	s.detailsFetcherRunner.Stop(errors.New("interrupted"))
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestScannerShouldIgnoreType(t *testing.T) {
@@ -31,3 +32,66 @@ func TestScannerShouldIgnoreType(t *testing.T) {
 	assert.Nil(t, err)
 	fakeEnumerator.AssertExpectations(t)
 }
+
+func TestScannerShouldReadDetailsInDeepMode(t *testing.T) {
+	alerter := alerter.NewAlerter()
+	enumeratedRes := &resource.Resource{
+		Id:   "fake-id",
+		Type: "FakeType",
+	}
+	detailedRes := &resource.Resource{
+		Id:   "fake-id",
+		Type: "FakeType",
+		Attrs: &resource.Attributes{
+			"name": "fake",
+		},
+	}
+
+	fakeEnumerator := &common.MockEnumerator{}
+	fakeEnumerator.On("SupportedType").Return(resource.ResourceType("FakeType"))
+	fakeEnumerator.On("Enumerate").Return([]*resource.Resource{enumeratedRes}, nil)
+
+	fakeDetailsFetcher := &common.MockDetailsFetcher{}
+	fakeDetailsFetcher.On("ReadDetails", enumeratedRes).Return(detailedRes, nil)
+
+	remoteLibrary := common.NewRemoteLibrary()
+	remoteLibrary.AddEnumerator(fakeEnumerator)
+	remoteLibrary.AddDetailsFetcher("FakeType", fakeDetailsFetcher)
+
+	testFilter := &enumeration.MockFilter{}
+	testFilter.On("IsTypeIgnored", resource.ResourceType("FakeType")).Return(false)
+
+	s := NewScannerWithOptions(remoteLibrary, alerter, ScannerOptions{Deep: true}, testFilter)
+	resources, err := s.Resources()
+	assert.Nil(t, err)
+	assert.Equal(t, []*resource.Resource{detailedRes}, resources)
+	fakeEnumerator.AssertExpectations(t)
+	fakeDetailsFetcher.AssertExpectations(t)
+}
+
+func TestScannerShouldNotReadDetailsWithoutDeepMode(t *testing.T) {
+	alerter := alerter.NewAlerter()
+	enumeratedRes := &resource.Resource{
+		Id:   "fake-id",
+		Type: "FakeType",
+	}
+
+	fakeEnumerator := &common.MockEnumerator{}
+	fakeEnumerator.On("SupportedType").Return(resource.ResourceType("FakeType"))
+	fakeEnumerator.On("Enumerate").Return([]*resource.Resource{enumeratedRes}, nil)
+
+	fakeDetailsFetcher := &common.MockDetailsFetcher{}
+
+	remoteLibrary := common.NewRemoteLibrary()
+	remoteLibrary.AddEnumerator(fakeEnumerator)
+	remoteLibrary.AddDetailsFetcher("FakeType", fakeDetailsFetcher)
+
+	testFilter := &enumeration.MockFilter{}
+	testFilter.On("IsTypeIgnored", resource.ResourceType("FakeType")).Return(false)
+
+	s := NewScanner(remoteLibrary, alerter, testFilter)
+	resources, err := s.Resources()
+	assert.Nil(t, err)
+	assert.Equal(t, []*resource.Resource{enumeratedRes}, resources)
+	fakeDetailsFetcher.AssertNotCalled(t, "ReadDetails", mock.Anything)
+}
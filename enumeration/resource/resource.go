@@ -16,12 +16,38 @@ type Source interface {
 	InternalName() string
 }
 
+const TerraformStateSourceType = "terraform_state"
+
 type SerializableSource struct {
+	// Type is used as a discriminator to deserialize the right kind of source,
+	// an empty type means the source was serialized before the introduction of the discriminator
+	Type string `json:"type,omitempty"`
 	S    string `json:"source"`
 	Ns   string `json:"namespace"`
 	Name string `json:"internal_name"`
 }
 
+func NewSerializableSource(src Source) *SerializableSource {
+	s := &SerializableSource{
+		S:    src.Source(),
+		Ns:   src.Namespace(),
+		Name: src.InternalName(),
+	}
+	switch src.(type) {
+	case *TerraformStateSource:
+		s.Type = TerraformStateSourceType
+	}
+	return s
+}
+
+func (s *SerializableSource) Src() (Source, error) {
+	switch s.Type {
+	case "", TerraformStateSourceType:
+		return NewTerraformStateSource(s.S, s.Ns, s.Name), nil
+	}
+	return nil, errors.Errorf("unsupported source type %s", s.Type)
+}
+
 type TerraformStateSource struct {
 	State  string
 	Module string
@@ -103,16 +129,15 @@ type SerializableResource struct {
 	Type               string              `json:"type"`
 	ReadableAttributes map[string]string   `json:"human_readable_attributes,omitempty"`
 	Source             *SerializableSource `json:"source,omitempty"`
+	Attributes         *Attributes         `json:"attributes,omitempty"`
 }
 
+// NewSerializableResource serializes a resource without its attributes, use NewSerializableResourceWithAttributes
+// to keep them
 func NewSerializableResource(res *Resource) *SerializableResource {
 	var src *SerializableSource
 	if res.Src() != nil {
-		src = &SerializableSource{
-			S:    res.Src().Source(),
-			Ns:   res.Src().Namespace(),
-			Name: res.Src().InternalName(),
-		}
+		src = NewSerializableSource(res.Src())
 	}
 	return &SerializableResource{
 		Id:                 res.ResourceId(),
@@ -122,6 +147,38 @@ func NewSerializableResource(res *Resource) *SerializableResource {
 	}
 }
 
+func NewSerializableResourceWithAttributes(res *Resource) *SerializableResource {
+	s := NewSerializableResource(res)
+	s.Attributes = res.Attributes()
+	return s
+}
+
+// Resource rebuilds a resource from its serialized form.
+// Human readable attributes are kept through a schema as the resource type schema is not available.
+func (s *SerializableResource) Resource() (*Resource, error) {
+	res := &Resource{
+		Id:    s.Id,
+		Type:  s.Type,
+		Attrs: s.Attributes,
+	}
+	if s.Source != nil {
+		src, err := s.Source.Src()
+		if err != nil {
+			return nil, err
+		}
+		res.Source = src
+	}
+	if len(s.ReadableAttributes) > 0 {
+		readableAttributes := s.ReadableAttributes
+		res.Sch = &Schema{
+			HumanReadableAttributesFunc: func(*Resource) map[string]string {
+				return readableAttributes
+			},
+		}
+	}
+	return res, nil
+}
+
 func formatReadableAttributes(res *Resource) map[string]string {
 	if res.Schema() == nil || res.Schema().HumanReadableAttributesFunc == nil {
 		return map[string]string{}
@@ -76,6 +76,9 @@ func run() int {
 		if _, isNotInSync := err.(cmderrors.InfrastructureNotInSync); isNotInSync {
 			return scan.EXIT_NOT_IN_SYNC
 		}
+		if violation, isViolation := err.(cmderrors.PolicyViolation); isViolation {
+			return violation.ExitCode
+		}
 		if cmd.IsReportingEnabled(&driftctlCmd.Command) {
 			sentry.CaptureException(err)
 		}
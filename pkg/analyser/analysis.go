@@ -3,53 +3,160 @@ package analyser
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
+	"github.com/pkg/errors"
+	"github.com/r3labs/diff/v2"
 
 	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
 )
 
+// JSONVersion is the version of the JSON document written for an analysis.
+// Documents without any version were written before versioning was introduced: every source is a Terraform state
+// and alerts only carry a message. They are upgraded transparently when read.
+const JSONVersion = 2
+
+type Change struct {
+	diff.Change
+	Computed   bool `json:"computed"`
+	JsonString bool `json:"-"`
+}
+
+type Changelog []Change
+
+type Difference struct {
+	Res       *resource.Resource
+	Changelog Changelog
+}
+
+type SerializableDifference struct {
+	Res       resource.SerializableResource `json:"res"`
+	Changelog Changelog                     `json:"changelog"`
+}
+
+// Duplicate is a cloud resource claimed by more than one Terraform state resource
+type Duplicate struct {
+	Res     *resource.Resource
+	Sources []resource.Source
+}
+
+type SerializableDuplicate struct {
+	Res     resource.SerializableResource `json:"res"`
+	Sources []resource.SerializableSource `json:"sources"`
+}
+
 type Summary struct {
 	TotalResources      int  `json:"total_resources"`
 	TotalUnmanaged      int  `json:"total_unmanaged"`
 	TotalDeleted        int  `json:"total_missing"`
 	TotalManaged        int  `json:"total_managed"`
+	TotalDrifted        int  `json:"total_changed,omitempty"`
+	TotalDuplicated     int  `json:"total_duplicated,omitempty"`
 	TotalIaCSourceCount uint `json:"total_iac_source_count"`
+	// Coverage breakdowns are computed from the resources of the analysis once it is complete
+	CoverageByType    []CoverageBreakdown `json:"coverage_by_type,omitempty"`
+	CoverageByService []CoverageBreakdown `json:"coverage_by_service,omitempty"`
+	CoverageBySource  []CoverageBreakdown `json:"coverage_by_source,omitempty"`
+	// StatesWithoutResources are IaC sources that do not manage any resource, either because the state is empty or
+	// because every resource it contains is missing on the cloud provider
+	StatesWithoutResources []string `json:"states_without_resources,omitempty"`
 }
 
 type Analysis struct {
-	unmanaged       []*resource.Resource
-	managed         []*resource.Resource
-	deleted         []*resource.Resource
-	summary         Summary
-	alerts          alerter.Alerts
-	Duration        time.Duration
-	Date            time.Time
-	ProviderName    string
-	ProviderVersion string
+	unmanaged        []*resource.Resource
+	managed          []*resource.Resource
+	deleted          []*resource.Resource
+	differences      []Difference
+	duplicates       []Duplicate
+	risks            []Risk
+	explanations     []Explanation
+	baseline         *BaselineComparison
+	policy           *PolicyEvaluation
+	options          AnalyzerOptions
+	withAttributes   bool
+	summary          Summary
+	coverageOutdated bool
+	emptyIaCSources  []string
+	alerts           alerter.Alerts
+	providers        []Provider
+	Duration         time.Duration
+	Date             time.Time
+	ProviderName     string
+	ProviderVersion  string
 }
 
 type serializableAnalysis struct {
+	Version         int                                    `json:"version"`
 	Summary         Summary                                `json:"summary"`
 	Managed         []resource.SerializableResource        `json:"managed"`
 	Unmanaged       []resource.SerializableResource        `json:"unmanaged"`
 	Deleted         []resource.SerializableResource        `json:"missing"`
+	Differences     []SerializableDifference               `json:"differences,omitempty"`
+	Duplicates      []SerializableDuplicate                `json:"duplicates,omitempty"`
+	Risks           []SerializableRisk                     `json:"risks,omitempty"`
 	Coverage        int                                    `json:"coverage"`
 	Alerts          map[string][]alerter.SerializableAlert `json:"alerts"`
 	ProviderName    string                                 `json:"provider_name"`
 	ProviderVersion string                                 `json:"provider_version"`
+	Providers       []ProviderSummary                      `json:"providers,omitempty"`
 	ScanDuration    uint                                   `json:"scan_duration,omitempty"`
 	Date            time.Time                              `json:"date"`
+	Options         *AnalyzerOptions                       `json:"options,omitempty"`
+	Baseline        *serializableBaselineComparison        `json:"baseline,omitempty"`
+	Policy          *PolicyEvaluation                      `json:"policy,omitempty"`
+	Explanations    []Explanation                          `json:"explanations,omitempty"`
 }
 
 type GenDriftIgnoreOptions struct {
 	ExcludeUnmanaged bool
 	ExcludeDeleted   bool
 	ExcludeDrifted   bool
-	InputPath        string
-	OutputPath       string
+	// Types and Sources restrict the rules to the resources whose type or IaC source matches one of the patterns
+	Types   []string
+	Sources []string
+	// WildcardThreshold replaces the rules of a type by a wildcard when there are at least this many, 0 disables it
+	WildcardThreshold int
+	InputPath         string
+	OutputPath        string
+}
+
+func (o GenDriftIgnoreOptions) selects(res *resource.Resource) bool {
+	if len(o.Types) > 0 && !matchAny(o.Types, res.ResourceType()) {
+		return false
+	}
+	if len(o.Sources) > 0 && (res.Src() == nil || !matchAny(o.Sources, res.Src().Source())) {
+		return false
+	}
+	return true
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if match, _ := filepath.Match(pattern, value); match {
+			return true
+		}
+	}
+	return false
+}
+
+// DriftIgnoreSection holds the generated driftignore rules of a resource type
+type DriftIgnoreSection struct {
+	Type  string
+	Rules []string
+	// Wildcard tells the rules are replaced by TYPE.*, see GenDriftIgnoreOptions.WildcardThreshold
+	Wildcard bool
+}
+
+// Lines returns the section comment followed by the rules of the section
+func (s DriftIgnoreSection) Lines() []string {
+	if s.Wildcard {
+		return []string{fmt.Sprintf("# %s", s.Type), fmt.Sprintf("%s.*", s.Type)}
+	}
+	return append([]string{fmt.Sprintf("# %s", s.Type)}, s.Rules...)
 }
 
 func NewAnalysis() *Analysis {
@@ -57,16 +164,32 @@ func NewAnalysis() *Analysis {
 }
 
 func (a Analysis) MarshalJSON() ([]byte, error) {
-	bla := serializableAnalysis{}
+	bla := serializableAnalysis{Version: JSONVersion}
+	serialize := resource.NewSerializableResource
+	if a.withAttributes {
+		serialize = resource.NewSerializableResourceWithAttributes
+	}
 	for _, m := range a.managed {
-		bla.Managed = append(bla.Managed, *resource.NewSerializableResource(m))
+		bla.Managed = append(bla.Managed, *serialize(m))
 	}
 	for _, u := range a.unmanaged {
-		bla.Unmanaged = append(bla.Unmanaged, *resource.NewSerializableResource(u))
+		bla.Unmanaged = append(bla.Unmanaged, *serialize(u))
 	}
 	for _, d := range a.deleted {
-		bla.Deleted = append(bla.Deleted, *resource.NewSerializableResource(d))
+		bla.Deleted = append(bla.Deleted, *serialize(d))
+	}
+	for _, di := range a.differences {
+		difference := newSerializableDifference(di)
+		difference.Res = *serialize(di.Res)
+		bla.Differences = append(bla.Differences, difference)
+	}
+	for _, du := range a.duplicates {
+		bla.Duplicates = append(bla.Duplicates, newSerializableDuplicate(du))
+	}
+	for _, ri := range a.risks {
+		bla.Risks = append(bla.Risks, newSerializableRisk(ri))
 	}
+	bla.Explanations = a.explanations
 	if len(a.alerts) > 0 {
 		bla.Alerts = make(map[string][]alerter.SerializableAlert)
 		for k, v := range a.alerts {
@@ -75,12 +198,26 @@ func (a Analysis) MarshalJSON() ([]byte, error) {
 			}
 		}
 	}
-	bla.Summary = a.summary
+	bla.Summary = a.Summary()
 	bla.Coverage = a.Coverage()
 	bla.ProviderName = a.ProviderName
 	bla.ProviderVersion = a.ProviderVersion
+	if len(a.providers) > 0 {
+		bla.Providers = a.Providers()
+	}
 	bla.ScanDuration = uint(a.Duration.Seconds())
 	bla.Date = a.Date
+	if a.options.Deep {
+		bla.Options = &a.options
+	}
+	if a.baseline != nil {
+		bla.Baseline = &serializableBaselineComparison{
+			New:        newSerializableFindings(a.baseline.New),
+			Persisting: newSerializableFindings(a.baseline.Persisting),
+			Resolved:   newSerializableFindings(a.baseline.Resolved),
+		}
+	}
+	bla.Policy = a.policy
 
 	return json.Marshal(bla)
 }
@@ -90,74 +227,166 @@ func (a *Analysis) UnmarshalJSON(bytes []byte) error {
 	if err := json.Unmarshal(bytes, &bla); err != nil {
 		return err
 	}
+	if bla.Version > JSONVersion {
+		return errors.Errorf("unsupported analysis version %d, this version of driftctl can only read analysis up to version %d", bla.Version, JSONVersion)
+	}
+	readResource := func(serialized resource.SerializableResource) (*resource.Resource, error) {
+		// Attributes are only written when requested, keep them if the analysis is written again
+		if serialized.Attributes != nil {
+			a.withAttributes = true
+		}
+		return serialized.Resource()
+	}
 	for _, u := range bla.Unmanaged {
-		a.AddUnmanaged(&resource.Resource{
-			Id:   u.Id,
-			Type: u.Type,
-		})
+		res, err := readResource(u)
+		if err != nil {
+			return err
+		}
+		a.AddUnmanaged(res)
 	}
 	for _, d := range bla.Deleted {
-		a.AddDeleted(&resource.Resource{
-			Id:   d.Id,
-			Type: d.Type,
-		})
+		res, err := readResource(d)
+		if err != nil {
+			return err
+		}
+		a.AddDeleted(res)
 	}
 	for _, m := range bla.Managed {
-		res := &resource.Resource{
-			Id:   m.Id,
-			Type: m.Type,
-		}
-		if m.Source != nil {
-			// We loose the source type in the serialization process, for now everything is serialized back to a
-			// TerraformStateSource.
-			// TODO: Add a discriminator field to be able to serialize back to the right type
-			// when we'll introduce a new source type
-			res.Source = &resource.TerraformStateSource{
-				State:  m.Source.S,
-				Module: m.Source.Ns,
-				Name:   m.Source.Name,
-			}
+		res, err := readResource(m)
+		if err != nil {
+			return err
 		}
 		a.AddManaged(res)
 	}
+	for _, di := range bla.Differences {
+		difference, err := di.difference()
+		if err != nil {
+			return err
+		}
+		a.AddDifference(difference)
+	}
+	for _, du := range bla.Duplicates {
+		duplicate, err := du.duplicate()
+		if err != nil {
+			return err
+		}
+		a.AddDuplicate(duplicate)
+	}
+	for _, ri := range bla.Risks {
+		risk, err := ri.risk()
+		if err != nil {
+			return err
+		}
+		a.risks = append(a.risks, risk)
+	}
+	if bla.Options != nil {
+		a.SetOptions(*bla.Options)
+	}
+	if bla.Baseline != nil {
+		comparison, err := bla.Baseline.comparison()
+		if err != nil {
+			return err
+		}
+		a.baseline = comparison
+	}
+	a.policy = bla.Policy
+	a.explanations = bla.Explanations
 	if len(bla.Alerts) > 0 {
 		a.alerts = make(alerter.Alerts)
 		for k, v := range bla.Alerts {
 			for _, al := range v {
-				a.alerts[k] = append(a.alerts[k], &alerter.SerializedAlert{
-					Msg: al.Message(),
-				})
+				a.alerts[k] = append(a.alerts[k], al.Alert)
 			}
 		}
 	}
 	a.ProviderName = bla.ProviderName
 	a.ProviderVersion = bla.ProviderVersion
+	for _, p := range bla.Providers {
+		a.providers = append(a.providers, Provider{Name: p.Name, Type: p.Type, Version: p.Version})
+	}
 	a.SetIaCSourceCount(bla.Summary.TotalIaCSourceCount)
+	a.emptyIaCSources = bla.Summary.StatesWithoutResources
 	a.Duration = time.Duration(bla.ScanDuration) * time.Second
 	a.Date = bla.Date
+	a.computeCoverage()
 	return nil
 }
 
 func (a *Analysis) IsSync() bool {
-	return a.summary.TotalUnmanaged == 0 && a.summary.TotalDeleted == 0
+	return a.summary.TotalUnmanaged == 0 && a.summary.TotalDeleted == 0 && a.summary.TotalDrifted == 0 &&
+		a.summary.TotalDuplicated == 0
 }
 
 func (a *Analysis) AddDeleted(resources ...*resource.Resource) {
 	a.deleted = append(a.deleted, resources...)
 	a.summary.TotalResources += len(resources)
 	a.summary.TotalDeleted += len(resources)
+	a.coverageOutdated = true
 }
 
 func (a *Analysis) AddUnmanaged(resources ...*resource.Resource) {
 	a.unmanaged = append(a.unmanaged, resources...)
 	a.summary.TotalResources += len(resources)
 	a.summary.TotalUnmanaged += len(resources)
+	a.coverageOutdated = true
 }
 
 func (a *Analysis) AddManaged(resources ...*resource.Resource) {
 	a.managed = append(a.managed, resources...)
 	a.summary.TotalResources += len(resources)
 	a.summary.TotalManaged += len(resources)
+	a.coverageOutdated = true
+}
+
+func (a *Analysis) AddDifference(diffs ...Difference) {
+	a.differences = append(a.differences, diffs...)
+	a.summary.TotalDrifted += len(diffs)
+}
+
+func (a *Analysis) AddDuplicate(duplicates ...Duplicate) {
+	a.duplicates = append(a.duplicates, duplicates...)
+	a.summary.TotalDuplicated += len(duplicates)
+}
+
+// ApplyBaseline classifies findings of the analysis against the given reference analysis
+func (a *Analysis) ApplyBaseline(baseline *Analysis) {
+	a.baseline = NewBaselineComparison(a, baseline)
+}
+
+func (a *Analysis) SetOptions(options AnalyzerOptions) {
+	a.options = options
+}
+
+// SetRisks attaches the classification of the findings of the analysis, risks are sorted by severity
+func (a *Analysis) SetRisks(risks []Risk) {
+	a.risks = SortRisks(risks)
+}
+
+func (a *Analysis) Risks() []Risk {
+	return a.risks
+}
+
+// SetExplanations attaches the decision trail of the resources explained during the scan
+func (a *Analysis) SetExplanations(explanations []Explanation) {
+	a.explanations = explanations
+}
+
+func (a *Analysis) Explanations() []Explanation {
+	return a.explanations
+}
+
+// SetPolicyEvaluation attaches the result of a failure policy to the analysis so it can be reported by outputs
+func (a *Analysis) SetPolicyEvaluation(evaluation *PolicyEvaluation) {
+	a.policy = evaluation
+}
+
+func (a *Analysis) PolicyEvaluation() *PolicyEvaluation {
+	return a.policy
+}
+
+// SetWithAttributes includes the attributes of every resource when the analysis is serialized
+func (a *Analysis) SetWithAttributes(withAttributes bool) {
+	a.withAttributes = withAttributes
 }
 
 func (a *Analysis) SetAlerts(alerts alerter.Alerts) {
@@ -168,6 +397,15 @@ func (a *Analysis) SetIaCSourceCount(i uint) {
 	a.summary.TotalIaCSourceCount = i
 }
 
+// SetEmptyIaCSources records the IaC sources read without any resource, they are reported in the summary along with
+// the sources whose resources are all missing
+func (a *Analysis) SetEmptyIaCSources(sources []string) {
+	a.emptyIaCSources = sources
+	if !a.coverageOutdated {
+		a.computeCoverage()
+	}
+}
+
 func (a *Analysis) Coverage() int {
 	if a.summary.TotalResources > 0 {
 		return int((float32(a.summary.TotalManaged) / float32(a.summary.TotalResources)) * 100.0)
@@ -187,10 +425,47 @@ func (a *Analysis) Deleted() []*resource.Resource {
 	return a.deleted
 }
 
+func (a *Analysis) Differences() []Difference {
+	return a.differences
+}
+
+func (a *Analysis) Duplicates() []Duplicate {
+	return a.duplicates
+}
+
+// Baseline returns the comparison with the reference analysis, nil when no baseline was applied
+func (a *Analysis) Baseline() *BaselineComparison {
+	return a.baseline
+}
+
+// HasNewFindings returns true when the analysis reports drifts that are not in the applied baseline.
+// Without baseline, every drift is considered as new.
+func (a *Analysis) HasNewFindings() bool {
+	if a.baseline == nil {
+		return !a.IsSync()
+	}
+	return a.baseline.New.Count() > 0
+}
+
+func (a *Analysis) Options() AnalyzerOptions {
+	return a.options
+}
+
+// Summary returns the counters of the analysis. Coverage breakdowns are computed once the analysis is complete, they
+// are only computed on the fly for analyses still being built.
 func (a *Analysis) Summary() Summary {
+	if a.coverageOutdated {
+		return a.summary.withCoverageBreakdowns(a)
+	}
 	return a.summary
 }
 
+// computeCoverage stores the coverage breakdowns in the summary, it must be called once every resource is added
+func (a *Analysis) computeCoverage() {
+	a.summary = a.summary.withCoverageBreakdowns(a)
+	a.coverageOutdated = false
+}
+
 func (a *Analysis) Alerts() alerter.Alerts {
 	return a.alerts
 }
@@ -198,30 +473,166 @@ func (a *Analysis) Alerts() alerter.Alerts {
 func (a *Analysis) SortResources() {
 	a.unmanaged = resource.Sort(a.unmanaged)
 	a.deleted = resource.Sort(a.deleted)
+	a.differences = SortDifferences(a.differences)
+	a.duplicates = SortDuplicates(a.duplicates)
 }
 
-func (a *Analysis) DriftIgnoreList(opts GenDriftIgnoreOptions) (int, string) {
-	var list []string
+// DriftIgnoreSections returns the rules ignoring the findings of the analysis grouped by type, sorted by type, and the
+// number of resources they ignore
+func (a *Analysis) DriftIgnoreSections(opts GenDriftIgnoreOptions) (int, []DriftIgnoreSection) {
+	sections := make(map[string]*DriftIgnoreSection)
+	section := func(ty string) *DriftIgnoreSection {
+		s, exist := sections[ty]
+		if !exist {
+			s = &DriftIgnoreSection{Type: ty}
+			sections[ty] = s
+		}
+		return s
+	}
 
 	resourceCount := 0
 
 	addResources := func(res ...*resource.Resource) {
 		for _, r := range res {
-			list = append(list, fmt.Sprintf("%s.%s", r.ResourceType(), escapeKey(r.ResourceId())))
+			if !opts.selects(r) {
+				continue
+			}
+			s := section(r.ResourceType())
+			s.Rules = append(s.Rules, fmt.Sprintf("%s.%s", r.ResourceType(), escapeKey(r.ResourceId())))
+			resourceCount++
 		}
-		resourceCount += len(res)
 	}
 
-	if !opts.ExcludeUnmanaged && a.Summary().TotalUnmanaged > 0 {
-		list = append(list, "# Resources not covered by IaC")
+	if !opts.ExcludeUnmanaged {
 		addResources(a.Unmanaged()...)
 	}
-	if !opts.ExcludeDeleted && a.Summary().TotalDeleted > 0 {
-		list = append(list, "# Missing resources")
+	if !opts.ExcludeDeleted {
 		addResources(a.Deleted()...)
 	}
+	if !opts.ExcludeDrifted {
+		for _, d := range a.Differences() {
+			if !opts.selects(d.Res) {
+				continue
+			}
+			s := section(d.Res.ResourceType())
+			for _, c := range d.Changelog {
+				path := make([]string, 0, len(c.Path))
+				for _, p := range c.Path {
+					path = append(path, escapeKey(p))
+				}
+				s.Rules = append(s.Rules, fmt.Sprintf("%s.%s.%s", d.Res.ResourceType(), escapeKey(d.Res.ResourceId()), strings.Join(path, ".")))
+			}
+			resourceCount++
+		}
+	}
+
+	// A wildcard would ignore the managed resources of the type too, such types keep their rules
+	managedTypes := make(map[string]struct{})
+	for _, res := range a.Managed() {
+		managedTypes[res.ResourceType()] = struct{}{}
+	}
 
-	return resourceCount, strings.Join(list, "\n")
+	result := make([]DriftIgnoreSection, 0, len(sections))
+	for ty, s := range sections {
+		_, managed := managedTypes[ty]
+		s.Wildcard = opts.WildcardThreshold > 0 && len(s.Rules) >= opts.WildcardThreshold && !managed
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Type < result[j].Type
+	})
+
+	return resourceCount, result
+}
+
+// DriftIgnoreList returns the rules ignoring the findings of the analysis, see DriftIgnoreSections
+func (a *Analysis) DriftIgnoreList(opts GenDriftIgnoreOptions) (int, string) {
+	n, sections := a.DriftIgnoreSections(opts)
+	var list []string
+	for _, s := range sections {
+		list = append(list, s.Lines()...)
+	}
+	return n, strings.Join(list, "\n")
+}
+
+func SortDifferences(diffs []Difference) []Difference {
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Res.ResourceType() != diffs[j].Res.ResourceType() {
+			return diffs[i].Res.ResourceType() < diffs[j].Res.ResourceType()
+		}
+		return diffs[i].Res.ResourceId() < diffs[j].Res.ResourceId()
+	})
+
+	for _, d := range diffs {
+		SortChanges(d.Changelog)
+	}
+
+	return diffs
+}
+
+func SortDuplicates(duplicates []Duplicate) []Duplicate {
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		if duplicates[i].Res.ResourceType() != duplicates[j].Res.ResourceType() {
+			return duplicates[i].Res.ResourceType() < duplicates[j].Res.ResourceType()
+		}
+		return duplicates[i].Res.ResourceId() < duplicates[j].Res.ResourceId()
+	})
+	return duplicates
+}
+
+func SortChanges(changes []Change) []Change {
+	sort.SliceStable(changes, func(i, j int) bool {
+		return strings.Join(changes[i].Path, ".") < strings.Join(changes[j].Path, ".")
+	})
+	return changes
+}
+
+func newSerializableDifference(difference Difference) SerializableDifference {
+	return SerializableDifference{
+		Res:       *resource.NewSerializableResource(difference.Res),
+		Changelog: difference.Changelog,
+	}
+}
+
+func (s SerializableDifference) difference() (Difference, error) {
+	res, err := s.Res.Resource()
+	if err != nil {
+		return Difference{}, err
+	}
+	return Difference{
+		Res:       res,
+		Changelog: s.Changelog,
+	}, nil
+}
+
+func newSerializableDuplicate(duplicate Duplicate) SerializableDuplicate {
+	sources := make([]resource.SerializableSource, 0, len(duplicate.Sources))
+	for _, src := range duplicate.Sources {
+		sources = append(sources, *resource.NewSerializableSource(src))
+	}
+	return SerializableDuplicate{
+		Res:     *resource.NewSerializableResource(duplicate.Res),
+		Sources: sources,
+	}
+}
+
+func (s SerializableDuplicate) duplicate() (Duplicate, error) {
+	res, err := s.Res.Resource()
+	if err != nil {
+		return Duplicate{}, err
+	}
+	sources := make([]resource.Source, 0, len(s.Sources))
+	for _, serializedSource := range s.Sources {
+		src, err := serializedSource.Src()
+		if err != nil {
+			return Duplicate{}, err
+		}
+		sources = append(sources, src)
+	}
+	return Duplicate{
+		Res:     res,
+		Sources: sources,
+	}, nil
 }
 
 func escapeKey(line string) string {
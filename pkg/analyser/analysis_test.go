@@ -0,0 +1,126 @@
+package analyser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+func TestAnalysis_DriftIgnoreSections(t *testing.T) {
+	prodSource := resource.NewTerraformStateSource("s3://bucket/prod.tfstate", "", "main")
+	devSource := resource.NewTerraformStateSource("s3://bucket/dev.tfstate", "", "main")
+	bucket := &resource.Resource{Id: "logs.example.com", Type: "aws_s3_bucket", Source: prodSource}
+
+	analysis := NewAnalysis()
+	analysis.AddManaged(bucket)
+	analysis.AddUnmanaged(
+		&resource.Resource{Id: "bob", Type: "aws_iam_user"},
+		&resource.Resource{Id: "alice", Type: "aws_iam_user"},
+		&resource.Resource{Id: "assets", Type: "aws_s3_bucket"},
+	)
+	analysis.AddDeleted(
+		&resource.Resource{Id: "queue", Type: "aws_sqs_queue", Source: devSource},
+	)
+	analysis.AddDifference(Difference{Res: bucket, Changelog: Changelog{
+		{Change: diff.Change{Type: diff.UPDATE, Path: []string{"tags", "env.name"}, From: "prod", To: "dev"}},
+	}})
+
+	cases := []struct {
+		name     string
+		opts     GenDriftIgnoreOptions
+		count    int
+		expected []DriftIgnoreSection
+	}{
+		{
+			name:  "every finding grouped by type",
+			count: 5,
+			expected: []DriftIgnoreSection{
+				{Type: "aws_iam_user", Rules: []string{"aws_iam_user.bob", "aws_iam_user.alice"}},
+				{Type: "aws_s3_bucket", Rules: []string{"aws_s3_bucket.assets", `aws_s3_bucket.logs\.example\.com.tags.env\.name`}},
+				{Type: "aws_sqs_queue", Rules: []string{"aws_sqs_queue.queue"}},
+			},
+		},
+		{
+			name:  "wildcards for types without managed resources",
+			opts:  GenDriftIgnoreOptions{WildcardThreshold: 2},
+			count: 5,
+			expected: []DriftIgnoreSection{
+				{Type: "aws_iam_user", Rules: []string{"aws_iam_user.bob", "aws_iam_user.alice"}, Wildcard: true},
+				{Type: "aws_s3_bucket", Rules: []string{"aws_s3_bucket.assets", `aws_s3_bucket.logs\.example\.com.tags.env\.name`}},
+				{Type: "aws_sqs_queue", Rules: []string{"aws_sqs_queue.queue"}},
+			},
+		},
+		{
+			name:  "selected types without drifts",
+			opts:  GenDriftIgnoreOptions{Types: []string{"aws_s3_*", "aws_sqs_queue"}, ExcludeDrifted: true},
+			count: 2,
+			expected: []DriftIgnoreSection{
+				{Type: "aws_s3_bucket", Rules: []string{"aws_s3_bucket.assets"}},
+				{Type: "aws_sqs_queue", Rules: []string{"aws_sqs_queue.queue"}},
+			},
+		},
+		{
+			name:  "selected sources",
+			opts:  GenDriftIgnoreOptions{Sources: []string{"s3://bucket/prod*"}},
+			count: 1,
+			expected: []DriftIgnoreSection{
+				{Type: "aws_s3_bucket", Rules: []string{`aws_s3_bucket.logs\.example\.com.tags.env\.name`}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			count, sections := analysis.DriftIgnoreSections(c.opts)
+			assert.Equal(t, c.count, count)
+			assert.Equal(t, c.expected, sections)
+		})
+	}
+
+	_, list := analysis.DriftIgnoreList(GenDriftIgnoreOptions{WildcardThreshold: 2, ExcludeDrifted: true})
+	assert.Equal(t, "# aws_iam_user\naws_iam_user.*\n# aws_s3_bucket\naws_s3_bucket.assets\n# aws_sqs_queue\naws_sqs_queue.queue", list)
+}
+
+func TestAnalysis_Providers(t *testing.T) {
+	repository := &resource.Resource{Id: "driftctl", Type: "github_repository"}
+	analysis := NewAnalysis()
+	analysis.AddManaged(
+		&resource.Resource{Id: "logs", Type: "aws_s3_bucket"},
+		repository,
+	)
+	analysis.AddUnmanaged(
+		&resource.Resource{Id: "bob", Type: "aws_iam_user"},
+		&resource.Resource{Id: "alice", Type: "aws_iam_user"},
+		&resource.Resource{Id: "assets", Type: "google_storage_bucket"},
+	)
+	analysis.AddDeleted(&resource.Resource{Id: "docs", Type: "github_repository"})
+	analysis.AddDifference(Difference{Res: repository, Changelog: Changelog{
+		{Change: diff.Change{Type: diff.UPDATE, Path: []string{"description"}, From: "foo", To: "bar"}},
+	}})
+	analysis.SetProviders(
+		Provider{Name: "aws+tf", Type: "aws", Version: "3.19.0"},
+		Provider{Name: "github+tf", Type: "github", Version: "4.4.0"},
+	)
+
+	expected := []ProviderSummary{
+		{Name: "aws+tf", Type: "aws", Version: "3.19.0", TotalResources: 3, TotalUnmanaged: 2, TotalManaged: 1, Coverage: 33},
+		{Name: "github+tf", Type: "github", Version: "4.4.0", TotalResources: 2, TotalDeleted: 1, TotalManaged: 1, TotalDrifted: 1, Coverage: 50},
+	}
+	assert.Equal(t, expected, analysis.Providers())
+	assert.Equal(t, "aws+tf,github+tf", analysis.ProviderName)
+	assert.Equal(t, "3.19.0,4.4.0", analysis.ProviderVersion)
+
+	serialized, err := json.Marshal(analysis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := NewAnalysis()
+	if err := json.Unmarshal(serialized, got); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, expected, got.Providers())
+}
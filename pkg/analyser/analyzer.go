@@ -4,6 +4,7 @@ import (
 	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
 	resourceaws "github.com/khulnasoft-lab/driftctl/enumeration/resource/aws"
 	"github.com/khulnasoft-lab/driftctl/pkg/filter"
+	"github.com/r3labs/diff/v2"
 
 	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
 )
@@ -44,17 +45,24 @@ func (c *ComputedDiffAlert) Resource() *resource.Resource {
 	return nil
 }
 
+type AnalyzerOptions struct {
+	// Deep enables the comparison of state attributes against remote ones
+	Deep bool `json:"deep"`
+}
+
 type Analyzer struct {
 	alerter *alerter.Alerter
+	options AnalyzerOptions
 	filter  filter.Filter
 }
 
-func NewAnalyzer(alerter *alerter.Alerter, filter filter.Filter) *Analyzer {
-	return &Analyzer{alerter, filter}
+func NewAnalyzer(alerter *alerter.Alerter, options AnalyzerOptions, filter filter.Filter) *Analyzer {
+	return &Analyzer{alerter, options, filter}
 }
 
 func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resource) (Analysis, error) {
 	analysis := Analysis{}
+	analysis.SetOptions(a.options)
 
 	// Iterate on remote resources and filter ignored resources
 	filteredRemoteResource := make([]*resource.Resource, 0, len(remoteResources))
@@ -65,25 +73,70 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 		filteredRemoteResource = append(filteredRemoteResource, remoteRes)
 	}
 
+	matcher := newResourceMatcher(filteredRemoteResource)
+	// State resources claiming each matched remote resource
+	owners := make(map[*resource.Resource][]*resource.Resource)
+
 	haveComputedDiff := false
 	for _, stateRes := range resourcesFromState {
-		i, _, found := findCorrespondingRes(filteredRemoteResource, stateRes)
-
 		if a.filter.IsResourceIgnored(stateRes) || a.alerter.IsResourceIgnored(stateRes) {
 			continue
 		}
 
+		// Matched resources are consumed, so it will remain only unmanaged ones
+		remoteRes, found := matcher.Match(stateRes)
 		if !found {
+			// The remote resource may already be managed from another source, this is not a missing resource
+			if claimedRes, claimed := matcher.MatchConsumed(stateRes); claimed && isOwnedFromAnotherSource(owners[claimedRes], stateRes) {
+				owners[claimedRes] = append(owners[claimedRes], stateRes)
+				continue
+			}
 			analysis.AddDeleted(stateRes)
 			continue
 		}
+		owners[remoteRes] = []*resource.Resource{stateRes}
 
-		// Remove managed resources, so it will remain only unmanaged ones
-		filteredRemoteResource = removeResourceByIndex(i, filteredRemoteResource)
 		analysis.AddManaged(stateRes)
+
+		if !a.options.Deep {
+			continue
+		}
+
+		changelog, err := a.compare(stateRes, remoteRes)
+		if err != nil {
+			return analysis, err
+		}
+		if len(changelog) > 0 {
+			for _, change := range changelog {
+				if change.Computed {
+					haveComputedDiff = true
+					break
+				}
+			}
+			analysis.AddDifference(Difference{
+				Res:       stateRes,
+				Changelog: changelog,
+			})
+		}
+	}
+
+	for _, remoteRes := range filteredRemoteResource {
+		if len(owners[remoteRes]) < 2 {
+			continue
+		}
+		sources := make([]resource.Source, 0, len(owners[remoteRes]))
+		for _, owner := range owners[remoteRes] {
+			sources = append(sources, owner.Src())
+		}
+		analysis.AddDuplicate(Duplicate{
+			Res:     remoteRes,
+			Sources: sources,
+		})
 	}
 
-	if a.hasUnmanagedSecurityGroupRules(filteredRemoteResource) {
+	unmanagedResources := matcher.Unmatched()
+
+	if a.hasUnmanagedSecurityGroupRules(unmanagedResources) {
 		a.alerter.SendAlert("", newUnmanagedSecurityGroupRulesAlert())
 	}
 
@@ -92,31 +145,40 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 	}
 
 	// Add remaining unmanaged resources
-	analysis.AddUnmanaged(filteredRemoteResource...)
+	analysis.AddUnmanaged(unmanagedResources...)
 
 	// Sort resources by Terraform Id
 	// The purpose is to have a predictable output
 	analysis.SortResources()
+	analysis.computeCoverage()
 
 	analysis.SetAlerts(a.alerter.Retrieve())
 
 	return analysis, nil
 }
 
-func findCorrespondingRes(resources []*resource.Resource, res *resource.Resource) (int, *resource.Resource, bool) {
-	for i, r := range resources {
-		if res.Equal(r) {
-			return i, r, true
-		}
+// compare returns the changes needed to go from the state attributes to the remote ones,
+// skipping fields ignored by the filter
+func (a Analyzer) compare(stateRes, remoteRes *resource.Resource) (Changelog, error) {
+	delta, err := diff.Diff(stateRes.Attributes(), remoteRes.Attributes())
+	if err != nil {
+		return nil, err
 	}
-	return -1, nil, false
-}
 
-func removeResourceByIndex(i int, resources []*resource.Resource) []*resource.Resource {
-	if i == len(resources)-1 {
-		return resources[:len(resources)-1]
+	changelog := make(Changelog, 0, len(delta))
+	for _, change := range delta {
+		if a.filter.IsFieldIgnored(stateRes, change.Path) {
+			continue
+		}
+		c := Change{Change: change}
+		if stateRes.Schema() != nil {
+			c.Computed = stateRes.Schema().IsComputedField(c.Path)
+			c.JsonString = stateRes.Schema().IsJsonStringField(c.Path)
+		}
+		changelog = append(changelog, c)
 	}
-	return append(resources[:i], resources[i+1:]...)
+
+	return SortChanges(changelog), nil
 }
 
 // hasUnmanagedSecurityGroupRules returns true if we find at least one unmanaged
@@ -129,3 +191,23 @@ func (a Analyzer) hasUnmanagedSecurityGroupRules(unmanagedResources []*resource.
 	}
 	return false
 }
+
+// isOwnedFromAnotherSource returns true if every owner of a remote resource comes from
+// a different source than the given state resource.
+// Resources without source (e.g. created by middlewares) are never considered as duplicated.
+func isOwnedFromAnotherSource(owners []*resource.Resource, stateRes *resource.Resource) bool {
+	if stateRes.Src() == nil || len(owners) == 0 {
+		return false
+	}
+	for _, owner := range owners {
+		if owner.Src() == nil {
+			return false
+		}
+		if owner.Src().Source() == stateRes.Src().Source() &&
+			owner.Src().Namespace() == stateRes.Src().Namespace() &&
+			owner.Src().InternalName() == stateRes.Src().InternalName() {
+			return false
+		}
+	}
+	return true
+}
@@ -36,6 +36,7 @@ func TestAnalyze(t *testing.T) {
 			path []string
 		}
 		alerts     alerter2.Alerts
+		options    AnalyzerOptions
 		expected   Analysis
 		hasDrifted bool
 	}{
@@ -356,6 +357,313 @@ func TestAnalyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TestDuplicatedOwnership",
+			iac: []*resource.Resource{
+				{
+					Id:     "role",
+					Type:   aws.AwsIamRoleResourceType,
+					Source: resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+				},
+				{
+					Id:     "role",
+					Type:   aws.AwsIamRoleResourceType,
+					Source: resource.NewTerraformStateSource("tfstate://second.tfstate", "module.iam", "admin"),
+				},
+			},
+			cloud: []*resource.Resource{
+				{
+					Id:   "role",
+					Type: aws.AwsIamRoleResourceType,
+				},
+			},
+			hasDrifted: true,
+			expected: Analysis{
+				managed: []*resource.Resource{
+					{
+						Id:     "role",
+						Type:   aws.AwsIamRoleResourceType,
+						Source: resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+					},
+				},
+				duplicates: []Duplicate{
+					{
+						Res: &resource.Resource{
+							Id:   "role",
+							Type: aws.AwsIamRoleResourceType,
+						},
+						Sources: []resource.Source{
+							resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+							resource.NewTerraformStateSource("tfstate://second.tfstate", "module.iam", "admin"),
+						},
+					},
+				},
+				summary: Summary{
+					TotalResources:  1,
+					TotalManaged:    1,
+					TotalDuplicated: 1,
+				},
+			},
+		},
+		{
+			name: "TestDuplicatedResourceFromSameSourceIsMissing",
+			iac: []*resource.Resource{
+				{
+					Id:     "role",
+					Type:   aws.AwsIamRoleResourceType,
+					Source: resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+				},
+				{
+					Id:     "role",
+					Type:   aws.AwsIamRoleResourceType,
+					Source: resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+				},
+			},
+			cloud: []*resource.Resource{
+				{
+					Id:   "role",
+					Type: aws.AwsIamRoleResourceType,
+				},
+			},
+			hasDrifted: true,
+			expected: Analysis{
+				managed: []*resource.Resource{
+					{
+						Id:     "role",
+						Type:   aws.AwsIamRoleResourceType,
+						Source: resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+					},
+				},
+				deleted: []*resource.Resource{
+					{
+						Id:     "role",
+						Type:   aws.AwsIamRoleResourceType,
+						Source: resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+					},
+				},
+				summary: Summary{
+					TotalResources: 2,
+					TotalManaged:   1,
+					TotalDeleted:   1,
+				},
+			},
+		},
+		{
+			name: "TestDeepModeReportsDrift",
+			iac: []*resource.Resource{
+				{
+					Id:   "i-foobar",
+					Type: aws.AwsInstanceResourceType,
+					Attrs: &resource.Attributes{
+						"iam_instance_profile": "profile-a",
+					},
+				},
+			},
+			cloud: []*resource.Resource{
+				{
+					Id:   "i-foobar",
+					Type: aws.AwsInstanceResourceType,
+					Attrs: &resource.Attributes{
+						"iam_instance_profile": "profile-b",
+					},
+				},
+			},
+			options: AnalyzerOptions{Deep: true},
+			expected: Analysis{
+				managed: []*resource.Resource{
+					{
+						Id:   "i-foobar",
+						Type: aws.AwsInstanceResourceType,
+						Attrs: &resource.Attributes{
+							"iam_instance_profile": "profile-a",
+						},
+					},
+				},
+				differences: []Difference{
+					{
+						Res: &resource.Resource{
+							Id:   "i-foobar",
+							Type: aws.AwsInstanceResourceType,
+							Attrs: &resource.Attributes{
+								"iam_instance_profile": "profile-a",
+							},
+						},
+						Changelog: Changelog{
+							{
+								Change: diff.Change{
+									Type: diff.UPDATE,
+									Path: []string{"iam_instance_profile"},
+									From: "profile-a",
+									To:   "profile-b",
+								},
+							},
+						},
+					},
+				},
+				summary: Summary{
+					TotalResources: 1,
+					TotalManaged:   1,
+					TotalDrifted:   1,
+				},
+			},
+			hasDrifted: true,
+		},
+		{
+			name: "TestDeepModeWithIgnoredField",
+			iac: []*resource.Resource{
+				{
+					Id:   "i-foobar",
+					Type: aws.AwsInstanceResourceType,
+					Attrs: &resource.Attributes{
+						"iam_instance_profile": "profile-a",
+					},
+				},
+			},
+			cloud: []*resource.Resource{
+				{
+					Id:   "i-foobar",
+					Type: aws.AwsInstanceResourceType,
+					Attrs: &resource.Attributes{
+						"iam_instance_profile": "profile-b",
+					},
+				},
+			},
+			ignoredDrift: []struct {
+				res  *resource.Resource
+				path []string
+			}{
+				{
+					res: &resource.Resource{
+						Id:   "i-foobar",
+						Type: aws.AwsInstanceResourceType,
+						Attrs: &resource.Attributes{
+							"iam_instance_profile": "profile-a",
+						},
+					},
+					path: []string{"iam_instance_profile"},
+				},
+			},
+			options: AnalyzerOptions{Deep: true},
+			expected: Analysis{
+				managed: []*resource.Resource{
+					{
+						Id:   "i-foobar",
+						Type: aws.AwsInstanceResourceType,
+						Attrs: &resource.Attributes{
+							"iam_instance_profile": "profile-a",
+						},
+					},
+				},
+				summary: Summary{
+					TotalResources: 1,
+					TotalManaged:   1,
+				},
+			},
+			hasDrifted: false,
+		},
+		{
+			name: "TestDeepModeAlertOnComputedField",
+			iac: []*resource.Resource{
+				{
+					Id:   "i-foobar",
+					Type: aws.AwsInstanceResourceType,
+					Attrs: &resource.Attributes{
+						"private_ip": "10.0.0.1",
+					},
+				},
+			},
+			cloud: []*resource.Resource{
+				{
+					Id:   "i-foobar",
+					Type: aws.AwsInstanceResourceType,
+					Attrs: &resource.Attributes{
+						"private_ip": "10.0.0.2",
+					},
+				},
+			},
+			options: AnalyzerOptions{Deep: true},
+			expected: Analysis{
+				managed: []*resource.Resource{
+					{
+						Id:   "i-foobar",
+						Type: aws.AwsInstanceResourceType,
+						Attrs: &resource.Attributes{
+							"private_ip": "10.0.0.1",
+						},
+					},
+				},
+				differences: []Difference{
+					{
+						Res: &resource.Resource{
+							Id:   "i-foobar",
+							Type: aws.AwsInstanceResourceType,
+							Attrs: &resource.Attributes{
+								"private_ip": "10.0.0.1",
+							},
+						},
+						Changelog: Changelog{
+							{
+								Change: diff.Change{
+									Type: diff.UPDATE,
+									Path: []string{"private_ip"},
+									From: "10.0.0.1",
+									To:   "10.0.0.2",
+								},
+								Computed: true,
+							},
+						},
+					},
+				},
+				summary: Summary{
+					TotalResources: 1,
+					TotalManaged:   1,
+					TotalDrifted:   1,
+				},
+				alerts: alerter2.Alerts{
+					"": {
+						NewComputedDiffAlert(),
+					},
+				},
+			},
+			hasDrifted: true,
+		},
+		{
+			name: "TestAttributesNotComparedWithoutDeepMode",
+			iac: []*resource.Resource{
+				{
+					Id:   "i-foobar",
+					Type: aws.AwsInstanceResourceType,
+					Attrs: &resource.Attributes{
+						"iam_instance_profile": "profile-a",
+					},
+				},
+			},
+			cloud: []*resource.Resource{
+				{
+					Id:   "i-foobar",
+					Type: aws.AwsInstanceResourceType,
+					Attrs: &resource.Attributes{
+						"iam_instance_profile": "profile-b",
+					},
+				},
+			},
+			expected: Analysis{
+				managed: []*resource.Resource{
+					{
+						Id:   "i-foobar",
+						Type: aws.AwsInstanceResourceType,
+						Attrs: &resource.Attributes{
+							"iam_instance_profile": "profile-a",
+						},
+					},
+				},
+				summary: Summary{
+					TotalResources: 1,
+					TotalManaged:   1,
+				},
+			},
+			hasDrifted: false,
+		},
 	}
 
 	differ, err := diff.NewDiffer(diff.SliceOrdering(true))
@@ -370,6 +678,10 @@ func TestAnalyze(t *testing.T) {
 				testFilter.On("IsResourceIgnored", ignored).Return(true)
 			}
 			testFilter.On("IsResourceIgnored", mock.Anything).Return(false)
+			for _, drift := range c.ignoredDrift {
+				testFilter.On("IsFieldIgnored", drift.res, drift.path).Return(true)
+			}
+			testFilter.On("IsFieldIgnored", mock.Anything, mock.Anything).Return(false)
 
 			al := alerter2.NewAlerter()
 			if c.alerts != nil {
@@ -379,7 +691,7 @@ func TestAnalyze(t *testing.T) {
 			repo := testresource.InitFakeSchemaRepository("aws", "3.19.0")
 			aws.InitResourcesMetadata(repo)
 
-			analyzer := NewAnalyzer(al, testFilter)
+			analyzer := NewAnalyzer(al, c.options, testFilter)
 
 			for _, res := range c.cloud {
 				addSchemaToRes(res, repo)
@@ -438,6 +750,28 @@ func TestAnalyze(t *testing.T) {
 				}
 			}
 
+			differencesChanges, err := differ.Diff(result.Differences(), c.expected.Differences())
+			if err != nil {
+				t.Fatalf("Unable to compare %+v", err)
+			}
+			if len(differencesChanges) > 0 {
+				for _, change := range differencesChanges {
+					t.Errorf("%+v", change)
+				}
+			}
+
+			duplicatesChanges, err := differ.Diff(result.Duplicates(), c.expected.Duplicates())
+			if err != nil {
+				t.Fatalf("Unable to compare %+v", err)
+			}
+			if len(duplicatesChanges) > 0 {
+				for _, change := range duplicatesChanges {
+					t.Errorf("%+v", change)
+				}
+			}
+
+			// coverage breakdowns are derived from the expected resources
+			c.expected.computeCoverage()
 			summaryChanges, err := differ.Diff(c.expected.Summary(), result.Summary())
 			if err != nil {
 				t.Fatalf("Unable to compare %+v", err)
@@ -537,6 +871,17 @@ func TestAnalysis_UnmarshalJSON(t *testing.T) {
 			TotalDeleted:        2,
 			TotalManaged:        2,
 			TotalIaCSourceCount: 3,
+			CoverageByType: []CoverageBreakdown{
+				{Key: "aws_iam_access_key", Managed: 1, Total: 2, Coverage: 50},
+				{Key: "aws_iam_user", Managed: 1, Total: 2, Coverage: 50},
+				{Key: "aws_s3_bucket_notification", Managed: 0, Total: 1, Coverage: 0},
+				{Key: "aws_s3_bucket_policy", Managed: 0, Total: 1, Coverage: 0},
+			},
+			CoverageByService: []CoverageBreakdown{
+				{Key: "aws_iam", Managed: 2, Total: 4, Coverage: 50},
+				{Key: "aws_s3", Managed: 0, Total: 2, Coverage: 0},
+			},
+			CoverageBySource: []CoverageBreakdown{},
 		},
 		managed: []*resource.Resource{
 			{
@@ -599,3 +944,28 @@ func TestAnalysis_UnmarshalJSON(t *testing.T) {
 	assert.Len(t, got.alerts, 1)
 	assert.Equal(t, got.alerts["aws_iam_access_key"][0].Message(), "This is an alert")
 }
+
+func TestAnalysis_UnmarshalJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "unsupported version",
+			input: `{"version": 3}`,
+			err:   "unsupported analysis version 3, this version of driftctl can only read analysis up to version 2",
+		},
+		{
+			name:  "unknown source type",
+			input: `{"version": 2, "managed": [{"id": "foo", "type": "aws_s3_bucket", "source": {"type": "cloudformation", "source": "stack", "namespace": "", "internal_name": "foo"}}]}`,
+			err:   "unsupported source type cloudformation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := json.Unmarshal([]byte(tt.input), &Analysis{})
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package analyser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+// Findings groups drifts reported by an analysis
+type Findings struct {
+	Unmanaged   []*resource.Resource
+	Deleted     []*resource.Resource
+	Differences []Difference
+	Duplicates  []Duplicate
+}
+
+type serializableFindings struct {
+	Unmanaged   []resource.SerializableResource `json:"unmanaged,omitempty"`
+	Deleted     []resource.SerializableResource `json:"missing,omitempty"`
+	Differences []SerializableDifference        `json:"differences,omitempty"`
+	Duplicates  []SerializableDuplicate         `json:"duplicates,omitempty"`
+}
+
+func (f Findings) Count() int {
+	return len(f.Unmanaged) + len(f.Deleted) + len(f.Differences) + len(f.Duplicates)
+}
+
+// BaselineComparison classifies findings of an analysis against a reference analysis.
+// New findings were not reported by the reference, persisting ones were already there
+// and resolved ones were only reported by the reference.
+type BaselineComparison struct {
+	New        Findings
+	Persisting Findings
+	Resolved   Findings
+}
+
+type serializableBaselineComparison struct {
+	New        serializableFindings `json:"new"`
+	Persisting serializableFindings `json:"persisting"`
+	Resolved   serializableFindings `json:"resolved"`
+}
+
+// NewBaselineComparison compares findings of the current analysis with the baseline ones.
+// Resources are matched using their type and id, a changed resource is considered as new
+// as soon as one of its changed attributes was not reported by the baseline.
+func NewBaselineComparison(current, baseline *Analysis) *BaselineComparison {
+	comparison := &BaselineComparison{}
+
+	baselineUnmanaged := indexResources(baseline.Unmanaged())
+	currentUnmanaged := indexResources(current.Unmanaged())
+	for _, res := range current.Unmanaged() {
+		if _, exist := baselineUnmanaged[findingKey(res)]; exist {
+			comparison.Persisting.Unmanaged = append(comparison.Persisting.Unmanaged, res)
+			continue
+		}
+		comparison.New.Unmanaged = append(comparison.New.Unmanaged, res)
+	}
+	for _, res := range baseline.Unmanaged() {
+		if _, exist := currentUnmanaged[findingKey(res)]; !exist {
+			comparison.Resolved.Unmanaged = append(comparison.Resolved.Unmanaged, res)
+		}
+	}
+
+	baselineDeleted := indexResources(baseline.Deleted())
+	currentDeleted := indexResources(current.Deleted())
+	for _, res := range current.Deleted() {
+		if _, exist := baselineDeleted[findingKey(res)]; exist {
+			comparison.Persisting.Deleted = append(comparison.Persisting.Deleted, res)
+			continue
+		}
+		comparison.New.Deleted = append(comparison.New.Deleted, res)
+	}
+	for _, res := range baseline.Deleted() {
+		if _, exist := currentDeleted[findingKey(res)]; !exist {
+			comparison.Resolved.Deleted = append(comparison.Resolved.Deleted, res)
+		}
+	}
+
+	baselineChanges := make(map[string]struct{})
+	for _, difference := range baseline.Differences() {
+		for _, change := range difference.Changelog {
+			baselineChanges[changeKey(difference.Res, change)] = struct{}{}
+		}
+	}
+	currentDifferences := make(map[string]struct{}, len(current.Differences()))
+	for _, difference := range current.Differences() {
+		currentDifferences[findingKey(difference.Res)] = struct{}{}
+		isNew := false
+		for _, change := range difference.Changelog {
+			if _, exist := baselineChanges[changeKey(difference.Res, change)]; !exist {
+				isNew = true
+				break
+			}
+		}
+		if isNew {
+			comparison.New.Differences = append(comparison.New.Differences, difference)
+			continue
+		}
+		comparison.Persisting.Differences = append(comparison.Persisting.Differences, difference)
+	}
+	for _, difference := range baseline.Differences() {
+		if _, exist := currentDifferences[findingKey(difference.Res)]; !exist {
+			comparison.Resolved.Differences = append(comparison.Resolved.Differences, difference)
+		}
+	}
+
+	baselineDuplicates := make(map[string]struct{}, len(baseline.Duplicates()))
+	for _, duplicate := range baseline.Duplicates() {
+		baselineDuplicates[findingKey(duplicate.Res)] = struct{}{}
+	}
+	currentDuplicates := make(map[string]struct{}, len(current.Duplicates()))
+	for _, duplicate := range current.Duplicates() {
+		currentDuplicates[findingKey(duplicate.Res)] = struct{}{}
+		if _, exist := baselineDuplicates[findingKey(duplicate.Res)]; exist {
+			comparison.Persisting.Duplicates = append(comparison.Persisting.Duplicates, duplicate)
+			continue
+		}
+		comparison.New.Duplicates = append(comparison.New.Duplicates, duplicate)
+	}
+	for _, duplicate := range baseline.Duplicates() {
+		if _, exist := currentDuplicates[findingKey(duplicate.Res)]; !exist {
+			comparison.Resolved.Duplicates = append(comparison.Resolved.Duplicates, duplicate)
+		}
+	}
+
+	return comparison
+}
+
+func findingKey(res *resource.Resource) string {
+	return fmt.Sprintf("%s.%s", res.ResourceType(), escapeKey(res.ResourceId()))
+}
+
+func changeKey(res *resource.Resource, change Change) string {
+	path := make([]string, 0, len(change.Path))
+	for _, p := range change.Path {
+		path = append(path, escapeKey(p))
+	}
+	return fmt.Sprintf("%s.%s", findingKey(res), strings.Join(path, "."))
+}
+
+func indexResources(resources []*resource.Resource) map[string]struct{} {
+	index := make(map[string]struct{}, len(resources))
+	for _, res := range resources {
+		index[findingKey(res)] = struct{}{}
+	}
+	return index
+}
+
+func newSerializableFindings(findings Findings) serializableFindings {
+	s := serializableFindings{}
+	for _, res := range findings.Unmanaged {
+		s.Unmanaged = append(s.Unmanaged, *resource.NewSerializableResource(res))
+	}
+	for _, res := range findings.Deleted {
+		s.Deleted = append(s.Deleted, *resource.NewSerializableResource(res))
+	}
+	for _, difference := range findings.Differences {
+		s.Differences = append(s.Differences, newSerializableDifference(difference))
+	}
+	for _, duplicate := range findings.Duplicates {
+		s.Duplicates = append(s.Duplicates, newSerializableDuplicate(duplicate))
+	}
+	return s
+}
+
+func (s serializableFindings) findings() (Findings, error) {
+	findings := Findings{}
+	for _, serialized := range s.Unmanaged {
+		res, err := serialized.Resource()
+		if err != nil {
+			return Findings{}, err
+		}
+		findings.Unmanaged = append(findings.Unmanaged, res)
+	}
+	for _, serialized := range s.Deleted {
+		res, err := serialized.Resource()
+		if err != nil {
+			return Findings{}, err
+		}
+		findings.Deleted = append(findings.Deleted, res)
+	}
+	for _, serialized := range s.Differences {
+		difference, err := serialized.difference()
+		if err != nil {
+			return Findings{}, err
+		}
+		findings.Differences = append(findings.Differences, difference)
+	}
+	for _, serialized := range s.Duplicates {
+		duplicate, err := serialized.duplicate()
+		if err != nil {
+			return Findings{}, err
+		}
+		findings.Duplicates = append(findings.Duplicates, duplicate)
+	}
+	return findings, nil
+}
+
+func (s serializableBaselineComparison) comparison() (*BaselineComparison, error) {
+	newFindings, err := s.New.findings()
+	if err != nil {
+		return nil, err
+	}
+	persisting, err := s.Persisting.findings()
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := s.Resolved.findings()
+	if err != nil {
+		return nil, err
+	}
+	return &BaselineComparison{
+		New:        newFindings,
+		Persisting: persisting,
+		Resolved:   resolved,
+	}, nil
+}
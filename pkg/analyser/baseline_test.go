@@ -0,0 +1,159 @@
+package analyser
+
+import (
+	"testing"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBaselineComparison(t *testing.T) {
+	change := func(path ...string) Change {
+		return Change{Change: diff.Change{Type: diff.UPDATE, Path: path, From: "foo", To: "bar"}}
+	}
+
+	tests := []struct {
+		name             string
+		current          func() *Analysis
+		baseline         func() *Analysis
+		expected         *BaselineComparison
+		expectNewFinding bool
+	}{
+		{
+			name:             "empty analyses",
+			current:          func() *Analysis { return &Analysis{} },
+			baseline:         func() *Analysis { return &Analysis{} },
+			expected:         &BaselineComparison{},
+			expectNewFinding: false,
+		},
+		{
+			name: "unmanaged and missing resources",
+			current: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(
+					&resource.Resource{Id: "legacy", Type: "aws_s3_bucket"},
+					&resource.Resource{Id: "new", Type: "aws_s3_bucket"},
+				)
+				a.AddDeleted(&resource.Resource{Id: "role", Type: "aws_iam_role"})
+				return a
+			},
+			baseline: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(
+					&resource.Resource{Id: "legacy", Type: "aws_s3_bucket"},
+					&resource.Resource{Id: "removed", Type: "aws_s3_bucket"},
+				)
+				a.AddDeleted(&resource.Resource{Id: "role", Type: "aws_iam_role"})
+				return a
+			},
+			expected: &BaselineComparison{
+				New: Findings{
+					Unmanaged: []*resource.Resource{{Id: "new", Type: "aws_s3_bucket"}},
+				},
+				Persisting: Findings{
+					Unmanaged: []*resource.Resource{{Id: "legacy", Type: "aws_s3_bucket"}},
+					Deleted:   []*resource.Resource{{Id: "role", Type: "aws_iam_role"}},
+				},
+				Resolved: Findings{
+					Unmanaged: []*resource.Resource{{Id: "removed", Type: "aws_s3_bucket"}},
+				},
+			},
+			expectNewFinding: true,
+		},
+		{
+			name: "same resource id with different types",
+			current: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(&resource.Resource{Id: "foo", Type: "aws_iam_user"})
+				return a
+			},
+			baseline: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(&resource.Resource{Id: "foo", Type: "aws_iam_role"})
+				return a
+			},
+			expected: &BaselineComparison{
+				New: Findings{
+					Unmanaged: []*resource.Resource{{Id: "foo", Type: "aws_iam_user"}},
+				},
+				Resolved: Findings{
+					Unmanaged: []*resource.Resource{{Id: "foo", Type: "aws_iam_role"}},
+				},
+			},
+			expectNewFinding: true,
+		},
+		{
+			name: "changed resources",
+			current: func() *Analysis {
+				a := &Analysis{}
+				a.AddDifference(
+					Difference{Res: &resource.Resource{Id: "known", Type: "aws_instance"}, Changelog: Changelog{change("tags", "env")}},
+					Difference{Res: &resource.Resource{Id: "more", Type: "aws_instance"}, Changelog: Changelog{change("tags", "env"), change("instance_type")}},
+				)
+				return a
+			},
+			baseline: func() *Analysis {
+				a := &Analysis{}
+				a.AddDifference(
+					Difference{Res: &resource.Resource{Id: "known", Type: "aws_instance"}, Changelog: Changelog{change("tags", "env")}},
+					Difference{Res: &resource.Resource{Id: "more", Type: "aws_instance"}, Changelog: Changelog{change("tags", "env")}},
+					Difference{Res: &resource.Resource{Id: "fixed", Type: "aws_instance"}, Changelog: Changelog{change("ami")}},
+				)
+				return a
+			},
+			expected: &BaselineComparison{
+				New: Findings{
+					Differences: []Difference{
+						{Res: &resource.Resource{Id: "more", Type: "aws_instance"}, Changelog: Changelog{change("tags", "env"), change("instance_type")}},
+					},
+				},
+				Persisting: Findings{
+					Differences: []Difference{
+						{Res: &resource.Resource{Id: "known", Type: "aws_instance"}, Changelog: Changelog{change("tags", "env")}},
+					},
+				},
+				Resolved: Findings{
+					Differences: []Difference{
+						{Res: &resource.Resource{Id: "fixed", Type: "aws_instance"}, Changelog: Changelog{change("ami")}},
+					},
+				},
+			},
+			expectNewFinding: true,
+		},
+		{
+			name: "only persisting and resolved findings",
+			current: func() *Analysis {
+				a := &Analysis{}
+				a.AddDuplicate(Duplicate{Res: &resource.Resource{Id: "role", Type: "aws_iam_role"}})
+				return a
+			},
+			baseline: func() *Analysis {
+				a := &Analysis{}
+				a.AddDuplicate(
+					Duplicate{Res: &resource.Resource{Id: "role", Type: "aws_iam_role"}},
+					Duplicate{Res: &resource.Resource{Id: "policy", Type: "aws_iam_policy"}},
+				)
+				return a
+			},
+			expected: &BaselineComparison{
+				Persisting: Findings{
+					Duplicates: []Duplicate{{Res: &resource.Resource{Id: "role", Type: "aws_iam_role"}}},
+				},
+				Resolved: Findings{
+					Duplicates: []Duplicate{{Res: &resource.Resource{Id: "policy", Type: "aws_iam_policy"}}},
+				},
+			},
+			expectNewFinding: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := tt.current()
+			current.ApplyBaseline(tt.baseline())
+
+			assert.Equal(t, tt.expected, current.Baseline())
+			assert.Equal(t, tt.expectNewFinding, current.HasNewFindings())
+		})
+	}
+}
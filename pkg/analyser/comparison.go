@@ -0,0 +1,149 @@
+package analyser
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+// CoverageDelta is the evolution of the coverage of a group of resources between two analyses
+type CoverageDelta struct {
+	Key    string `json:"key,omitempty"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+	Delta  int    `json:"delta"`
+}
+
+// AnalysisComparison reports what changed between two analyses
+type AnalysisComparison struct {
+	// BecameUnmanaged resources are not covered by IaC anymore or appeared on the cloud provider
+	BecameUnmanaged []*resource.Resource
+	// BecameMissing resources are still in a state but are now missing on the cloud provider
+	BecameMissing []*resource.Resource
+	// Adopted resources were not covered by IaC and are now managed
+	Adopted []*resource.Resource
+	// Disappeared resources are not reported at all by the latest analysis
+	Disappeared      []*resource.Resource
+	Coverage         CoverageDelta
+	CoverageByType   []CoverageDelta
+	CoverageBySource []CoverageDelta
+}
+
+type serializableAnalysisComparison struct {
+	BecameUnmanaged  []resource.SerializableResource `json:"became_unmanaged"`
+	BecameMissing    []resource.SerializableResource `json:"became_missing"`
+	Adopted          []resource.SerializableResource `json:"adopted"`
+	Disappeared      []resource.SerializableResource `json:"disappeared"`
+	Coverage         CoverageDelta                   `json:"coverage"`
+	CoverageByType   []CoverageDelta                 `json:"coverage_by_type"`
+	CoverageBySource []CoverageDelta                 `json:"coverage_by_source"`
+}
+
+// CompareAnalyses compares two analyses, resources are matched using their type and id
+func CompareAnalyses(before, after *Analysis) *AnalysisComparison {
+	comparison := &AnalysisComparison{
+		Coverage: CoverageDelta{
+			Before: before.Coverage(),
+			After:  after.Coverage(),
+			Delta:  after.Coverage() - before.Coverage(),
+		},
+	}
+
+	beforeUnmanaged := indexResources(before.Unmanaged())
+	beforeDeleted := indexResources(before.Deleted())
+	afterAll := indexResources(after.Managed())
+	for key := range indexResources(after.Unmanaged()) {
+		afterAll[key] = struct{}{}
+	}
+	for key := range indexResources(after.Deleted()) {
+		afterAll[key] = struct{}{}
+	}
+
+	for _, res := range after.Unmanaged() {
+		if _, exist := beforeUnmanaged[findingKey(res)]; !exist {
+			comparison.BecameUnmanaged = append(comparison.BecameUnmanaged, res)
+		}
+	}
+	for _, res := range after.Deleted() {
+		if _, exist := beforeDeleted[findingKey(res)]; !exist {
+			comparison.BecameMissing = append(comparison.BecameMissing, res)
+		}
+	}
+	for _, res := range after.Managed() {
+		if _, exist := beforeUnmanaged[findingKey(res)]; exist {
+			comparison.Adopted = append(comparison.Adopted, res)
+		}
+	}
+	for _, resources := range [][]*resource.Resource{before.Managed(), before.Unmanaged(), before.Deleted()} {
+		for _, res := range resources {
+			if _, exist := afterAll[findingKey(res)]; !exist {
+				comparison.Disappeared = append(comparison.Disappeared, res)
+			}
+		}
+	}
+
+	comparison.BecameUnmanaged = resource.Sort(comparison.BecameUnmanaged)
+	comparison.BecameMissing = resource.Sort(comparison.BecameMissing)
+	comparison.Adopted = resource.Sort(comparison.Adopted)
+	comparison.Disappeared = resource.Sort(comparison.Disappeared)
+
+	comparison.CoverageByType = coverageDeltas(before.Summary().CoverageByType, after.Summary().CoverageByType)
+	comparison.CoverageBySource = coverageDeltas(before.Summary().CoverageBySource, after.Summary().CoverageBySource)
+
+	return comparison
+}
+
+func (c AnalysisComparison) MarshalJSON() ([]byte, error) {
+	s := serializableAnalysisComparison{
+		BecameUnmanaged:  make([]resource.SerializableResource, 0, len(c.BecameUnmanaged)),
+		BecameMissing:    make([]resource.SerializableResource, 0, len(c.BecameMissing)),
+		Adopted:          make([]resource.SerializableResource, 0, len(c.Adopted)),
+		Disappeared:      make([]resource.SerializableResource, 0, len(c.Disappeared)),
+		Coverage:         c.Coverage,
+		CoverageByType:   c.CoverageByType,
+		CoverageBySource: c.CoverageBySource,
+	}
+	for _, res := range c.BecameUnmanaged {
+		s.BecameUnmanaged = append(s.BecameUnmanaged, *resource.NewSerializableResource(res))
+	}
+	for _, res := range c.BecameMissing {
+		s.BecameMissing = append(s.BecameMissing, *resource.NewSerializableResource(res))
+	}
+	for _, res := range c.Adopted {
+		s.Adopted = append(s.Adopted, *resource.NewSerializableResource(res))
+	}
+	for _, res := range c.Disappeared {
+		s.Disappeared = append(s.Disappeared, *resource.NewSerializableResource(res))
+	}
+	return json.Marshal(s)
+}
+
+func coverageDeltas(before, after []CoverageBreakdown) []CoverageDelta {
+	beforeByKey := make(map[string]CoverageBreakdown, len(before))
+	keys := make([]string, 0, len(before)+len(after))
+	for _, breakdown := range before {
+		beforeByKey[breakdown.Key] = breakdown
+		keys = append(keys, breakdown.Key)
+	}
+	afterByKey := make(map[string]CoverageBreakdown, len(after))
+	for _, breakdown := range after {
+		afterByKey[breakdown.Key] = breakdown
+		if _, exist := beforeByKey[breakdown.Key]; !exist {
+			keys = append(keys, breakdown.Key)
+		}
+	}
+	sort.Strings(keys)
+
+	deltas := make([]CoverageDelta, 0, len(keys))
+	for _, key := range keys {
+		delta := CoverageDelta{
+			Key:    key,
+			Before: beforeByKey[key].Coverage,
+			After:  afterByKey[key].Coverage,
+		}
+		delta.Delta = delta.After - delta.Before
+		deltas = append(deltas, delta)
+	}
+	return deltas
+}
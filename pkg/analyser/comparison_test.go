@@ -0,0 +1,97 @@
+package analyser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareAnalyses(t *testing.T) {
+	prodSource := resource.NewTerraformStateSource("tfstate://prod.tfstate", "", "bucket")
+
+	tests := []struct {
+		name     string
+		before   func() *Analysis
+		after    func() *Analysis
+		expected *AnalysisComparison
+	}{
+		{
+			name:   "empty analyses",
+			before: func() *Analysis { return &Analysis{} },
+			after:  func() *Analysis { return &Analysis{} },
+			expected: &AnalysisComparison{
+				CoverageByType:   []CoverageDelta{},
+				CoverageBySource: []CoverageDelta{},
+			},
+		},
+		{
+			name: "managed resource removed from state",
+			before: func() *Analysis {
+				a := &Analysis{}
+				a.AddManaged(&resource.Resource{Id: "bucket", Type: "aws_s3_bucket", Source: prodSource})
+				return a
+			},
+			after: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"})
+				return a
+			},
+			expected: &AnalysisComparison{
+				BecameUnmanaged: []*resource.Resource{{Id: "bucket", Type: "aws_s3_bucket"}},
+				Coverage:        CoverageDelta{Before: 100, After: 0, Delta: -100},
+				CoverageByType: []CoverageDelta{
+					{Key: "aws_s3_bucket", Before: 100, After: 0, Delta: -100},
+				},
+				CoverageBySource: []CoverageDelta{
+					{Key: "tfstate://prod.tfstate", Before: 100, After: 0, Delta: -100},
+				},
+			},
+		},
+		{
+			name: "resources adopted and disappeared",
+			before: func() *Analysis {
+				a := &Analysis{}
+				a.AddUnmanaged(
+					&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"},
+					&resource.Resource{Id: "user", Type: "aws_iam_user"},
+				)
+				return a
+			},
+			after: func() *Analysis {
+				a := &Analysis{}
+				a.AddManaged(&resource.Resource{Id: "bucket", Type: "aws_s3_bucket", Source: prodSource})
+				return a
+			},
+			expected: &AnalysisComparison{
+				Adopted:     []*resource.Resource{{Id: "bucket", Type: "aws_s3_bucket", Source: prodSource}},
+				Disappeared: []*resource.Resource{{Id: "user", Type: "aws_iam_user"}},
+				Coverage:    CoverageDelta{Before: 0, After: 100, Delta: 100},
+				CoverageByType: []CoverageDelta{
+					{Key: "aws_iam_user", Before: 0, After: 0, Delta: 0},
+					{Key: "aws_s3_bucket", Before: 0, After: 100, Delta: 100},
+				},
+				CoverageBySource: []CoverageDelta{
+					{Key: "tfstate://prod.tfstate", Before: 0, After: 100, Delta: 100},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompareAnalyses(tt.before(), tt.after())
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestAnalysisComparison_MarshalJSON(t *testing.T) {
+	got, err := json.Marshal(AnalysisComparison{})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"became_unmanaged":[],"became_missing":[],"adopted":[],"disappeared":[],"coverage":{"before":0,"after":0,"delta":0},"coverage_by_type":null,"coverage_by_source":null}`,
+		string(got),
+	)
+}
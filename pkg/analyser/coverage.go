@@ -0,0 +1,109 @@
+package analyser
+
+import (
+	"sort"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	dctlresource "github.com/khulnasoft-lab/driftctl/pkg/resource"
+)
+
+// CoverageBreakdown is the coverage of a group of resources, e.g. every resource of a given type
+type CoverageBreakdown struct {
+	Key      string `json:"key"`
+	Managed  int    `json:"managed"`
+	Total    int    `json:"total"`
+	Coverage int    `json:"coverage"`
+}
+
+type coverageCounter struct {
+	managed int
+	total   int
+}
+
+func (c coverageCounter) coverage() int {
+	if c.total > 0 {
+		return int((float32(c.managed) / float32(c.total)) * 100.0)
+	}
+	return 0
+}
+
+func resourceSource(res *resource.Resource) string {
+	if res.Src() == nil {
+		return ""
+	}
+	return res.Src().Source()
+}
+
+// countCoverage groups resources of the analysis using the given key, resources with an empty key are ignored
+func countCoverage(analysis *Analysis, key func(res *resource.Resource) string) map[string]*coverageCounter {
+	counters := make(map[string]*coverageCounter)
+	count := func(managed bool, resources []*resource.Resource) {
+		for _, res := range resources {
+			k := key(res)
+			if k == "" {
+				continue
+			}
+			counter, exist := counters[k]
+			if !exist {
+				counter = &coverageCounter{}
+				counters[k] = counter
+			}
+			counter.total++
+			if managed {
+				counter.managed++
+			}
+		}
+	}
+	count(true, analysis.managed)
+	count(false, analysis.unmanaged)
+	count(false, analysis.deleted)
+	return counters
+}
+
+func coverageBreakdowns(counters map[string]*coverageCounter) []CoverageBreakdown {
+	breakdowns := make([]CoverageBreakdown, 0, len(counters))
+	for key, counter := range counters {
+		breakdowns = append(breakdowns, CoverageBreakdown{
+			Key:      key,
+			Managed:  counter.managed,
+			Total:    counter.total,
+			Coverage: counter.coverage(),
+		})
+	}
+	sort.Slice(breakdowns, func(i, j int) bool {
+		return breakdowns[i].Key < breakdowns[j].Key
+	})
+	return breakdowns
+}
+
+// withCoverageBreakdowns fills the summary with the coverage of each resource type, provider service and IaC source.
+// Unmanaged resources do not belong to any IaC source, so the coverage of a source only takes managed and missing
+// resources into account. Resources of unsupported types have no known service and are left out of the service
+// coverage. Empty IaC sources hold no resource at all, they are only listed in the states without resources.
+func (s Summary) withCoverageBreakdowns(analysis *Analysis) Summary {
+	s.CoverageByType = coverageBreakdowns(countCoverage(analysis, func(res *resource.Resource) string {
+		return res.ResourceType()
+	}))
+	s.CoverageByService = coverageBreakdowns(countCoverage(analysis, func(res *resource.Resource) string {
+		return dctlresource.GetService(res.ResourceType())
+	}))
+	s.CoverageBySource = coverageBreakdowns(countCoverage(analysis, resourceSource))
+
+	s.StatesWithoutResources = nil
+	listed := make(map[string]struct{})
+	for _, breakdown := range s.CoverageBySource {
+		if breakdown.Managed == 0 {
+			s.StatesWithoutResources = append(s.StatesWithoutResources, breakdown.Key)
+			listed[breakdown.Key] = struct{}{}
+		}
+	}
+	for _, source := range analysis.emptyIaCSources {
+		if _, exist := listed[source]; exist {
+			continue
+		}
+		s.StatesWithoutResources = append(s.StatesWithoutResources, source)
+		listed[source] = struct{}{}
+	}
+	sort.Strings(s.StatesWithoutResources)
+	return s
+}
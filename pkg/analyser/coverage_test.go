@@ -0,0 +1,102 @@
+package analyser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+func TestAnalysis_SummaryCoverageBreakdowns(t *testing.T) {
+	prodSource := resource.NewTerraformStateSource("tfstate://prod.tfstate", "", "bucket")
+	deadSource := resource.NewTerraformStateSource("tfstate://dead.tfstate", "", "user")
+
+	a := &Analysis{}
+	a.AddManaged(
+		&resource.Resource{Id: "bucket", Type: "aws_s3_bucket", Source: prodSource},
+		&resource.Resource{Id: "bucket", Type: "aws_s3_bucket_policy", Source: prodSource},
+	)
+	a.AddUnmanaged(
+		&resource.Resource{Id: "other-bucket", Type: "aws_s3_bucket"},
+		&resource.Resource{Id: "role", Type: "aws_iam_role"},
+	)
+	a.AddDeleted(
+		&resource.Resource{Id: "user", Type: "aws_iam_user", Source: deadSource},
+		&resource.Resource{Id: "policy", Type: "aws_s3_bucket_policy", Source: prodSource},
+	)
+
+	summary := a.Summary()
+	assert.Equal(t, []CoverageBreakdown{
+		{Key: "aws_iam_role", Managed: 0, Total: 1, Coverage: 0},
+		{Key: "aws_iam_user", Managed: 0, Total: 1, Coverage: 0},
+		{Key: "aws_s3_bucket", Managed: 1, Total: 2, Coverage: 50},
+		{Key: "aws_s3_bucket_policy", Managed: 1, Total: 2, Coverage: 50},
+	}, summary.CoverageByType)
+	assert.Equal(t, []CoverageBreakdown{
+		{Key: "aws_iam", Managed: 0, Total: 2, Coverage: 0},
+		{Key: "aws_s3", Managed: 2, Total: 4, Coverage: 50},
+	}, summary.CoverageByService)
+	assert.Equal(t, []CoverageBreakdown{
+		{Key: "tfstate://dead.tfstate", Managed: 0, Total: 1, Coverage: 0},
+		{Key: "tfstate://prod.tfstate", Managed: 2, Total: 3, Coverage: 66},
+	}, summary.CoverageBySource)
+	assert.Equal(t, []string{"tfstate://dead.tfstate"}, summary.StatesWithoutResources)
+}
+
+func TestAnalysis_SummaryCoverageBreakdowns_Empty(t *testing.T) {
+	summary := (&Analysis{}).Summary()
+	assert.Empty(t, summary.CoverageByType)
+	assert.Empty(t, summary.CoverageByService)
+	assert.Empty(t, summary.CoverageBySource)
+	assert.Nil(t, summary.StatesWithoutResources)
+}
+
+func TestAnalysis_SummaryCoverageByService(t *testing.T) {
+	a := &Analysis{}
+	a.AddManaged(
+		&resource.Resource{Id: "i-1", Type: "aws_instance"},
+		&resource.Resource{Id: "sg-1", Type: "aws_security_group"},
+		&resource.Resource{Id: "db", Type: "aws_db_instance"},
+		&resource.Resource{Id: "zone", Type: "azurerm_private_dns_zone"},
+	)
+	a.AddUnmanaged(
+		&resource.Resource{Id: "vpc-1", Type: "aws_vpc"},
+		&resource.Resource{Id: "vol-1", Type: "aws_ebs_volume"},
+		&resource.Resource{Id: "route", Type: "aws_route"},
+		&resource.Resource{Id: "cluster", Type: "aws_rds_cluster"},
+		&resource.Resource{Id: "record", Type: "azurerm_private_dns_a_record"},
+		&resource.Resource{Id: "fake", Type: "FakeResource"},
+	)
+
+	assert.Equal(t, []CoverageBreakdown{
+		{Key: "aws_ec2", Managed: 2, Total: 5, Coverage: 40},
+		{Key: "aws_rds", Managed: 1, Total: 2, Coverage: 50},
+		{Key: "azurerm_privatedns", Managed: 1, Total: 2, Coverage: 50},
+	}, a.Summary().CoverageByService)
+}
+
+func TestAnalysis_SummaryCoverageBreakdowns_AddedAfterCompute(t *testing.T) {
+	a := &Analysis{}
+	a.AddManaged(&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"})
+	a.computeCoverage()
+	assert.Equal(t, []CoverageBreakdown{{Key: "aws_s3_bucket", Managed: 1, Total: 1, Coverage: 100}}, a.Summary().CoverageByType)
+
+	a.AddUnmanaged(&resource.Resource{Id: "other", Type: "aws_s3_bucket"})
+	assert.Equal(t, []CoverageBreakdown{{Key: "aws_s3_bucket", Managed: 1, Total: 2, Coverage: 50}}, a.Summary().CoverageByType)
+}
+
+func TestAnalysis_SummaryStatesWithoutResources_EmptySources(t *testing.T) {
+	deadSource := resource.NewTerraformStateSource("tfstate://dead.tfstate", "", "user")
+
+	a := &Analysis{}
+	a.AddDeleted(&resource.Resource{Id: "user", Type: "aws_iam_user", Source: deadSource})
+	a.computeCoverage()
+	a.SetEmptyIaCSources([]string{"tfstate://empty.tfstate", "tfstate://dead.tfstate"})
+
+	summary := a.Summary()
+	assert.Equal(t, []string{"tfstate://dead.tfstate", "tfstate://empty.tfstate"}, summary.StatesWithoutResources)
+	assert.Equal(t, []CoverageBreakdown{
+		{Key: "tfstate://dead.tfstate", Managed: 0, Total: 1, Coverage: 0},
+	}, summary.CoverageBySource)
+}
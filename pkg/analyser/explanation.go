@@ -0,0 +1,24 @@
+package analyser
+
+const (
+	ExplanationStageEnumeration = "enumeration"
+	ExplanationStageIaC         = "iac"
+	ExplanationStageMiddleware  = "middleware"
+	ExplanationStageFilter      = "filter"
+	ExplanationStageDriftignore = "driftignore"
+	ExplanationStageAlert       = "alert"
+	ExplanationStageAnalysis    = "analysis"
+)
+
+// Explanation is the decision trail of a resource through a scan, from its enumeration to the category it is reported in
+type Explanation struct {
+	Type    string            `json:"type"`
+	Id      string            `json:"id"`
+	Steps   []ExplanationStep `json:"steps"`
+	Outcome string            `json:"outcome"`
+}
+
+type ExplanationStep struct {
+	Stage   string `json:"stage"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,85 @@
+package analyser
+
+import (
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+type resourceKey struct {
+	ty string
+	id string
+}
+
+// resourceMatcher indexes remote resources by type and id so that each state resource
+// can be matched in constant time.
+// Several remote resources may share the same type and id, in that case candidates are
+// checked in their original order using resource.Equal, which honors Schema.DiscriminantFunc.
+type resourceMatcher struct {
+	resources []*resource.Resource
+	matched   []bool
+	index     map[resourceKey][]int
+	consumed  map[resourceKey][]int
+}
+
+func newResourceMatcher(resources []*resource.Resource) *resourceMatcher {
+	m := &resourceMatcher{
+		resources: resources,
+		matched:   make([]bool, len(resources)),
+		index:     make(map[resourceKey][]int, len(resources)),
+		consumed:  make(map[resourceKey][]int),
+	}
+	for i, res := range resources {
+		key := resourceKey{res.ResourceType(), res.ResourceId()}
+		m.index[key] = append(m.index[key], i)
+	}
+	return m
+}
+
+// Match returns the first remote resource not yet matched that is equal to the given one,
+// the returned resource is consumed and will not be returned again.
+func (m *resourceMatcher) Match(res *resource.Resource) (*resource.Resource, bool) {
+	key := resourceKey{res.ResourceType(), res.ResourceId()}
+	candidates, exist := m.index[key]
+	if !exist {
+		return nil, false
+	}
+
+	for i, candidate := range candidates {
+		if !res.Equal(m.resources[candidate]) {
+			continue
+		}
+
+		m.matched[candidate] = true
+		m.consumed[key] = append(m.consumed[key], candidate)
+		if len(candidates) == 1 {
+			delete(m.index, key)
+		} else {
+			m.index[key] = append(candidates[:i], candidates[i+1:]...)
+		}
+		return m.resources[candidate], true
+	}
+
+	return nil, false
+}
+
+// MatchConsumed returns the first remote resource already returned by Match that is equal to the given one.
+// It is used to find out which remote resource is claimed by several state resources.
+func (m *resourceMatcher) MatchConsumed(res *resource.Resource) (*resource.Resource, bool) {
+	key := resourceKey{res.ResourceType(), res.ResourceId()}
+	for _, candidate := range m.consumed[key] {
+		if res.Equal(m.resources[candidate]) {
+			return m.resources[candidate], true
+		}
+	}
+	return nil, false
+}
+
+// Unmatched returns remote resources that were never matched, in their original order
+func (m *resourceMatcher) Unmatched() []*resource.Resource {
+	unmatched := make([]*resource.Resource, 0, len(m.resources))
+	for i, res := range m.resources {
+		if !m.matched[i] {
+			unmatched = append(unmatched, res)
+		}
+	}
+	return unmatched
+}
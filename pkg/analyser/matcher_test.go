@@ -0,0 +1,175 @@
+package analyser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceMatcher_Match(t *testing.T) {
+	discriminantSchema := &resource.Schema{
+		DiscriminantFunc: func(self, target *resource.Resource) bool {
+			return (*self.Attributes())["dimension"] == (*target.Attributes())["dimension"]
+		},
+	}
+
+	tests := []struct {
+		name          string
+		remote        []*resource.Resource
+		state         []*resource.Resource
+		wantMatched   []string
+		wantUnmatched []*resource.Resource
+	}{
+		{
+			name:          "no remote resources",
+			remote:        []*resource.Resource{},
+			state:         []*resource.Resource{{Id: "foo", Type: "type_a"}},
+			wantMatched:   []string{""},
+			wantUnmatched: []*resource.Resource{},
+		},
+		{
+			name: "match on type and id",
+			remote: []*resource.Resource{
+				{Id: "foo", Type: "type_a"},
+				{Id: "foo", Type: "type_b"},
+				{Id: "bar", Type: "type_a"},
+			},
+			state: []*resource.Resource{
+				{Id: "foo", Type: "type_b"},
+				{Id: "baz", Type: "type_a"},
+			},
+			wantMatched: []string{"type_b.foo", ""},
+			wantUnmatched: []*resource.Resource{
+				{Id: "foo", Type: "type_a"},
+				{Id: "bar", Type: "type_a"},
+			},
+		},
+		{
+			name: "matched resources are consumed",
+			remote: []*resource.Resource{
+				{Id: "foo", Type: "type_a"},
+			},
+			state: []*resource.Resource{
+				{Id: "foo", Type: "type_a"},
+				{Id: "foo", Type: "type_a"},
+			},
+			wantMatched:   []string{"type_a.foo", ""},
+			wantUnmatched: []*resource.Resource{},
+		},
+		{
+			name: "duplicated keys are matched with discriminant func",
+			remote: []*resource.Resource{
+				{Id: "foo", Type: "type_a", Attrs: &resource.Attributes{"dimension": "first"}, Sch: discriminantSchema},
+				{Id: "foo", Type: "type_a", Attrs: &resource.Attributes{"dimension": "second"}, Sch: discriminantSchema},
+				{Id: "foo", Type: "type_a", Attrs: &resource.Attributes{"dimension": "third"}, Sch: discriminantSchema},
+			},
+			state: []*resource.Resource{
+				{Id: "foo", Type: "type_a", Attrs: &resource.Attributes{"dimension": "second"}, Sch: discriminantSchema},
+				{Id: "foo", Type: "type_a", Attrs: &resource.Attributes{"dimension": "fourth"}, Sch: discriminantSchema},
+				{Id: "foo", Type: "type_a", Attrs: &resource.Attributes{"dimension": "first"}, Sch: discriminantSchema},
+			},
+			wantMatched: []string{"type_a.foo.second", "", "type_a.foo.first"},
+			wantUnmatched: []*resource.Resource{
+				{Id: "foo", Type: "type_a", Attrs: &resource.Attributes{"dimension": "third"}, Sch: discriminantSchema},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newResourceMatcher(tt.remote)
+
+			matched := make([]string, 0, len(tt.state))
+			for _, stateRes := range tt.state {
+				res, found := m.Match(stateRes)
+				if !found {
+					matched = append(matched, "")
+					continue
+				}
+				key := fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId())
+				if res.Attributes() != nil {
+					key = fmt.Sprintf("%s.%s", key, (*res.Attributes())["dimension"])
+				}
+				matched = append(matched, key)
+			}
+
+			assert.Equal(t, tt.wantMatched, matched)
+			assert.Equal(t, tt.wantUnmatched, m.Unmatched())
+		})
+	}
+}
+
+type noopFilter struct{}
+
+func (noopFilter) IsTypeIgnored(resource.ResourceType) bool         { return false }
+func (noopFilter) IsResourceIgnored(*resource.Resource) bool        { return false }
+func (noopFilter) IsFieldIgnored(*resource.Resource, []string) bool { return false }
+
+// generateBenchmarkResources returns remote and state resources where 90% of remote resources are managed
+// and 5% of state resources are missing. One type out of four relies on a discriminant func.
+func generateBenchmarkResources(count int) ([]*resource.Resource, []*resource.Resource) {
+	discriminantSchema := &resource.Schema{
+		DiscriminantFunc: func(self, target *resource.Resource) bool {
+			return (*self.Attributes())["dimension"] == (*target.Attributes())["dimension"]
+		},
+	}
+	types := []string{"aws_route53_record", "aws_iam_policy_attachment", "aws_security_group_rule", "aws_appautoscaling_target"}
+
+	remote := make([]*resource.Resource, 0, count)
+	state := make([]*resource.Resource, 0, count)
+	for i := 0; i < count; i++ {
+		ty := types[i%len(types)]
+		res := &resource.Resource{
+			Id:   fmt.Sprintf("id-%d", i/len(types)),
+			Type: ty,
+		}
+		if ty == "aws_appautoscaling_target" {
+			res.Id = fmt.Sprintf("id-%d", i/(len(types)*2))
+			res.Attrs = &resource.Attributes{"dimension": fmt.Sprintf("dimension-%d", i)}
+			res.Sch = discriminantSchema
+		}
+		remote = append(remote, res)
+
+		if i%10 != 0 {
+			stateRes := *res
+			state = append(state, &stateRes)
+		}
+		if i%20 == 0 {
+			state = append(state, &resource.Resource{
+				Id:   fmt.Sprintf("missing-%d", i),
+				Type: ty,
+			})
+		}
+	}
+
+	return remote, state
+}
+
+func benchmarkAnalyze(b *testing.B, count int) {
+	remote, state := generateBenchmarkResources(count)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		analyzer := NewAnalyzer(alerter.NewAlerter(), AnalyzerOptions{}, noopFilter{})
+		b.StartTimer()
+
+		if _, err := analyzer.Analyze(remote, state); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAnalyze_10k(b *testing.B) {
+	benchmarkAnalyze(b, 10000)
+}
+
+func BenchmarkAnalyze_100k(b *testing.B) {
+	benchmarkAnalyze(b, 100000)
+}
+
+func BenchmarkAnalyze_500k(b *testing.B) {
+	benchmarkAnalyze(b, 500000)
+}
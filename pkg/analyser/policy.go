@@ -0,0 +1,30 @@
+package analyser
+
+// PolicyEvaluation is the result of the evaluation of a failure policy against an analysis
+type PolicyEvaluation struct {
+	// ExitCode is the exit code of the first violated rule, zero when every rule passed
+	ExitCode int              `json:"exit_code"`
+	Rules    []RuleEvaluation `json:"rules"`
+}
+
+type RuleEvaluation struct {
+	Name     string   `json:"name"`
+	Violated bool     `json:"violated"`
+	ExitCode int      `json:"exit_code"`
+	Reasons  []string `json:"reasons,omitempty"`
+}
+
+func (e *PolicyEvaluation) Violated() bool {
+	return e.ExitCode != 0
+}
+
+// ViolatedRules returns the names of the rules that are not satisfied by the analysis
+func (e *PolicyEvaluation) ViolatedRules() []string {
+	rules := make([]string, 0)
+	for _, rule := range e.Rules {
+		if rule.Violated {
+			rules = append(rules, rule.Name)
+		}
+	}
+	return rules
+}
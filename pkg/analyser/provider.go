@@ -0,0 +1,74 @@
+package analyser
+
+import (
+	"strings"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+// Provider is a cloud provider scanned to produce the analysis
+type Provider struct {
+	// Name is the remote given to the scan, e.g. aws+tf
+	Name string
+	// Type is the Terraform provider prefixing the types of its resources, e.g. aws for aws_s3_bucket
+	Type    string
+	Version string
+}
+
+// ProviderSummary counts the resources of a scanned provider
+type ProviderSummary struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Version        string `json:"version"`
+	TotalResources int    `json:"total_resources"`
+	TotalUnmanaged int    `json:"total_unmanaged"`
+	TotalDeleted   int    `json:"total_missing"`
+	TotalManaged   int    `json:"total_managed"`
+	TotalDrifted   int    `json:"total_changed"`
+	Coverage       int    `json:"coverage"`
+}
+
+func (p Provider) owns(res *resource.Resource) bool {
+	return strings.HasPrefix(res.ResourceType(), p.Type+"_")
+}
+
+// SetProviders records the providers scanned to produce the analysis, the provider name and version of the analysis
+// list them in the same order
+func (a *Analysis) SetProviders(providers ...Provider) {
+	a.providers = providers
+	names := make([]string, 0, len(providers))
+	versions := make([]string, 0, len(providers))
+	for _, p := range providers {
+		names = append(names, p.Name)
+		versions = append(versions, p.Version)
+	}
+	a.ProviderName = strings.Join(names, ",")
+	a.ProviderVersion = strings.Join(versions, ",")
+}
+
+// Providers returns the summary of each scanned provider, in the order they were scanned
+func (a *Analysis) Providers() []ProviderSummary {
+	summaries := make([]ProviderSummary, 0, len(a.providers))
+	for _, p := range a.providers {
+		summary := ProviderSummary{Name: p.Name, Type: p.Type, Version: p.Version}
+		count := func(total *int, resources []*resource.Resource) {
+			for _, res := range resources {
+				if p.owns(res) {
+					*total++
+				}
+			}
+		}
+		count(&summary.TotalManaged, a.managed)
+		count(&summary.TotalUnmanaged, a.unmanaged)
+		count(&summary.TotalDeleted, a.deleted)
+		for _, difference := range a.differences {
+			if p.owns(difference.Res) {
+				summary.TotalDrifted++
+			}
+		}
+		summary.TotalResources = summary.TotalManaged + summary.TotalUnmanaged + summary.TotalDeleted
+		summary.Coverage = coverageCounter{managed: summary.TotalManaged, total: summary.TotalResources}.coverage()
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
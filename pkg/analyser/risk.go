@@ -0,0 +1,89 @@
+package analyser
+
+import (
+	"sort"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+)
+
+// Severities are sorted from the most to the least severe
+var Severities = []Severity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow}
+
+// Rank orders severities, the most severe has the highest rank and unknown severities are ranked zero
+func (s Severity) Rank() int {
+	for i, severity := range Severities {
+		if s == severity {
+			return len(Severities) - i
+		}
+	}
+	return 0
+}
+
+const (
+	RiskKindUnmanaged = "unmanaged"
+	RiskKindMissing   = "missing"
+)
+
+// Risk is an unmanaged or missing resource classified by a risk rule
+type Risk struct {
+	Res         *resource.Resource
+	Kind        string
+	Severity    Severity
+	Rule        string
+	Description string
+}
+
+type SerializableRisk struct {
+	Res         resource.SerializableResource `json:"res"`
+	Kind        string                        `json:"kind"`
+	Severity    Severity                      `json:"severity"`
+	Rule        string                        `json:"rule"`
+	Description string                        `json:"description,omitempty"`
+}
+
+// SortRisks sorts risks from the most to the least severe, then by resource type and id
+func SortRisks(risks []Risk) []Risk {
+	sort.SliceStable(risks, func(i, j int) bool {
+		if risks[i].Severity.Rank() != risks[j].Severity.Rank() {
+			return risks[i].Severity.Rank() > risks[j].Severity.Rank()
+		}
+		if risks[i].Res.ResourceType() != risks[j].Res.ResourceType() {
+			return risks[i].Res.ResourceType() < risks[j].Res.ResourceType()
+		}
+		return risks[i].Res.ResourceId() < risks[j].Res.ResourceId()
+	})
+	return risks
+}
+
+func newSerializableRisk(risk Risk) SerializableRisk {
+	return SerializableRisk{
+		Res:         *resource.NewSerializableResource(risk.Res),
+		Kind:        risk.Kind,
+		Severity:    risk.Severity,
+		Rule:        risk.Rule,
+		Description: risk.Description,
+	}
+}
+
+func (s SerializableRisk) risk() (Risk, error) {
+	res, err := s.Res.Resource()
+	if err != nil {
+		return Risk{}, err
+	}
+	return Risk{
+		Res:         res,
+		Kind:        s.Kind,
+		Severity:    s.Severity,
+		Rule:        s.Rule,
+		Description: s.Description,
+	}, nil
+}
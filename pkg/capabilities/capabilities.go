@@ -0,0 +1,139 @@
+package capabilities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+	"github.com/khulnasoft-lab/driftctl/pkg/middlewares"
+	dctlresource "github.com/khulnasoft-lab/driftctl/pkg/resource"
+)
+
+// typePrefixes are the prefixes of the resource types supported by each remote
+var typePrefixes = map[string]string{
+	common.RemoteAWSTerraform:    "aws_",
+	common.RemoteGoogleTerraform: "google_",
+	common.RemoteAzureTerraform:  "azurerm_",
+	common.RemoteGithubTerraform: "github_",
+}
+
+// Remotes lists the remotes whose capabilities are known, in a stable order
+var Remotes = []string{
+	common.RemoteAWSTerraform,
+	common.RemoteGoogleTerraform,
+	common.RemoteAzureTerraform,
+	common.RemoteGithubTerraform,
+}
+
+// Resource describes what driftctl is able to do with a supported resource type
+type Resource struct {
+	Type string `json:"type"`
+	// Enumerated is true when an enumerator lists the resources of this type on the cloud provider,
+	// other types are only read from IaC or created by middlewares
+	Enumerated bool     `json:"enumerated"`
+	Parents    []string `json:"parents"`
+	Children   []string `json:"children"`
+	// IgnoredOutsideStrict is true when some resources of this type, e.g. service linked roles,
+	// are ignored unless the scan runs with --strict
+	IgnoredOutsideStrict bool     `json:"ignored_outside_strict"`
+	Middlewares          []string `json:"middlewares"`
+	Permissions          []string `json:"permissions"`
+}
+
+// Remote holds the capabilities of a remote, Permissions is the union of the permissions of its resource types
+type Remote struct {
+	Name        string     `json:"name"`
+	Resources   []Resource `json:"resources"`
+	Permissions []string   `json:"permissions"`
+}
+
+// List returns the capabilities of the given remote, or of every remote when empty
+func List(remote string) ([]Remote, error) {
+	names := Remotes
+	if remote != "" {
+		if _, exist := typePrefixes[remote]; !exist {
+			return nil, errors.Errorf("Unsupported remote '%s'\nAccepted values are: %s", remote, strings.Join(Remotes, ","))
+		}
+		names = []string{remote}
+	}
+
+	nonStrict := make(map[string]struct{})
+	for _, middleware := range middlewares.NewNonStrictChain() {
+		nonStrict[middlewareName(middleware)] = struct{}{}
+	}
+
+	parents := make(map[string][]string)
+	for _, ty := range dctlresource.GetSupportedTypes() {
+		for _, child := range dctlresource.GetMeta(dctlresource.ResourceType(ty)).GetChildrenTypes() {
+			parents[child.String()] = append(parents[child.String()], ty)
+		}
+	}
+
+	remotes := make([]Remote, 0, len(names))
+	for _, name := range names {
+		r := Remote{Name: name, Resources: make([]Resource, 0), Permissions: make([]string, 0)}
+		for _, ty := range dctlresource.GetSupportedTypes() {
+			if !strings.HasPrefix(ty, typePrefixes[name]) {
+				continue
+			}
+			perms, enumerated := permissions[name][ty]
+			res := Resource{
+				Type:        ty,
+				Enumerated:  enumerated,
+				Parents:     sorted(parents[ty]),
+				Children:    make([]string, 0),
+				Middlewares: make([]string, 0),
+				Permissions: sorted(perms),
+			}
+			for _, child := range dctlresource.GetMeta(dctlresource.ResourceType(ty)).GetChildrenTypes() {
+				res.Children = append(res.Children, child.String())
+			}
+			res.Children = sorted(res.Children)
+			for middleware, types := range middlewareTypes {
+				if !contains(types, ty) {
+					continue
+				}
+				res.Middlewares = append(res.Middlewares, middleware)
+				if _, exist := nonStrict[middleware]; exist {
+					res.IgnoredOutsideStrict = true
+				}
+			}
+			res.Middlewares = sorted(res.Middlewares)
+			r.Resources = append(r.Resources, res)
+			r.Permissions = append(r.Permissions, perms...)
+		}
+		r.Permissions = sorted(r.Permissions)
+		remotes = append(remotes, r)
+	}
+	return remotes, nil
+}
+
+// middlewareName returns the name of the middleware type without its package, e.g. AwsDefaults
+func middlewareName(middleware middlewares.Middleware) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", middleware), "*")
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// sorted returns a sorted copy of the given values without duplicates, never nil so that JSON lists are not null
+func sorted(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package capabilities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+	dctlresource "github.com/khulnasoft-lab/driftctl/pkg/resource"
+)
+
+func TestCatalog_TypesAreSupported(t *testing.T) {
+	for remote, types := range permissions {
+		for ty, perms := range types {
+			assert.Truef(t, dctlresource.IsResourceTypeSupported(ty), "%s enumerates unsupported type %s", remote, ty)
+			assert.NotEmptyf(t, perms, "%s has no permission", ty)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	remotes, err := List("")
+	require.NoError(t, err)
+	require.Len(t, remotes, 4)
+	for i, remote := range remotes {
+		assert.Equal(t, Remotes[i], remote.Name)
+		assert.NotEmpty(t, remote.Resources)
+	}
+
+	remotes, err = List(common.RemoteAWSTerraform)
+	require.NoError(t, err)
+	require.Len(t, remotes, 1)
+	assert.Contains(t, remotes[0].Permissions, "s3:ListAllMyBuckets")
+
+	resources := map[string]Resource{}
+	for _, res := range remotes[0].Resources {
+		resources[res.Type] = res
+	}
+
+	assert.Equal(t, Resource{
+		Type:                 "aws_iam_role",
+		Enumerated:           true,
+		Parents:              []string{},
+		Children:             []string{"aws_iam_policy_attachment", "aws_iam_role_policy"},
+		IgnoredOutsideStrict: true,
+		Middlewares:          []string{"AwsDefaults", "AwsRoleManagedPolicyExpander"},
+		Permissions:          []string{"iam:ListRoles"},
+	}, resources["aws_iam_role"])
+
+	assert.Equal(t, []string{"aws_alb"}, resources["aws_lb"].Parents)
+	assert.Equal(t, []string{"aws_eip_association"}, resources["aws_eip"].Children)
+	assert.False(t, resources["aws_eip"].IgnoredOutsideStrict)
+	assert.False(t, resources["aws_alb"].Enumerated)
+	assert.Empty(t, resources["aws_alb"].Permissions)
+
+	_, err = List("aws")
+	assert.EqualError(t, err, "Unsupported remote 'aws'\nAccepted values are: aws+tf,gcp+tf,azure+tf,github+tf")
+}
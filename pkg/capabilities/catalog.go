@@ -0,0 +1,232 @@
+package capabilities
+
+import "github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+
+// permissions holds, by remote, the resource types having an enumerator and the cloud permissions it needs.
+// AWS permissions are IAM actions, Google permissions are IAM permissions, Azure permissions are RBAC actions
+// and GitHub permissions are OAuth scopes of the token.
+// Keep this catalog in sync when adding an enumerator or changing the API calls of a repository, tests compare its
+// types with the enumerators registered by each remote.
+var permissions = map[string]map[string][]string{
+	common.RemoteAWSTerraform: {
+		"aws_ami":                               {"ec2:DescribeImages"},
+		"aws_api_gateway_account":               {"apigateway:GET"},
+		"aws_api_gateway_api_key":               {"apigateway:GET"},
+		"aws_api_gateway_authorizer":            {"apigateway:GET"},
+		"aws_api_gateway_base_path_mapping":     {"apigateway:GET"},
+		"aws_api_gateway_domain_name":           {"apigateway:GET"},
+		"aws_api_gateway_gateway_response":      {"apigateway:GET"},
+		"aws_api_gateway_integration":           {"apigateway:GET"},
+		"aws_api_gateway_integration_response":  {"apigateway:GET"},
+		"aws_api_gateway_method":                {"apigateway:GET"},
+		"aws_api_gateway_method_response":       {"apigateway:GET"},
+		"aws_api_gateway_method_settings":       {"apigateway:GET"},
+		"aws_api_gateway_model":                 {"apigateway:GET"},
+		"aws_api_gateway_request_validator":     {"apigateway:GET"},
+		"aws_api_gateway_resource":              {"apigateway:GET"},
+		"aws_api_gateway_rest_api":              {"apigateway:GET"},
+		"aws_api_gateway_rest_api_policy":       {"apigateway:GET"},
+		"aws_api_gateway_stage":                 {"apigateway:GET"},
+		"aws_api_gateway_vpc_link":              {"apigateway:GET"},
+		"aws_apigatewayv2_api":                  {"apigateway:GET"},
+		"aws_apigatewayv2_api_mapping":          {"apigateway:GET"},
+		"aws_apigatewayv2_authorizer":           {"apigateway:GET"},
+		"aws_apigatewayv2_deployment":           {"apigateway:GET"},
+		"aws_apigatewayv2_domain_name":          {"apigateway:GET"},
+		"aws_apigatewayv2_integration":          {"apigateway:GET"},
+		"aws_apigatewayv2_integration_response": {"apigateway:GET"},
+		"aws_apigatewayv2_model":                {"apigateway:GET"},
+		"aws_apigatewayv2_route":                {"apigateway:GET"},
+		"aws_apigatewayv2_route_response":       {"apigateway:GET"},
+		"aws_apigatewayv2_stage":                {"apigateway:GET"},
+		"aws_apigatewayv2_vpc_link":             {"apigateway:GET"},
+		"aws_appautoscaling_policy":             {"application-autoscaling:DescribeScalingPolicies"},
+		"aws_appautoscaling_scheduled_action":   {"application-autoscaling:DescribeScheduledActions"},
+		"aws_appautoscaling_target":             {"application-autoscaling:DescribeScalableTargets"},
+		"aws_cloudformation_stack":              {"cloudformation:DescribeStacks"},
+		"aws_cloudfront_distribution":           {"cloudfront:ListDistributions"},
+		"aws_cloudtrail":                        {"cloudtrail:ListTrails"},
+		"aws_db_instance":                       {"rds:DescribeDBInstances"},
+		"aws_db_subnet_group":                   {"rds:DescribeDBSubnetGroups"},
+		"aws_default_network_acl":               {"ec2:DescribeNetworkAcls"},
+		"aws_default_route_table":               {"ec2:DescribeRouteTables"},
+		"aws_default_security_group":            {"ec2:DescribeSecurityGroups"},
+		"aws_default_subnet":                    {"ec2:DescribeSubnets"},
+		"aws_default_vpc":                       {"ec2:DescribeVpcs"},
+		"aws_dynamodb_table":                    {"dynamodb:ListTables"},
+		"aws_ebs_encryption_by_default":         {"ec2:GetEbsEncryptionByDefault"},
+		"aws_ebs_snapshot":                      {"ec2:DescribeSnapshots"},
+		"aws_ebs_volume":                        {"ec2:DescribeVolumes"},
+		"aws_ecr_repository":                    {"ecr:DescribeRepositories"},
+		"aws_ecr_repository_policy":             {"ecr:DescribeRepositories", "ecr:GetRepositoryPolicy"},
+		"aws_eip":                               {"ec2:DescribeAddresses"},
+		"aws_eip_association":                   {"ec2:DescribeAddresses"},
+		"aws_elasticache_cluster":               {"elasticache:DescribeCacheClusters"},
+		"aws_elb":                               {"elasticloadbalancing:DescribeLoadBalancers"},
+		"aws_iam_access_key":                    {"iam:ListAccessKeys", "iam:ListUsers"},
+		"aws_iam_group":                         {"iam:ListGroups"},
+		"aws_iam_group_policy":                  {"iam:ListGroupPolicies", "iam:ListGroups"},
+		"aws_iam_group_policy_attachment":       {"iam:ListAttachedGroupPolicies", "iam:ListGroups"},
+		"aws_iam_policy":                        {"iam:ListPolicies"},
+		"aws_iam_role":                          {"iam:ListRoles"},
+		"aws_iam_role_policy":                   {"iam:ListRolePolicies", "iam:ListRoles"},
+		"aws_iam_role_policy_attachment":        {"iam:ListAttachedRolePolicies", "iam:ListRoles"},
+		"aws_iam_user":                          {"iam:ListUsers"},
+		"aws_iam_user_policy":                   {"iam:ListUserPolicies", "iam:ListUsers"},
+		"aws_iam_user_policy_attachment":        {"iam:ListAttachedUserPolicies", "iam:ListUsers"},
+		"aws_instance":                          {"ec2:DescribeInstances"},
+		"aws_internet_gateway":                  {"ec2:DescribeInternetGateways"},
+		"aws_key_pair":                          {"ec2:DescribeKeyPairs"},
+		"aws_kms_alias":                         {"kms:DescribeKey", "kms:ListAliases"},
+		"aws_kms_key":                           {"kms:DescribeKey", "kms:ListKeys"},
+		"aws_lambda_event_source_mapping":       {"lambda:ListEventSourceMappings"},
+		"aws_lambda_function":                   {"lambda:ListFunctions"},
+		"aws_launch_configuration":              {"autoscaling:DescribeLaunchConfigurations"},
+		"aws_launch_template":                   {"ec2:DescribeLaunchTemplates"},
+		"aws_lb":                                {"elasticloadbalancing:DescribeLoadBalancers"},
+		"aws_lb_listener":                       {"elasticloadbalancing:DescribeListeners", "elasticloadbalancing:DescribeLoadBalancers"},
+		"aws_nat_gateway":                       {"ec2:DescribeNatGateways"},
+		"aws_network_acl":                       {"ec2:DescribeNetworkAcls"},
+		"aws_network_acl_rule":                  {"ec2:DescribeNetworkAcls"},
+		"aws_rds_cluster":                       {"rds:DescribeDBClusters"},
+		"aws_route":                             {"ec2:DescribeRouteTables"},
+		"aws_route53_health_check":              {"route53:ListHealthChecks"},
+		"aws_route53_record":                    {"route53:ListHostedZones", "route53:ListResourceRecordSets"},
+		"aws_route53_zone":                      {"route53:ListHostedZones"},
+		"aws_route_table":                       {"ec2:DescribeRouteTables"},
+		"aws_route_table_association":           {"ec2:DescribeRouteTables"},
+		"aws_s3_account_public_access_block":    {"s3:GetAccountPublicAccessBlock"},
+		"aws_s3_bucket":                         {"s3:GetBucketLocation", "s3:ListAllMyBuckets"},
+		"aws_s3_bucket_analytics_configuration": {"s3:GetAnalyticsConfiguration", "s3:GetBucketLocation", "s3:ListAllMyBuckets"},
+		"aws_s3_bucket_inventory":               {"s3:GetBucketLocation", "s3:GetInventoryConfiguration", "s3:ListAllMyBuckets"},
+		"aws_s3_bucket_metric":                  {"s3:GetBucketLocation", "s3:GetMetricsConfiguration", "s3:ListAllMyBuckets"},
+		"aws_s3_bucket_notification":            {"s3:GetBucketLocation", "s3:GetBucketNotification", "s3:ListAllMyBuckets"},
+		"aws_s3_bucket_policy":                  {"s3:GetBucketLocation", "s3:GetBucketPolicy", "s3:ListAllMyBuckets"},
+		"aws_s3_bucket_public_access_block":     {"s3:GetBucketLocation", "s3:GetBucketPublicAccessBlock", "s3:ListAllMyBuckets"},
+		"aws_security_group":                    {"ec2:DescribeSecurityGroups"},
+		"aws_security_group_rule":               {"ec2:DescribeSecurityGroups"},
+		"aws_sns_topic":                         {"sns:ListTopics"},
+		"aws_sns_topic_policy":                  {"sns:ListTopics"},
+		"aws_sns_topic_subscription":            {"sns:ListSubscriptions"},
+		"aws_sqs_queue":                         {"sqs:ListQueues"},
+		"aws_sqs_queue_policy":                  {"sqs:GetQueueAttributes", "sqs:ListQueues"},
+		"aws_subnet":                            {"ec2:DescribeSubnets"},
+		"aws_vpc":                               {"ec2:DescribeVpcs"},
+	},
+	common.RemoteGoogleTerraform: {
+		"google_bigquery_dataset":               {"cloudasset.assets.searchAllResources"},
+		"google_bigquery_table":                 {"cloudasset.assets.searchAllResources"},
+		"google_bigtable_instance":              {"cloudasset.assets.listResource"},
+		"google_bigtable_table":                 {"cloudasset.assets.listResource"},
+		"google_cloud_run_service":              {"cloudasset.assets.searchAllResources"},
+		"google_cloudfunctions_function":        {"cloudasset.assets.listResource"},
+		"google_compute_address":                {"cloudasset.assets.searchAllResources"},
+		"google_compute_disk":                   {"cloudasset.assets.searchAllResources"},
+		"google_compute_firewall":               {"cloudasset.assets.searchAllResources"},
+		"google_compute_forwarding_rule":        {"cloudasset.assets.listResource"},
+		"google_compute_global_address":         {"cloudasset.assets.listResource"},
+		"google_compute_global_forwarding_rule": {"cloudasset.assets.listResource"},
+		"google_compute_health_check":           {"cloudasset.assets.searchAllResources"},
+		"google_compute_image":                  {"cloudasset.assets.searchAllResources"},
+		"google_compute_instance":               {"cloudasset.assets.searchAllResources"},
+		"google_compute_instance_group":         {"cloudasset.assets.searchAllResources"},
+		"google_compute_instance_group_manager": {"cloudasset.assets.listResource"},
+		"google_compute_network":                {"cloudasset.assets.searchAllResources"},
+		"google_compute_node_group":             {"cloudasset.assets.listResource"},
+		"google_compute_router":                 {"cloudasset.assets.searchAllResources"},
+		"google_compute_ssl_certificate":        {"cloudasset.assets.listResource"},
+		"google_compute_subnetwork":             {"cloudasset.assets.searchAllResources"},
+		"google_dns_managed_zone":               {"cloudasset.assets.searchAllResources"},
+		"google_project_iam_member":             {"resourcemanager.projects.getIamPolicy"},
+		"google_sql_database_instance":          {"cloudasset.assets.listResource"},
+		"google_storage_bucket":                 {"cloudasset.assets.searchAllResources"},
+		"google_storage_bucket_iam_member":      {"cloudasset.assets.searchAllResources", "storage.buckets.getIamPolicy"},
+	},
+	common.RemoteAzureTerraform: {
+		"azurerm_container_registry":       {"Microsoft.ContainerRegistry/registries/read"},
+		"azurerm_firewall":                 {"Microsoft.Network/azureFirewalls/read"},
+		"azurerm_image":                    {"Microsoft.Compute/images/read"},
+		"azurerm_lb":                       {"Microsoft.Network/loadBalancers/read"},
+		"azurerm_lb_rule":                  {"Microsoft.Network/loadBalancers/loadBalancingRules/read", "Microsoft.Network/loadBalancers/read"},
+		"azurerm_network_security_group":   {"Microsoft.Network/networkSecurityGroups/read"},
+		"azurerm_postgresql_database":      {"Microsoft.DBforPostgreSQL/servers/databases/read", "Microsoft.DBforPostgreSQL/servers/read"},
+		"azurerm_postgresql_server":        {"Microsoft.DBforPostgreSQL/servers/read"},
+		"azurerm_private_dns_a_record":     {"Microsoft.Network/privateDnsZones/A/read", "Microsoft.Network/privateDnsZones/read"},
+		"azurerm_private_dns_aaaa_record":  {"Microsoft.Network/privateDnsZones/AAAA/read", "Microsoft.Network/privateDnsZones/read"},
+		"azurerm_private_dns_cname_record": {"Microsoft.Network/privateDnsZones/CNAME/read", "Microsoft.Network/privateDnsZones/read"},
+		"azurerm_private_dns_mx_record":    {"Microsoft.Network/privateDnsZones/MX/read", "Microsoft.Network/privateDnsZones/read"},
+		"azurerm_private_dns_ptr_record":   {"Microsoft.Network/privateDnsZones/PTR/read", "Microsoft.Network/privateDnsZones/read"},
+		"azurerm_private_dns_srv_record":   {"Microsoft.Network/privateDnsZones/SRV/read", "Microsoft.Network/privateDnsZones/read"},
+		"azurerm_private_dns_txt_record":   {"Microsoft.Network/privateDnsZones/TXT/read", "Microsoft.Network/privateDnsZones/read"},
+		"azurerm_private_dns_zone":         {"Microsoft.Network/privateDnsZones/read"},
+		"azurerm_public_ip":                {"Microsoft.Network/publicIPAddresses/read"},
+		"azurerm_resource_group":           {"Microsoft.Resources/subscriptions/resourceGroups/read"},
+		"azurerm_route":                    {"Microsoft.Network/routeTables/read"},
+		"azurerm_route_table":              {"Microsoft.Network/routeTables/read"},
+		"azurerm_ssh_public_key":           {"Microsoft.Compute/sshPublicKeys/read"},
+		"azurerm_storage_account":          {"Microsoft.Storage/storageAccounts/read"},
+		"azurerm_storage_container":        {"Microsoft.Storage/storageAccounts/blobServices/containers/read", "Microsoft.Storage/storageAccounts/read"},
+		"azurerm_subnet":                   {"Microsoft.Network/virtualNetworks/read", "Microsoft.Network/virtualNetworks/subnets/read"},
+		"azurerm_virtual_network":          {"Microsoft.Network/virtualNetworks/read"},
+	},
+	common.RemoteGithubTerraform: {
+		"github_branch_protection": {"repo"},
+		"github_membership":        {"read:org"},
+		"github_repository":        {"repo"},
+		"github_team":              {"read:org"},
+		"github_team_membership":   {"read:org"},
+	},
+}
+
+// middlewareTypes holds the resource types each middleware creates, removes or rewrites,
+// middlewares touching every resource type like TagsAllManager are left out
+var middlewareTypes = map[string][]string{
+	"AwsALBListenerTransformer":                   {"aws_alb_listener", "aws_lb_listener"},
+	"AwsALBTransformer":                           {"aws_alb", "aws_lb"},
+	"AwsApiGatewayApiExpander":                    {"aws_api_gateway_gateway_response", "aws_api_gateway_integration", "aws_api_gateway_integration_response", "aws_api_gateway_method", "aws_api_gateway_method_response", "aws_api_gateway_resource", "aws_api_gateway_rest_api", "aws_apigatewayv2_api", "aws_apigatewayv2_integration", "aws_apigatewayv2_route"},
+	"AwsApiGatewayBasePathMappingReconciler":      {"aws_api_gateway_base_path_mapping", "aws_apigatewayv2_api_mapping"},
+	"AwsApiGatewayDeploymentExpander":             {"aws_api_gateway_deployment", "aws_api_gateway_stage"},
+	"AwsApiGatewayDomainNamesReconciler":          {"aws_api_gateway_domain_name", "aws_apigatewayv2_domain_name"},
+	"AwsApiGatewayResourceExpander":               {"aws_api_gateway_resource", "aws_api_gateway_rest_api"},
+	"AwsApiGatewayRestApiPolicyExpander":          {"aws_api_gateway_rest_api", "aws_api_gateway_rest_api_policy"},
+	"AwsBucketPolicyExpander":                     {"aws_s3_bucket", "aws_s3_bucket_policy"},
+	"AwsConsoleApiGatewayGatewayResponse":         {"aws_api_gateway_gateway_response"},
+	"AwsDefaultApiGatewayAccount":                 {"aws_api_gateway_account"},
+	"AwsDefaultInternetGateway":                   {"aws_internet_gateway"},
+	"AwsDefaultInternetGatewayRoute":              {"aws_route"},
+	"AwsDefaultNetworkACL":                        {"aws_default_network_acl"},
+	"AwsDefaultNetworkACLRule":                    {"aws_network_acl_rule"},
+	"AwsDefaultRoute":                             {"aws_route"},
+	"AwsDefaultRouteTable":                        {"aws_default_route_table"},
+	"AwsDefaultSQSQueuePolicy":                    {"aws_sqs_queue_policy"},
+	"AwsDefaultSecurityGroupRule":                 {"aws_security_group_rule"},
+	"AwsDefaultSubnet":                            {"aws_default_subnet"},
+	"AwsDefaultVPC":                               {"aws_default_vpc"},
+	"AwsDefaults":                                 {"aws_iam_role", "aws_iam_role_policy"},
+	"AwsEbsEncryptionByDefaultReconciler":         {"aws_ebs_encryption_by_default"},
+	"AwsInstanceBlockDeviceResourceMapper":        {"aws_ebs_volume", "aws_instance"},
+	"AwsInstanceEIP":                              {"aws_eip", "aws_eip_association", "aws_instance"},
+	"AwsNatGatewayEipAssoc":                       {"aws_eip_association", "aws_nat_gateway"},
+	"AwsNetworkACLExpander":                       {"aws_default_network_acl", "aws_network_acl", "aws_network_acl_rule"},
+	"AwsRDSClusterInstanceExpander":               {"aws_db_instance", "aws_rds_cluster_instance"},
+	"AwsRoleManagedPolicyExpander":                {"aws_iam_policy_attachment", "aws_iam_role"},
+	"AwsRouteTableExpander":                       {"aws_default_route_table", "aws_route", "aws_route_table"},
+	"AwsS3BucketPublicAccessBlockReconciler":      {"aws_s3_bucket_public_access_block"},
+	"AwsSNSTopicPolicyExpander":                   {"aws_sns_topic", "aws_sns_topic_policy"},
+	"AwsSQSQueuePolicyExpander":                   {"aws_sqs_queue", "aws_sqs_queue_policy"},
+	"AzurermRouteExpander":                        {"azurerm_route", "azurerm_route_table"},
+	"AzurermSubnetExpander":                       {"azurerm_subnet", "azurerm_virtual_network"},
+	"EipAssociationExpander":                      {"aws_eip", "aws_eip_association"},
+	"GoogleComputeInstanceGroupManagerReconciler": {"google_compute_instance_group", "google_compute_instance_group_manager"},
+	"GoogleDefaultIAMMember":                      {"google_project_iam_member"},
+	"GoogleIAMBindingTransformer":                 {"google_project_iam_binding", "google_project_iam_member", "google_storage_bucket_iam_binding", "google_storage_bucket_iam_member"},
+	"GoogleLegacyBucketIAMMember":                 {"google_storage_bucket_iam_member"},
+	"GoogleStorageBucketIAMPolicyTransformer":     {"google_project_iam_member", "google_project_iam_policy", "google_storage_bucket_iam_member", "google_storage_bucket_iam_policy"},
+	"IamPolicyAttachmentExpander":                 {"aws_iam_group_policy_attachment", "aws_iam_policy_attachment", "aws_iam_role_policy_attachment", "aws_iam_user_policy_attachment"},
+	"IamPolicyAttachmentTransformer":              {"aws_iam_group_policy_attachment", "aws_iam_policy_attachment", "aws_iam_role_policy_attachment", "aws_iam_user_policy_attachment"},
+	"Route53DefaultZoneRecordSanitizer":           {"aws_route53_record"},
+	"Route53RecordIDReconcilier":                  {"aws_route53_record"},
+	"S3BucketAcl":                                 {"aws_s3_bucket"},
+	"VPCDefaultSecurityGroupSanitizer":            {"aws_default_security_group"},
+	"VPCSecurityGroupRuleSanitizer":               {"aws_security_group_rule"},
+}
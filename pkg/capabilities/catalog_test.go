@@ -0,0 +1,221 @@
+package capabilities
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+	"github.com/khulnasoft-lab/driftctl/pkg/middlewares"
+)
+
+// remoteSources are the directories of the enumerators of each remote, relative to the root of the repository
+var remoteSources = map[string]string{
+	common.RemoteAWSTerraform:    "enumeration/remote/aws",
+	common.RemoteGoogleTerraform: "enumeration/remote/google",
+	common.RemoteAzureTerraform:  "enumeration/remote/azurerm",
+	common.RemoteGithubTerraform: "enumeration/remote/github",
+}
+
+// middlewaresOfEveryType touch every resource type and are left out of the catalog
+var middlewaresOfEveryType = []string{"TagsAllManager"}
+
+const repositoryRoot = "../.."
+
+// Enumerators can't be registered without credentials, the types they enumerate are read from the sources of the
+// remotes instead: each enumerator passed to AddEnumerator in init.go is resolved to the constant returned by its
+// SupportedType method.
+func TestCatalog_PermissionsMatchRegisteredEnumerators(t *testing.T) {
+	constants := make(map[string]string)
+	dirs, err := filepath.Glob(filepath.Join(repositoryRoot, "enumeration/resource/*"))
+	require.NoError(t, err)
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		for _, file := range parseDir(t, dir) {
+			for name, value := range stringConstants(file) {
+				constants[name] = value
+			}
+		}
+	}
+
+	for remote, dir := range remoteSources {
+		t.Run(remote, func(t *testing.T) {
+			constructors := make(map[string]string)
+			supportedTypes := make(map[string]string)
+			registered := make([]string, 0)
+			for _, file := range parseDir(t, filepath.Join(repositoryRoot, dir)) {
+				for _, decl := range file.Decls {
+					fn, ok := decl.(*ast.FuncDecl)
+					if !ok {
+						continue
+					}
+					if fn.Recv == nil && fn.Type.Results != nil && len(fn.Type.Results.List) > 0 {
+						constructors[fn.Name.Name] = typeName(fn.Type.Results.List[0].Type)
+					}
+					if fn.Recv != nil && fn.Name.Name == "SupportedType" {
+						supportedTypes[typeName(fn.Recv.List[0].Type)] = returnedConstant(fn)
+					}
+				}
+				ast.Inspect(file, func(node ast.Node) bool {
+					call, ok := node.(*ast.CallExpr)
+					if !ok || len(call.Args) != 1 {
+						return true
+					}
+					if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "AddEnumerator" {
+						return true
+					}
+					if constructor, ok := call.Args[0].(*ast.CallExpr); ok {
+						registered = append(registered, typeName(constructor.Fun))
+					}
+					return true
+				})
+			}
+			require.NotEmpty(t, registered)
+
+			enumerated := make([]string, 0, len(registered))
+			for _, constructor := range registered {
+				ty, exist := constants[supportedTypes[constructors[constructor]]]
+				if !assert.Truef(t, exist, "unable to resolve the type enumerated by %s", constructor) {
+					continue
+				}
+				enumerated = append(enumerated, ty)
+			}
+
+			catalog := make([]string, 0, len(permissions[remote]))
+			for ty := range permissions[remote] {
+				catalog = append(catalog, ty)
+			}
+			sort.Strings(enumerated)
+			sort.Strings(catalog)
+			assert.Equal(t, enumerated, catalog, "permissions of the catalog must list every registered enumerator")
+		})
+	}
+}
+
+// The middlewares of a scan are built in driftctl.go, the non strict ones by NewNonStrictChain
+func TestCatalog_MiddlewaresMatchScanChain(t *testing.T) {
+	constructors := make(map[string]string)
+	for _, file := range parseDir(t, filepath.Join(repositoryRoot, "pkg/middlewares")) {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Type.Results != nil && len(fn.Type.Results.List) > 0 {
+				constructors[fn.Name.Name] = typeName(fn.Type.Results.List[0].Type)
+			}
+		}
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(repositoryRoot, "pkg/driftctl.go"), nil, 0)
+	require.NoError(t, err)
+	chain := make(map[string]struct{})
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			if name := selectorOf(n.Fun, "middlewares"); name != "" && constructors[name] != "" && name != "NewChain" && name != "NewNonStrictChain" {
+				chain[constructors[name]] = struct{}{}
+			}
+		case *ast.CompositeLit:
+			if name := selectorOf(n.Type, "middlewares"); name != "" {
+				chain[name] = struct{}{}
+			}
+		}
+		return true
+	})
+	for _, middleware := range middlewares.NewNonStrictChain() {
+		chain[middlewareName(middleware)] = struct{}{}
+	}
+	require.NotEmpty(t, chain)
+
+	for middleware := range middlewareTypes {
+		_, exist := chain[middleware]
+		assert.Truef(t, exist, "%s is not a middleware of the scan", middleware)
+	}
+	for middleware := range chain {
+		_, exist := middlewareTypes[middleware]
+		assert.Truef(t, exist || contains(middlewaresOfEveryType, middleware), "middleware %s is missing from the catalog", middleware)
+	}
+}
+
+// parseDir parses the Go files of a directory, tests and mocks left aside
+func parseDir(t *testing.T, dir string) []*ast.File {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	require.NoError(t, err)
+	files := make([]*ast.File, 0, len(paths))
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || strings.HasPrefix(filepath.Base(path), "mock_") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+	return files
+}
+
+func stringConstants(file *ast.File) map[string]string {
+	constants := make(map[string]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				if i >= len(value.Values) {
+					break
+				}
+				if lit, ok := value.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					constants[name.Name], _ = strconv.Unquote(lit.Value)
+				}
+			}
+		}
+	}
+	return constants
+}
+
+// typeName returns the name of a type or function without pointer nor package, e.g. S3BucketEnumerator
+func typeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return typeName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+func returnedConstant(fn *ast.FuncDecl) string {
+	if fn.Body == nil || len(fn.Body.List) != 1 {
+		return ""
+	}
+	ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return ""
+	}
+	return typeName(ret.Results[0])
+}
+
+// selectorOf returns the name selected in the given package, e.g. NewAwsDefaults for middlewares.NewAwsDefaults
+func selectorOf(expr ast.Expr, pkg string) string {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := sel.X.(*ast.Ident); !ok || ident.Name != pkg {
+		return ""
+	}
+	return sel.Sel.Name
+}
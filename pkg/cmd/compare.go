@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/cmd/scan/output"
+)
+
+func NewCompareCmd(opts *pkg.CompareOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare <before.json> <after.json>",
+		Short: "Compare two analyses",
+		Long:  "Compare two analyses written with the JSON output and report what changed between them",
+		Args:  cobra.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			outputFlag, _ := cmd.Flags().GetStringSlice("output")
+			if len(outputFlag) > 1 {
+				return errors.New("Only one output format can be set")
+			}
+			out, err := parseOutputFlags(outputFlag)
+			if err != nil {
+				return err
+			}
+			opts.Output = out[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCompare(opts, args[0], args[1])
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringSliceP(
+		"output",
+		"o",
+		[]string{output.Example(output.ConsoleOutputType)},
+		"Output format, by default it will write to the console\n"+
+			"Accepted formats are: "+strings.Join([]string{output.ConsoleOutputExample, output.JSONOutputExample}, ",")+"\n",
+	)
+
+	return cmd
+}
+
+func runCompare(opts *pkg.CompareOptions, beforePath, afterPath string) error {
+	out, err := output.GetComparisonOutput(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	before, err := readAnalysis(beforePath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read analysis %s", beforePath)
+	}
+
+	after, err := readAnalysis(afterPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read analysis %s", afterPath)
+	}
+
+	return out.WriteComparison(analyser.CompareAnalyses(before, after))
+}
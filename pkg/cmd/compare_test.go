@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/pkg/cmd/scan/output"
+	"github.com/khulnasoft-lab/driftctl/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareCmd_Args(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "root"}
+	compareCmd := NewCompareCmd(&pkg.CompareOptions{})
+	compareCmd.RunE = func(_ *cobra.Command, args []string) error { return nil }
+	rootCmd.AddCommand(compareCmd)
+
+	cases := []struct {
+		args []string
+		err  string
+	}{
+		{args: []string{"compare", "before.json", "after.json"}},
+		{args: []string{"compare", "before.json", "after.json", "-o", "json://result.json"}},
+		{args: []string{"compare", "before.json"}, err: "accepts 2 arg(s), received 1"},
+		{args: []string{"compare", "before.json", "after.json", "-o", "json://a.json", "-o", "console://"}, err: "Only one output format can be set"},
+	}
+
+	for _, tt := range cases {
+		_, err := test.Execute(rootCmd, tt.args...)
+		if tt.err == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+func Test_runCompare_UnsupportedOutput(t *testing.T) {
+	opts := &pkg.CompareOptions{
+		Output: output.OutputConfig{
+			Key:  output.HTMLOutputType,
+			Path: "result.html",
+		},
+	}
+
+	err := runCompare(opts, "testdata/compare/before.json", "testdata/compare/after.json")
+	require.NotNil(t, err)
+	assert.Equal(t, "Output 'html' does not support comparisons, use console or json", err.Error())
+}
+
+func Test_runCompare_InvalidInput(t *testing.T) {
+	opts := &pkg.CompareOptions{
+		Output: output.OutputConfig{
+			Key: output.ConsoleOutputType,
+		},
+	}
+
+	err := runCompare(opts, "testdata/fmt/input_stdin_invalid.json", "testdata/compare/after.json")
+	require.NotNil(t, err)
+	assert.Equal(t, "unable to read analysis testdata/fmt/input_stdin_invalid.json: invalid character 'i' looking for beginning of value", err.Error())
+}
+
+func Test_runCompare_Console(t *testing.T) {
+	opts := &pkg.CompareOptions{
+		Output: output.OutputConfig{
+			Key: output.ConsoleOutputType,
+		},
+	}
+
+	stdout := os.Stdout // keep backup of the real stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runCompare(opts, "testdata/compare/before.json", "testdata/compare/after.json")
+
+	outC := make(chan []byte)
+	// copy the output in a separate goroutine so printing can't block indefinitely
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		outC <- buf.Bytes()
+	}()
+
+	// back to normal state
+	assert.Nil(t, w.Close())
+	os.Stdout = stdout // restoring the real stdout
+	result := <-outC
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := os.ReadFile("testdata/compare/expected_console.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, string(expected), string(result))
+}
+
+func Test_runCompare_JSON(t *testing.T) {
+	resultPath := path.Join(t.TempDir(), "result.json")
+	opts := &pkg.CompareOptions{
+		Output: output.OutputConfig{
+			Key:  output.JSONOutputType,
+			Path: resultPath,
+		},
+	}
+
+	err := runCompare(opts, "testdata/compare/before.json", "testdata/compare/after.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := os.ReadFile(resultPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile("testdata/compare/expected.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, string(expected), string(result))
+}
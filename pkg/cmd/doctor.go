@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/enumeration/terraform"
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/pkg/doctor"
+	"github.com/khulnasoft-lab/driftctl/pkg/filter"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
+	globaloutput "github.com/khulnasoft-lab/driftctl/pkg/output"
+	dctlresource "github.com/khulnasoft-lab/driftctl/pkg/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/resource/schemas"
+)
+
+func NewDoctorCmd(opts *pkg.DoctorOptions) *cobra.Command {
+	opts.BackendOptions = &backend.Options{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check credentials, permissions and state backends before a scan",
+		Long: "This command checks, for each selected cloud provider, that its Terraform provider can be configured with the " +
+			"current credentials, then which resource types are readable by running the enumerators of a scan. Enumerators able " +
+			"to probe their permissions only list a single resource, others list every resource of their type as in a scan. " +
+			"Details of resources are not read. It prints a minimal policy granting the missing permissions and checks that every state file of the " +
+			"IaC sources is reachable.\n\n" +
+			"With --dry-run, it only lists the enumerators and state files a scan would use, globs of IaC sources are still expanded.\n\n" +
+			"Example: driftctl doctor --to aws+tf --from tfstate+s3://my-bucket/path/to/state.tfstate",
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			from, _ := cmd.Flags().GetStringSlice("from")
+			iacSource, err := parseFromFlag(from)
+			if err != nil {
+				return err
+			}
+			opts.From = iacSource
+
+			toFlag, _ := cmd.Flags().GetStringSlice("to")
+			opts.To, err = parseToFlag(toFlag)
+			if err != nil {
+				return err
+			}
+
+			opts.ProviderVersions, err = parseProviderVersions(cmd, opts.To)
+			if err != nil {
+				return err
+			}
+
+			opts.ConfigDir, _ = cmd.Flags().GetString("config-dir")
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(opts, cmd.OutOrStdout())
+		},
+	}
+
+	fl := cmd.Flags()
+	addFromFlag(fl)
+	addRemoteFlags(fl)
+	addBackendFlags(fl, opts.BackendOptions)
+	fl.StringVar(&opts.DriftignorePath,
+		"driftignore",
+		".driftignore",
+		"Path to the driftignore file, resource types it ignores are left out as a scan does not enumerate them\n",
+	)
+	fl.BoolVar(&opts.DryRun,
+		"dry-run",
+		false,
+		"Only list the enumerators and state files a scan would use, without reading them\n",
+	)
+
+	return cmd
+}
+
+func runDoctor(opts *pkg.DoctorOptions, out io.Writer) error {
+	sources, err := defaultIaCSources(opts.From)
+	if err != nil {
+		return err
+	}
+
+	driftIgnore := filter.NewDriftIgnore(opts.DriftignorePath)
+	ignored := func(ty string) bool {
+		return driftIgnore.IsTypeIgnored(resource.ResourceType(ty))
+	}
+
+	report := doctor.Report{DryRun: opts.DryRun}
+	for _, to := range opts.To {
+		if opts.DryRun {
+			report.Remotes = append(report.Remotes, doctor.RemoteCheck{
+				Name:        to,
+				Status:      doctor.StatusSkipped,
+				Enumerators: doctor.DryRunEnumerators(to, ignored),
+			})
+			continue
+		}
+		report.Remotes = append(report.Remotes, checkRemote(to, opts, ignored))
+	}
+	report.States = doctor.CheckStates(sources, opts.BackendOptions, opts.DryRun)
+
+	report.Write(out)
+
+	if problems := report.Problems(); problems > 0 {
+		return errors.Errorf("%d problem(s) found, a scan would miss resources or fail", problems)
+	}
+	return nil
+}
+
+// checkRemote activates a cloud provider in its own libraries, so that only its enumerators are checked. Credentials are
+// only checked by the activation, a failing provider configuration is reported as a failed remote.
+func checkRemote(to string, opts *pkg.DoctorOptions, ignored doctor.TypeFilter) doctor.RemoteCheck {
+	check := doctor.RemoteCheck{Name: to, Status: doctor.StatusOK}
+
+	providerLibrary := terraform.NewProviderLibrary()
+	remoteLibrary := common.NewRemoteLibrary()
+	defer providerLibrary.Cleanup()
+
+	progress := globaloutput.NewProgress(fmt.Sprintf("Checking %s resource types", to), fmt.Sprintf("Checked %s resource types", to), true)
+	schemaRepository := schemas.NewSchemaRepository()
+	resFactory := dctlresource.NewDriftctlResourceFactory(schemaRepository)
+
+	_, err := activateRemote(to, opts.ProviderVersions[to], opts.ConfigDir, alerter.NewAlerter(), providerLibrary, remoteLibrary, progress, resFactory, schemaRepository)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"remote": to, "error": err}).Debug("Unable to activate remote")
+		check.Status = doctor.StatusFailed
+		check.Error = err.Error()
+		return check
+	}
+
+	progress.Start()
+	check.Enumerators = doctor.CheckEnumerators(to, remoteLibrary, ignored, progress)
+	progress.Stop()
+	return check
+}
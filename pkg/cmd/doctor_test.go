@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/config"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
+)
+
+func Test_runDoctor_DryRun(t *testing.T) {
+	opts := &pkg.DoctorOptions{
+		From:            []config.SupplierConfig{{Key: "tfstate", Path: "testdata/doctor/terraform.tfstate"}},
+		To:              []string{"github+tf"},
+		BackendOptions:  &backend.Options{},
+		DriftignorePath: "testdata/doctor/.driftignore",
+		DryRun:          true,
+	}
+
+	out := &bytes.Buffer{}
+	err := runDoctor(opts, out)
+	assert.NoError(t, err)
+
+	expected, err := os.ReadFile("testdata/doctor/dry_run.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(expected), out.String())
+}
+
+func Test_runDoctor_UnreachableState(t *testing.T) {
+	opts := &pkg.DoctorOptions{
+		From:            []config.SupplierConfig{{Key: "tfstate", Path: "testdata/doctor/doesnotexist.tfstate"}},
+		BackendOptions:  &backend.Options{},
+		DriftignorePath: "testdata/doctor/.driftignore",
+	}
+
+	out := &bytes.Buffer{}
+	err := runDoctor(opts, out)
+	assert.EqualError(t, err, "1 problem(s) found, a scan would miss resources or fail")
+	assert.Equal(t, "State files:\n  - tfstate://testdata/doctor/doesnotexist.tfstate unreachable: lstat testdata/doctor/doesnotexist.tfstate: no such file or directory\n", out.String())
+}
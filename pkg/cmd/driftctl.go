@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/khulnasoft-lab/driftctl/build"
 	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/pkg/config"
 	"github.com/khulnasoft-lab/driftctl/sentry"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -41,7 +46,12 @@ func NewDriftctlCmd(build build.BuildInterface) *DriftctlCmd {
 			Use:   "driftctl <command> [flags]",
 			Short: "Driftctl CLI",
 			PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-				err := bindEnvToFlags(cmd)
+				// Values of the project file are bound first, they take precedence over env variables
+				err := bindProjectToFlags(cmd)
+				if err != nil {
+					return err
+				}
+				err = bindEnvToFlags(cmd)
 				if err != nil {
 					return err
 				}
@@ -66,11 +76,19 @@ func NewDriftctlCmd(build build.BuildInterface) *DriftctlCmd {
 		cmd.PersistentFlags().BoolP("no-version-check", "", false, "Disable the version check")
 		cmd.PersistentFlags().BoolP("disable-telemetry", "", false, "Disable telemetry")
 	}
+	cmd.PersistentFlags().String("config-file", config.DefaultProjectFile, "Project configuration file holding the flags of each command and named profiles")
+	cmd.PersistentFlags().String("profile", "", "Profile of the project configuration file to use, its values override the ones shared by every profile")
 	cmd.PersistentFlags().BoolP("send-crash-report", "", false, "Enable error reporting. Crash data will be sent to us via Sentry.\nWARNING: may leak sensitive data (please read the documentation for more details)\nThis flag should be used only if an error occurs during execution")
 
 	cmd.AddCommand(NewScanCmd(&pkg.ScanOptions{}))
 	cmd.AddCommand(NewFmtCmd(&pkg.FmtOptions{}))
+	cmd.AddCommand(NewCompareCmd(&pkg.CompareOptions{}))
 	cmd.AddCommand(NewGenDriftIgnoreCmd())
+	cmd.AddCommand(NewDriftIgnoreCmd())
+	cmd.AddCommand(NewGenImportsCmd(&pkg.GenImportsOptions{}))
+	cmd.AddCommand(NewResourcesCmd(&pkg.ResourcesOptions{}))
+	cmd.AddCommand(NewServeCmd(&pkg.ServeOptions{}))
+	cmd.AddCommand(NewDoctorCmd(&pkg.DoctorOptions{}))
 
 	return cmd
 }
@@ -142,3 +160,123 @@ func bindEnvToFlags(cmd *cobra.Command) error {
 
 	return err
 }
+
+// bindProjectToFlags sets the flags not given on the command line from the project configuration file, using the
+// options of the selected profile. A missing file is only an error when it was explicitly requested.
+func bindProjectToFlags(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString("config-file")
+	explicit := cmd.Flags().Changed("config-file")
+	if !explicit && viper.IsSet("config_file") {
+		path, explicit = viper.GetString("config_file"), true
+	}
+	profile, _ := cmd.Flags().GetString("profile")
+	if !cmd.Flags().Changed("profile") && viper.IsSet("profile") {
+		profile = viper.GetString("profile")
+	}
+
+	project, err := config.ReadProject(path)
+	if os.IsNotExist(errors.Cause(err)) && !explicit && profile == "" {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	commandPath := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if err := validateProjectCommands(cmd.Root(), project); err != nil {
+		return err
+	}
+	options, err := project.Options(commandPath, profile)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || name == "config-file" || name == "profile" {
+			return errors.Errorf("%s: unknown option '%s' for command '%s'", project.Path, name, commandPath)
+		}
+		if f.Changed {
+			continue
+		}
+		if err := setFlag(f, options[name]); err != nil {
+			return errors.Errorf("%s: invalid value for option '%s' of command '%s': %s", project.Path, name, commandPath, err)
+		}
+		f.Changed = true
+		logrus.WithFields(logrus.Fields{
+			"file":    project.Path,
+			"profile": profile,
+			"flag":    name,
+		}).Debug("Bound project configuration to flag")
+	}
+	return nil
+}
+
+// validateProjectCommands makes sure every command of the project exists, to catch typos
+func validateProjectCommands(root *cobra.Command, project *config.Project) error {
+	known := make(map[string]struct{})
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, child := range cmd.Commands() {
+			known[strings.TrimPrefix(child.CommandPath(), root.Name()+" ")] = struct{}{}
+			walk(child)
+		}
+	}
+	walk(root)
+
+	for _, command := range project.CommandPaths() {
+		if _, exist := known[command]; !exist {
+			return errors.Errorf("%s: unknown command '%s'", project.Path, command)
+		}
+	}
+	return nil
+}
+
+// setFlag sets a flag from a value of the project file: lists are only accepted by slice flags, maps by key=value
+// flags such as --headers
+func setFlag(f *pflag.Flag, value interface{}) error {
+	switch v := value.(type) {
+	case []interface{}:
+		sliceValue, ok := f.Value.(pflag.SliceValue)
+		if !ok {
+			return errors.New("a single value is expected")
+		}
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, scalarString(item))
+		}
+		return sliceValue.Replace(values)
+	case map[string]interface{}:
+		if f.Value.Type() != "stringToString" {
+			return errors.New("a map is only accepted by key=value flags")
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := f.Value.Set(fmt.Sprintf("%s=%s", key, scalarString(v[key]))); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		if sliceValue, ok := f.Value.(pflag.SliceValue); ok {
+			return sliceValue.Replace([]string{scalarString(v)})
+		}
+		return f.Value.Set(scalarString(v))
+	}
+}
+
+func scalarString(value interface{}) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -13,6 +14,136 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestDriftctlCmd_Project(t *testing.T) {
+	project := `
+scan:
+  to: aws+tf
+  output: [console://, json://result.json]
+  tf-provider-version: 3.19.0
+profiles:
+  prod-us:
+    scan:
+      from:
+        - tfstate+s3://states/prod-us/network.tfstate
+        - tfstate+s3://states/prod-us/app.tfstate
+      headers:
+        Authorization: Bearer token
+  staging:
+    scan:
+      to: gcp+tf
+`
+
+	cases := []struct {
+		name     string
+		project  string
+		env      map[string]string
+		args     []string
+		expected map[string]string
+		err      string
+	}{
+		{
+			name:    "shared options",
+			project: project,
+			expected: map[string]string{
+				"to":                  "[aws+tf]",
+				"output":              "[console://,json://result.json]",
+				"tf-provider-version": "3.19.0",
+				"from":                "[]",
+			},
+		},
+		{
+			name:    "profile options",
+			project: project,
+			args:    []string{"--profile", "prod-us"},
+			expected: map[string]string{
+				"to":      "[aws+tf]",
+				"from":    "[tfstate+s3://states/prod-us/network.tfstate,tfstate+s3://states/prod-us/app.tfstate]",
+				"headers": "[Authorization=Bearer token]",
+			},
+		},
+		{
+			name:     "profile from env",
+			project:  project,
+			env:      map[string]string{"DCTL_PROFILE": "staging"},
+			expected: map[string]string{"to": "[gcp+tf]"},
+		},
+		{
+			name:     "flags override profile",
+			project:  project,
+			args:     []string{"--profile", "staging", "--to", "github+tf", "--output", "json://out.json"},
+			expected: map[string]string{"to": "[github+tf]", "output": "[json://out.json]"},
+		},
+		{
+			name:     "profile overrides env",
+			project:  project,
+			env:      map[string]string{"DCTL_TO": "azure+tf", "DCTL_TF_PROVIDER_VERSION": "4.0.0"},
+			args:     []string{"--profile", "staging"},
+			expected: map[string]string{"to": "[gcp+tf]", "tf-provider-version": "3.19.0"},
+		},
+		{
+			name:     "env applies to options missing from the project",
+			project:  project,
+			env:      map[string]string{"DCTL_TF_LOCKFILE": "custom.lock.hcl"},
+			expected: map[string]string{"tf-lockfile": "custom.lock.hcl"},
+		},
+		{
+			name:    "unknown profile",
+			project: project,
+			args:    []string{"--profile", "prod-eu"},
+			err:     "unknown profile 'prod-eu' in PROJECT\nAvailable profiles are: prod-us, staging",
+		},
+		{
+			name:    "unknown option",
+			project: "scan:\n  form: tfstate://terraform.tfstate\n",
+			err:     "PROJECT: unknown option 'form' for command 'scan'",
+		},
+		{
+			name:    "unknown command",
+			project: "scna:\n  to: aws+tf\n",
+			err:     "PROJECT: unknown command 'scna'",
+		},
+		{
+			name:    "list given to a single value option",
+			project: "scan:\n  tf-lockfile: [.terraform.lock.hcl]\n",
+			err:     "PROJECT: invalid value for option 'tf-lockfile' of command 'scan': a single value is expected",
+		},
+		{
+			name: "missing project",
+			err:  "open PROJECT: no such file or directory",
+		},
+	}
+
+	config.Init()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for key, val := range c.env {
+				_ = os.Setenv(key, val)
+				defer os.Unsetenv(key)
+			}
+			path := filepath.Join(t.TempDir(), ".driftctl.yaml")
+			if c.project != "" {
+				if err := os.WriteFile(path, []byte(c.project), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd := NewDriftctlCmd(mocks.MockBuild{})
+			scanCmd, _, _ := cmd.Find([]string{"scan"})
+			scanCmd.RunE = func(_ *cobra.Command, args []string) error { return nil }
+			args := append([]string{"scan", "--config-file", path}, c.args...)
+			_, err := test.Execute(&cmd.Command, args...)
+			if c.err != "" {
+				assert.EqualError(t, err, strings.ReplaceAll(c.err, "PROJECT", path))
+				return
+			}
+			assert.NoError(t, err)
+			for name, value := range c.expected {
+				assert.Equal(t, value, scanCmd.Flags().Lookup(name).Value.String(), name)
+			}
+		})
+	}
+}
+
 func TestDriftctlCmd_Help(t *testing.T) {
 	cmd := NewDriftctlCmd(mocks.MockBuild{})
 
@@ -94,7 +225,7 @@ func TestDriftctlCmd_Scan(t *testing.T) {
 			env: map[string]string{
 				"DCTL_OUTPUT": "test",
 			},
-			err: fmt.Errorf("Unable to parse output flag 'test': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err: fmt.Errorf("Unable to parse output flag 'test': \nAccepted formats are: console://,dot://PATH/TO/FILE.dot,graph://PATH/TO/FILE.json,hcl://PATH/TO/FILE.tf,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif,template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"),
 		},
 		{
 			env: map[string]string{
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/driftignore"
+)
+
+func NewDriftIgnoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "driftignore",
+		Short: "Manage driftignore files",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(NewDriftIgnoreLintCmd(&pkg.DriftIgnoreLintOptions{}))
+
+	return cmd
+}
+
+func NewDriftIgnoreLintCmd(opts *pkg.DriftIgnoreLintOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Validate a driftignore file",
+		Long: "This command reports the invalid patterns and unknown resource types of a driftignore file, " +
+			"and the rules without effect because another rule decides instead of them.\n\n" +
+			"Given a JSON analysis it also reports the rules matching no resource. " +
+			"Ignored resources are left out of analyses, the scan must run without the driftignore file.\n\n" +
+			"Example: driftctl scan --driftignore /dev/null -o json://analysis.json; driftctl driftignore lint -i analysis.json --fix",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lintDriftIgnore(opts, cmd.OutOrStdout())
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&opts.DriftignorePath, "driftignore", ".driftignore", "Path to the driftignore file")
+	fl.StringVarP(&opts.InputPath, "input", "i", "", "JSON analysis of a scan run without the driftignore file, used to find unused rules. Use - for stdin.")
+	fl.BoolVar(&opts.Fix, "fix", false, "Rewrite the driftignore file without its unused rules, exact rules ignoring every resource of a type are replaced by a wildcard. Requires --input")
+
+	return cmd
+}
+
+func lintDriftIgnore(opts *pkg.DriftIgnoreLintOptions, out io.Writer) error {
+	if opts.Fix && opts.InputPath == "" {
+		return errors.New("--fix requires a JSON analysis given with --input")
+	}
+
+	file, err := driftignore.Read(opts.DriftignorePath)
+	if err != nil {
+		return err
+	}
+
+	var analysis *analyser.Analysis
+	if opts.InputPath != "" {
+		analysis, err = readAnalysis(opts.InputPath)
+		if err != nil {
+			return errors.Wrap(err, "unable to read the analysis")
+		}
+	}
+
+	if opts.Fix {
+		result, err := file.Fix(analysis)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(opts.DriftignorePath, file.Bytes(), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Removed %d unused rule(s), collapsed %d rule(s) into %d wildcard(s)\n", result.Removed, result.Collapsed, result.Wildcards)
+	}
+
+	issues := file.Lint(analysis)
+	for _, issue := range issues {
+		fmt.Fprintf(out, "%s:%d: %s (%s)\n", opts.DriftignorePath, issue.Line, issue.Message, issue.Kind)
+	}
+
+	if len(issues) > 0 {
+		return errors.Errorf("%d issue(s) found in %s", len(issues), opts.DriftignorePath)
+	}
+	return nil
+}
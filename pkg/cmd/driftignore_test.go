@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/pkg"
+)
+
+func Test_lintDriftIgnore(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     *pkg.DriftIgnoreLintOptions
+		expected string
+		err      string
+	}{
+		{
+			name: "test valid driftignore",
+			opts: &pkg.DriftIgnoreLintOptions{DriftignorePath: "testdata/driftignore/valid.driftignore"},
+		},
+		{
+			name:     "test driftignore with issues",
+			opts:     &pkg.DriftIgnoreLintOptions{DriftignorePath: "testdata/driftignore/invalid.driftignore"},
+			expected: "testdata/driftignore/invalid.driftignore:2: unknown resource type 'aws_s3_buckt', did you mean 'aws_s3_bucket'? (unknown-type)\n",
+			err:      "1 issue(s) found in testdata/driftignore/invalid.driftignore",
+		},
+		{
+			name: "test fix without analysis",
+			opts: &pkg.DriftIgnoreLintOptions{DriftignorePath: "testdata/driftignore/valid.driftignore", Fix: true},
+			err:  "--fix requires a JSON analysis given with --input",
+		},
+		{
+			name: "test missing driftignore",
+			opts: &pkg.DriftIgnoreLintOptions{DriftignorePath: "testdata/driftignore/doesnotexist"},
+			err:  "open testdata/driftignore/doesnotexist: no such file or directory",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := lintDriftIgnore(c.opts, out)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.expected, out.String())
+		})
+	}
+}
@@ -1,7 +1,22 @@
 package errors
 
+import (
+	"fmt"
+	"strings"
+)
+
 type InfrastructureNotInSync struct{}
 
 func (i InfrastructureNotInSync) Error() string {
 	return "Infrastructure is not in sync"
 }
+
+// PolicyViolation is returned when the analysis does not satisfy the failure policy, driftctl exits with ExitCode
+type PolicyViolation struct {
+	Rules    []string
+	ExitCode int
+}
+
+func (p PolicyViolation) Error() string {
+	return fmt.Sprintf("Failure policy violated by rule(s): %s", strings.Join(p.Rules, ", "))
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	cmderrors "github.com/khulnasoft-lab/driftctl/pkg/cmd/errors"
@@ -131,51 +132,84 @@ func parseOutputFlag(out string) (*output.OutputConfig, error) {
 	}
 
 	opts := schemeOpts[1:]
+	// Outputs written to an object storage have a second scheme, e.g. json://s3://bucket/key.json
+	if len(opts) > 1 && output.IsRemoteScheme(opts[0]) {
+		opts = []string{strings.Join(opts, "://")}
+	}
 
 	switch o.Key {
-	case output.JSONOutputType:
+	case output.JSONOutputType, output.HTMLOutputType, output.PlanOutputType, output.GraphOutputType,
+		output.DOTOutputType, output.HCLOutputType, output.SARIFOutputType, output.JUnitOutputType:
 		if len(opts) != 1 || opts[0] == "" {
-			return nil, errors.Wrapf(
-				cmderrors.NewUsageError(
-					fmt.Sprintf(
-						"\nMust be of kind: %s",
-						output.Example(output.JSONOutputType),
-					),
-				),
-				"Invalid json output '%s'",
-				out,
-			)
+			return nil, invalidOutputError(o.Key, out)
 		}
 		o.Path = opts[0]
-	case output.HTMLOutputType:
-		if len(opts) != 1 || opts[0] == "" {
-			return nil, errors.Wrapf(
-				cmderrors.NewUsageError(
-					fmt.Sprintf(
-						"\nMust be of kind: %s",
-						output.Example(output.HTMLOutputType),
-					),
-				),
-				"Invalid html output '%s'",
-				out,
-			)
+	case output.MarkdownOutputType:
+		if len(opts) != 1 || opts[0] == "" || strings.HasPrefix(opts[0], "?") {
+			return nil, invalidOutputError(o.Key, out)
 		}
-		o.Path = opts[0]
-	case output.PlanOutputType:
-		if len(opts) != 1 || opts[0] == "" {
+		path, query := opts[0], ""
+		if i := strings.Index(path, "?"); i >= 0 {
+			path, query = path[:i], path[i+1:]
+		}
+		options, err := output.ParseMarkdownOptions(query)
+		if err != nil {
 			return nil, errors.Wrapf(
 				cmderrors.NewUsageError(
 					fmt.Sprintf(
-						"\nMust be of kind: %s",
-						output.Example(output.PlanOutputType),
+						"\nValid options are: %s=N, %s=N",
+						output.MarkdownMaxSizeOption,
+						output.MarkdownMaxResourcesOption,
 					),
 				),
-				"Invalid plan output '%s'",
+				"Invalid markdown output '%s': %s",
 				out,
+				err,
 			)
 		}
-		o.Path = opts[0]
+		o.Path = path
+		if len(options) > 0 {
+			o.Options = options
+		}
+	case output.TemplateOutputType:
+		templatePath, _, ok := output.SplitTemplatePath(strings.Join(opts, "://"))
+		if !ok {
+			return nil, invalidOutputError(o.Key, out)
+		}
+		if _, err := os.Stat(templatePath); err != nil {
+			return nil, errors.Wrapf(err, "Invalid template output '%s'", out)
+		}
+		o.Path = strings.Join(opts, "://")
+	}
+
+	if backendKey, objectPath, ok := output.SplitRemotePath(o.Destination()); ok && !output.IsValidRemotePath(objectPath) {
+		return nil, errors.Wrapf(
+			cmderrors.NewUsageError(
+				fmt.Sprintf(
+					"\nRemote destination must be of kind: %s",
+					output.RemoteExample(backendKey),
+				),
+			),
+			"Invalid %s output '%s'",
+			o.Key,
+			out,
+		)
 	}
 
 	return o, nil
 }
+
+// invalidOutputError is the usage error of an output whose options do not match the example of its type
+func invalidOutputError(key, out string) error {
+	return errors.Wrapf(
+		cmderrors.NewUsageError(
+			fmt.Sprintf(
+				"\nMust be of kind: %s",
+				output.Example(key),
+			),
+		),
+		"Invalid %s output '%s'",
+		key,
+		out,
+	)
+}
@@ -83,7 +83,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{""},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unable to parse output flag '': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unable to parse output flag '': \nAccepted formats are: console://,dot://PATH/TO/FILE.dot,graph://PATH/TO/FILE.json,hcl://PATH/TO/FILE.tf,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif,template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"),
 		},
 		{
 			name: "test empty array",
@@ -99,7 +99,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{"sdgjsdgjsdg"},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unable to parse output flag 'sdgjsdgjsdg': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unable to parse output flag 'sdgjsdgjsdg': \nAccepted formats are: console://,dot://PATH/TO/FILE.dot,graph://PATH/TO/FILE.json,hcl://PATH/TO/FILE.tf,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif,template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"),
 		},
 		{
 			name: "test invalid",
@@ -107,7 +107,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{"://"},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unable to parse output flag '://': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unable to parse output flag '://': \nAccepted formats are: console://,dot://PATH/TO/FILE.dot,graph://PATH/TO/FILE.json,hcl://PATH/TO/FILE.tf,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif,template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"),
 		},
 		{
 			name: "test unsupported",
@@ -115,7 +115,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{"foobar://"},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unsupported output 'foobar': \nValid formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unsupported output 'foobar': \nValid formats are: console://,dot://PATH/TO/FILE.dot,graph://PATH/TO/FILE.json,hcl://PATH/TO/FILE.tf,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif,template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"),
 		},
 		{
 			name: "test empty json",
@@ -171,6 +171,231 @@ func Test_parseOutputFlag(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "test empty dot",
+			args: args{
+				out: []string{"dot://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid dot output 'dot://': \nMust be of kind: dot://PATH/TO/FILE.dot"),
+		},
+		{
+			name: "test empty hcl",
+			args: args{
+				out: []string{"hcl://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid hcl output 'hcl://': \nMust be of kind: hcl://PATH/TO/FILE.tf"),
+		},
+		{
+			name: "test valid hcl",
+			args: args{
+				out: []string{"hcl:///tmp/foobar.tf"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "hcl",
+					Path: "/tmp/foobar.tf",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test empty sarif",
+			args: args{
+				out: []string{"sarif://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid sarif output 'sarif://': \nMust be of kind: sarif://PATH/TO/FILE.sarif"),
+		},
+		{
+			name: "test valid sarif",
+			args: args{
+				out: []string{"sarif:///tmp/foobar.sarif"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "sarif",
+					Path: "/tmp/foobar.sarif",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test empty junit",
+			args: args{
+				out: []string{"junit://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid junit output 'junit://': \nMust be of kind: junit://PATH/TO/FILE.xml"),
+		},
+		{
+			name: "test valid junit",
+			args: args{
+				out: []string{"junit:///tmp/foobar.xml"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "junit",
+					Path: "/tmp/foobar.xml",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test empty markdown",
+			args: args{
+				out: []string{"markdown://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown://': \nMust be of kind: markdown://PATH/TO/FILE.md"),
+		},
+		{
+			name: "test valid markdown",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "markdown",
+					Path: "/tmp/foobar.md",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test valid markdown with options",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md?max-size=30000&max-resources=20"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "markdown",
+					Path: "/tmp/foobar.md",
+					Options: map[string]string{
+						"max-size":      "30000",
+						"max-resources": "20",
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test markdown with invalid option",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md?max-lines=10"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown:///tmp/foobar.md?max-lines=10': unknown option 'max-lines': \nValid options are: max-size=N, max-resources=N"),
+		},
+		{
+			name: "test empty template",
+			args: args{
+				out: []string{"template://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid template output 'template://': \nMust be of kind: template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"),
+		},
+		{
+			name: "test template without output path",
+			args: args{
+				out: []string{"template://scan/output/testdata/templates/report.md.tmpl"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid template output 'template://scan/output/testdata/templates/report.md.tmpl': \nMust be of kind: template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"),
+		},
+		{
+			name: "test template not found",
+			args: args{
+				out: []string{"template://missing.tmpl:/tmp/foobar.md"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid template output 'template://missing.tmpl:/tmp/foobar.md': stat missing.tmpl: no such file or directory"),
+		},
+		{
+			name: "test valid template",
+			args: args{
+				out: []string{"template://scan/output/testdata/templates/report.md.tmpl:/tmp/foobar.md"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "template",
+					Path: "scan/output/testdata/templates/report.md.tmpl:/tmp/foobar.md",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test json output to s3",
+			args: args{
+				out: []string{"json://s3://bucket/reports/%Y-%m-%d/scan.json"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "json",
+					Path: "s3://bucket/reports/%Y-%m-%d/scan.json",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test markdown output to google storage with options",
+			args: args{
+				out: []string{"markdown://gs://bucket/report.md?max-size=30000"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "markdown",
+					Path: "gs://bucket/report.md",
+					Options: map[string]string{
+						"max-size": "30000",
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test template output to azure blob storage",
+			args: args{
+				out: []string{"template://scan/output/testdata/templates/report.md.tmpl:azurerm://container/report.md"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "template",
+					Path: "scan/output/testdata/templates/report.md.tmpl:azurerm://container/report.md",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test remote output without object path",
+			args: args{
+				out: []string{"html://s3://bucket"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid html output 'html://s3://bucket': \nRemote destination must be of kind: s3://BUCKET/PATH/TO/OBJECT"),
+		},
+		{
+			name: "test remote output without path",
+			args: args{
+				out: []string{"json://azurerm://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid json output 'json://azurerm://': \nRemote destination must be of kind: azurerm://CONTAINER/PATH/TO/OBJECT"),
+		},
+		{
+			name: "test valid graph",
+			args: args{
+				out: []string{"graph:///tmp/foobar.json"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "graph",
+					Path: "/tmp/foobar.json",
+				},
+			},
+			err: nil,
+		},
 		{
 			name: "test multiple output values",
 			args: args{
@@ -197,7 +422,7 @@ func Test_parseOutputFlag(t *testing.T) {
 					Key: "console",
 				},
 			},
-			err: fmt.Errorf("Unsupported output 'invalid': \nValid formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err: fmt.Errorf("Unsupported output 'invalid': \nValid formats are: console://,dot://PATH/TO/FILE.dot,graph://PATH/TO/FILE.json,hcl://PATH/TO/FILE.tf,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif,template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"),
 		},
 		{
 			name: "test multiple valid output values",
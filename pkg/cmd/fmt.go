@@ -13,6 +13,8 @@ import (
 
 	"github.com/khulnasoft-lab/driftctl/pkg"
 	"github.com/khulnasoft-lab/driftctl/pkg/cmd/scan/output"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend/options"
 )
 
 func NewFmtCmd(opts *pkg.FmtOptions) *cobra.Command {
@@ -64,5 +66,11 @@ func runFmt(opts *pkg.FmtOptions, reader io.Reader) error {
 		return err
 	}
 
-	return output.GetOutput(opts.Output).Write(analysis)
+	return output.GetOutput(opts.Output, &backend.Options{
+		// fmt has no backend flags, remote outputs use the environment variables the flags of scan default to
+		AzureRMBackendOptions: options.AzureRMBackendOptions{
+			StorageAccount: os.Getenv("AZURE_STORAGE_ACCOUNT"),
+			StorageKey:     os.Getenv("AZURE_STORAGE_KEY"),
+		},
+	}).Write(analysis)
 }
@@ -112,7 +112,7 @@ func TestFmtCmd_Invalid(t *testing.T) {
 	}{
 		{args: []string{"fmt", "test"}, expected: `unknown command "test" for "root fmt"`},
 		{args: []string{"fmt", "-o", "json://test.json", "-o", "html://test.html"}, expected: "Only one output format can be set"},
-		{args: []string{"fmt", "-o", "foobar://barfoo"}, expected: "Unsupported output 'foobar': \nValid formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"},
+		{args: []string{"fmt", "-o", "foobar://barfoo"}, expected: "Unsupported output 'foobar': \nValid formats are: console://,dot://PATH/TO/FILE.dot,graph://PATH/TO/FILE.json,hcl://PATH/TO/FILE.tf,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif,template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"},
 	}
 
 	for _, tt := range cases {
@@ -1,13 +1,13 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/driftignore"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -18,25 +18,60 @@ func NewGenDriftIgnoreCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "gen-driftignore",
 		Short: "Generate a .driftignore file based on your scan result",
-		Long:  "This command will generate a new .driftignore file containing your current drifts\n\nExample: driftctl scan -o json://stdout | driftctl gen-driftignore",
-		Args:  cobra.NoArgs,
+		Long: "This command will generate a new .driftignore file containing your current drifts\n\n" +
+			"Rules are grouped by resource type, an existing file is completed with the rules it is missing: " +
+			"running the command again does not duplicate them\n\n" +
+			"Example: driftctl scan -o json://stdout | driftctl gen-driftignore",
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, patterns := range []struct {
+				flag   string
+				values []string
+			}{{"type", opts.Types}, {"source", opts.Sources}} {
+				for _, pattern := range patterns.values {
+					if _, err := filepath.Match(pattern, ""); err != nil {
+						return errors.Errorf("invalid pattern '%s' for --%s: %s", pattern, patterns.flag, err)
+					}
+				}
+			}
+			if opts.WildcardThreshold < 0 {
+				return errors.New("--wildcard-threshold must be positive")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, list, err := genDriftIgnore(opts)
+			analysis, err := readAnalysis(opts.InputPath)
 			if err != nil {
 				return err
 			}
 
-			ignoreFile := os.Stdout
-			if opts.OutputPath != "-" {
-				var err error
-				ignoreFile, err = os.OpenFile(opts.OutputPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
-				if err != nil {
-					return errors.Errorf("error opening output file: %s", err)
-				}
-				defer ignoreFile.Close()
-				fmt.Fprintf(os.Stderr, "Appending ignore rules to %s\n", opts.OutputPath)
+			if opts.OutputPath == "-" {
+				_, list := analysis.DriftIgnoreList(*opts)
+				fmt.Fprintf(os.Stdout, "# Generated by gen-driftignore cmd @ %s\n%s\n", time.Now().Format(time.RFC1123), list)
+				return nil
 			}
-			fmt.Fprintf(ignoreFile, "# Generated by gen-driftignore cmd @ %s\n%s\n", time.Now().Format(time.RFC1123), list)
+
+			file, err := driftignore.Read(opts.OutputPath)
+			if os.IsNotExist(err) {
+				file, err = driftignore.New(opts.OutputPath), nil
+			}
+			if err != nil {
+				return errors.Errorf("error opening output file: %s", err)
+			}
+
+			_, sections := analysis.DriftIgnoreSections(*opts)
+			if file.Empty() {
+				file.Comment(fmt.Sprintf("Generated by gen-driftignore cmd @ %s", time.Now().Format(time.RFC1123)))
+			}
+			added := file.Merge(sections)
+			if added == 0 {
+				fmt.Fprintf(os.Stderr, "No rule to add to %s\n", opts.OutputPath)
+				return nil
+			}
+			if err := os.WriteFile(opts.OutputPath, file.Bytes(), 0644); err != nil {
+				return errors.Errorf("error writing output file: %s", err)
+			}
+			fmt.Fprintf(os.Stderr, "Added %d rule(s) to %s\n", added, opts.OutputPath)
 
 			return nil
 		},
@@ -46,35 +81,12 @@ func NewGenDriftIgnoreCmd() *cobra.Command {
 
 	fl.BoolVar(&opts.ExcludeUnmanaged, "exclude-unmanaged", false, "Exclude resources not managed by IaC")
 	fl.BoolVar(&opts.ExcludeDeleted, "exclude-missing", false, "Exclude missing resources")
+	fl.BoolVar(&opts.ExcludeDrifted, "exclude-drifted", false, "Exclude drifted resources")
+	fl.StringSliceVar(&opts.Types, "type", []string{}, "Only generate rules for the resource types matching one of these patterns, e.g. aws_iam_*")
+	fl.StringSliceVar(&opts.Sources, "source", []string{}, "Only generate rules for the resources of the IaC sources matching one of these patterns, e.g. s3://bucket/prod/*.tfstate.\nResources not covered by IaC have no source and are left out")
+	fl.IntVar(&opts.WildcardThreshold, "wildcard-threshold", 0, "Replace the rules of a type by a wildcard when there are at least this many, 0 disables it.\nTypes with resources managed by IaC keep their rules")
 	fl.StringVarP(&opts.InputPath, "input", "i", "-", "Input where the JSON should be parsed from. Defaults to stdin.")
 	fl.StringVarP(&opts.OutputPath, "output", "o", ".driftignore", "Output file path to write the driftignore to.")
 
 	return cmd
 }
-
-func genDriftIgnore(opts *analyser.GenDriftIgnoreOptions) (int, string, error) {
-	driftFile := os.Stdin
-	if opts.InputPath != "-" {
-		var err error
-		driftFile, err = os.Open(opts.InputPath)
-		if err != nil {
-			return 0, "", err
-		}
-		defer driftFile.Close()
-	}
-
-	input, err := io.ReadAll(driftFile)
-	if err != nil {
-		return 0, "", err
-	}
-
-	analysis := &analyser.Analysis{}
-	err = json.Unmarshal(input, analysis)
-	if err != nil {
-		return 0, "", err
-	}
-
-	n, list := analysis.DriftIgnoreList(*opts)
-
-	return n, list, nil
-}
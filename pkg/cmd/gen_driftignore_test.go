@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -43,6 +44,12 @@ func TestGenDriftIgnoreCmd_Input(t *testing.T) {
 			output: "./testdata/output_stdin_valid_filter2.txt",
 			err:    nil,
 		},
+		{
+			name:   "test driftignore content with valid input and filter missing only",
+			args:   []string{"-i", "./testdata/input_stdin_valid.json", "--exclude-unmanaged"},
+			output: "./testdata/output_stdin_valid_filter.txt",
+			err:    nil,
+		},
 		{
 			name:   "test error when input file does not exist",
 			args:   []string{"-i", "doesnotexist"},
@@ -100,6 +107,10 @@ func TestGenDriftIgnoreCmd_ValidFlags(t *testing.T) {
 		{args: []string{"gen-driftignore", "--exclude-missing=false", "--exclude-unmanaged=true"}},
 		{args: []string{"gen-driftignore", "--input", "-"}},
 		{args: []string{"gen-driftignore", "-i", "/dev/stdout"}},
+		{args: []string{"gen-driftignore", "--exclude-drifted"}},
+		{args: []string{"gen-driftignore", "--type", "aws_iam_*", "--type", "aws_s3_bucket"}},
+		{args: []string{"gen-driftignore", "--source", "s3://bucket/*.tfstate"}},
+		{args: []string{"gen-driftignore", "--wildcard-threshold", "10"}},
 	}
 
 	for _, tt := range cases {
@@ -114,11 +125,6 @@ func TestGenDriftIgnoreCmd_ValidFlags(t *testing.T) {
 }
 
 func TestGenDriftIgnoreCmd_InvalidFlags(t *testing.T) {
-	rootCmd := &cobra.Command{Use: "root"}
-	genDriftIgnoreCmd := NewGenDriftIgnoreCmd()
-	genDriftIgnoreCmd.RunE = func(_ *cobra.Command, args []string) error { return nil }
-	rootCmd.AddCommand(genDriftIgnoreCmd)
-
 	cases := []struct {
 		args []string
 		err  error
@@ -129,14 +135,65 @@ func TestGenDriftIgnoreCmd_InvalidFlags(t *testing.T) {
 		{args: []string{"gen-driftignore", "--missing"}, err: errors.New("unknown flag: --missing")},
 		{args: []string{"gen-driftignore", "--input"}, err: errors.New("flag needs an argument: --input")},
 		{args: []string{"gen-driftignore", "-i"}, err: errors.New("flag needs an argument: 'i' in -i")},
+		{args: []string{"gen-driftignore", "--type", "aws_[iam"}, err: errors.New("invalid pattern 'aws_[iam' for --type: syntax error in pattern")},
+		{args: []string{"gen-driftignore", "--wildcard-threshold", "-1"}, err: errors.New("--wildcard-threshold must be positive")},
 	}
 
 	for _, tt := range cases {
+		// Flag values are kept between executions of a command, each case needs its own
+		rootCmd := &cobra.Command{Use: "root"}
+		genDriftIgnoreCmd := NewGenDriftIgnoreCmd()
+		genDriftIgnoreCmd.RunE = func(_ *cobra.Command, args []string) error { return nil }
+		rootCmd.AddCommand(genDriftIgnoreCmd)
+
 		_, err := test.Execute(rootCmd, tt.args...)
 		assert.EqualError(t, err, tt.err.Error())
 	}
 }
 
+func TestGenDriftIgnoreCmd_Merge(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		output string
+	}{
+		{
+			name:   "test rules are merged with wildcards above the threshold",
+			args:   []string{"--wildcard-threshold", "3"},
+			output: "./testdata/gen_driftignore/merged_wildcard.driftignore",
+		},
+		{
+			name:   "test rules are merged for selected types",
+			args:   []string{"--type", "aws_iam_access_key", "--type", "aws_iam_role"},
+			output: "./testdata/gen_driftignore/merged_types.driftignore",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".driftignore")
+			existing, err := os.ReadFile("./testdata/gen_driftignore/existing.driftignore")
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(path, existing, 0644))
+
+			// Running the command again must not add anything
+			for i := 0; i < 2; i++ {
+				rootCmd := &cobra.Command{Use: "root"}
+				rootCmd.AddCommand(NewGenDriftIgnoreCmd())
+				args := append([]string{"gen-driftignore", "-o", path, "-i", "./testdata/input_stdin_valid.json"}, c.args...)
+				_, err = test.Execute(rootCmd, args...)
+				require.NoError(t, err)
+			}
+
+			output, err := os.ReadFile(path)
+			require.NoError(t, err)
+			expectedOutput, err := os.ReadFile(c.output)
+			require.NoError(t, err)
+			assert.Equal(t, string(expectedOutput), string(output))
+		})
+	}
+}
+
 // The leading comment, "Generated by gen-driftignore..." contains a timestamp,
 // that we don't care to assert on.
 func trimLeadingComment(content string) string {
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/pkg/codegen"
+)
+
+func NewGenImportsCmd(opts *pkg.GenImportsOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen-imports",
+		Short: "Generate Terraform imports for unmanaged resources",
+		Long: "This command will generate Terraform import blocks, or a terraform import script, for every resource not covered by IaC\n" +
+			"Some resources need their attributes to compute their import id, run the scan with --json-attributes to keep them\n" +
+			"Resource blocks matching these imports can be generated with the hcl output of a deep scan\n\n" +
+			"Example: driftctl scan -o json://stdout --json-attributes | driftctl gen-imports -o imports.tf",
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !contains(codegen.Formats, opts.Format) {
+				return errors.Errorf("Unsupported format '%s'\nAccepted values are: %s", opts.Format, strings.Join(codegen.Formats, ","))
+			}
+			if !contains(codegen.GroupByValues, opts.GroupBy) {
+				return errors.Errorf("Unsupported value '%s' for --group-by\nAccepted values are: %s", opts.GroupBy, strings.Join(codegen.GroupByValues, ","))
+			}
+
+			modules, _ := cmd.Flags().GetStringArray("module")
+			opts.Modules = make([]codegen.ModuleMapping, 0, len(modules))
+			for _, module := range modules {
+				mapping, err := codegen.ParseModuleMapping(module)
+				if err != nil {
+					return err
+				}
+				opts.Modules = append(opts.Modules, mapping)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return genImports(opts)
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVarP(&opts.InputPath, "input", "i", "-", "Input where the JSON should be parsed from. Defaults to stdin.")
+	fl.StringVarP(&opts.OutputPath, "output", "o", "-", "Output file path to write the imports to. Defaults to stdout.")
+	fl.StringVar(&opts.Format,
+		"format",
+		codegen.FormatHCL,
+		"Use hcl to write Terraform 1.5+ import blocks, or script to write a shell script running terraform import\n"+
+			"Accepted values are: "+strings.Join(codegen.Formats, ",")+"\n",
+	)
+	fl.StringVar(&opts.GroupBy,
+		"group-by",
+		codegen.GroupByType,
+		"Group imports by resource type or by target module\n"+
+			"Accepted values are: "+strings.Join(codegen.GroupByValues, ",")+"\n",
+	)
+	fl.StringArray(
+		"module",
+		[]string{},
+		"Import resources whose type matches a pattern into a module, the first matching pattern wins\n"+
+			"Example: --module 'aws_iam_*=module.iam'\n",
+	)
+
+	return cmd
+}
+
+func genImports(opts *pkg.GenImportsOptions) error {
+	analysis, err := readAnalysis(opts.InputPath)
+	if err != nil {
+		return err
+	}
+
+	groups := codegen.Imports(analysis.Unmanaged(), opts.Modules, opts.GroupBy)
+
+	file := os.Stdout
+	if opts.OutputPath != "-" {
+		file, err = os.OpenFile(opts.OutputPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+		if err != nil {
+			return errors.Errorf("error opening output file: %s", err)
+		}
+		defer file.Close()
+	}
+
+	write := codegen.WriteImportBlocks
+	if opts.Format == codegen.FormatScript {
+		write = codegen.WriteImportScript
+	}
+	if err := write(file, groups); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, group := range groups {
+		for _, imp := range group.Imports {
+			if imp.Err != nil {
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Unable to compute the import id of %d resource(s), see comments in the generated output\n", failed)
+	}
+
+	return nil
+}
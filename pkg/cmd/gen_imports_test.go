@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/test"
+)
+
+func TestGenImportsCmd(t *testing.T) {
+	cases := []struct {
+		name     string
+		args     []string
+		expected string
+		err      string
+	}{
+		{
+			name:     "test import blocks grouped by type",
+			args:     []string{"-i", "testdata/gen_imports/analysis.json"},
+			expected: "testdata/gen_imports/expected.tf",
+		},
+		{
+			name:     "test import script grouped by module",
+			args:     []string{"-i", "testdata/gen_imports/analysis.json", "--format", "script", "--group-by", "module", "--module", "aws_iam_*=module.iam"},
+			expected: "testdata/gen_imports/expected_modules.sh",
+		},
+		{
+			name: "test error when input file does not exist",
+			args: []string{"-i", "doesnotexist"},
+			err:  "open doesnotexist: no such file or directory",
+		},
+		{
+			name: "test error on unsupported format",
+			args: []string{"--format", "yaml"},
+			err:  "Unsupported format 'yaml'\nAccepted values are: hcl,script",
+		},
+		{
+			name: "test error on unsupported group",
+			args: []string{"--group-by", "source"},
+			err:  "Unsupported value 'source' for --group-by\nAccepted values are: type,module",
+		},
+		{
+			name: "test error on invalid module mapping",
+			args: []string{"--module", "aws_iam_*"},
+			err:  "invalid module mapping 'aws_iam_*', expected PATTERN=MODULE (e.g. aws_iam_*=module.iam)",
+		},
+		{
+			name: "test error on invalid module address",
+			args: []string{"--module", "aws_iam_*=iam"},
+			err:  "invalid module address 'iam' in module mapping, expected module.NAME",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "root"}
+			rootCmd.AddCommand(NewGenImportsCmd(&pkg.GenImportsOptions{}))
+
+			output := path.Join(t.TempDir(), "imports")
+			args := append([]string{"gen-imports", "-o", output}, c.args...)
+
+			_, err := test.Execute(rootCmd, args...)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+
+			result, err := os.ReadFile(output)
+			require.NoError(t, err)
+			expected, err := os.ReadFile(c.expected)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/pkg/capabilities"
+)
+
+const (
+	resourcesFormatTable = "table"
+	resourcesFormatJSON  = "json"
+)
+
+var resourcesFormats = []string{resourcesFormatTable, resourcesFormatJSON}
+
+func NewResourcesCmd(opts *pkg.ResourcesOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resources",
+		Short: "List supported resource types and their capabilities",
+		Long: "This command lists, for each remote, every supported resource type with whether it is enumerated on the cloud provider, " +
+			"its parent and children types, whether it is partly ignored outside --strict, the middlewares touching it " +
+			"and the permissions needed to enumerate it\n\n" +
+			"Example: driftctl resources --to aws+tf --format json",
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !contains(resourcesFormats, opts.Format) {
+				return errors.Errorf("Unsupported format '%s'\nAccepted values are: %s", opts.Format, strings.Join(resourcesFormats, ","))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listResources(opts)
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVar(&opts.To,
+		"to",
+		"",
+		"Only list the resource types of this remote\n"+
+			"Accepted values are: "+strings.Join(capabilities.Remotes, ",")+"\n",
+	)
+	fl.StringVar(&opts.Format,
+		"format",
+		resourcesFormatTable,
+		"Accepted values are: "+strings.Join(resourcesFormats, ",")+"\n",
+	)
+	fl.StringVarP(&opts.OutputPath, "output", "o", "-", "Output file path. Defaults to stdout.")
+
+	return cmd
+}
+
+func listResources(opts *pkg.ResourcesOptions) error {
+	remotes, err := capabilities.List(opts.To)
+	if err != nil {
+		return err
+	}
+
+	file := os.Stdout
+	if opts.OutputPath != "-" {
+		file, err = os.OpenFile(opts.OutputPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+		if err != nil {
+			return errors.Errorf("error opening output file: %s", err)
+		}
+		defer file.Close()
+	}
+
+	if opts.Format == resourcesFormatJSON {
+		return writeResourcesJSON(file, remotes)
+	}
+	return writeResourcesTable(file, remotes)
+}
+
+func writeResourcesJSON(w io.Writer, remotes []capabilities.Remote) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(remotes)
+}
+
+func writeResourcesTable(w io.Writer, remotes []capabilities.Remote) error {
+	for i, remote := range remotes {
+		enumerated := 0
+		for _, res := range remote.Resources {
+			if res.Enumerated {
+				enumerated++
+			}
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s: %d resource types, %d enumerated\n", remote.Name, len(remote.Resources), enumerated)
+
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "TYPE\tENUMERATED\tPARENTS\tCHILDREN\tIGNORED OUTSIDE STRICT\tMIDDLEWARES\tPERMISSIONS")
+		for _, res := range remote.Resources {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				res.Type,
+				yesNo(res.Enumerated),
+				joinValues(res.Parents),
+				joinValues(res.Children),
+				yesNo(res.IgnoredOutsideStrict),
+				joinValues(res.Middlewares),
+				joinValues(res.Permissions),
+			)
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func joinValues(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/test"
+)
+
+func TestResourcesCmd(t *testing.T) {
+	cases := []struct {
+		name     string
+		args     []string
+		expected string
+		err      string
+	}{
+		{
+			name:     "test table output",
+			args:     []string{"--to", "github+tf"},
+			expected: "testdata/resources/github.txt",
+		},
+		{
+			name:     "test json output",
+			args:     []string{"--to", "github+tf", "--format", "json"},
+			expected: "testdata/resources/github.json",
+		},
+		{
+			name: "test error on unsupported format",
+			args: []string{"--format", "yaml"},
+			err:  "Unsupported format 'yaml'\nAccepted values are: table,json",
+		},
+		{
+			name: "test error on unsupported remote",
+			args: []string{"--to", "aws"},
+			err:  "Unsupported remote 'aws'\nAccepted values are: aws+tf,gcp+tf,azure+tf,github+tf",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "root"}
+			rootCmd.AddCommand(NewResourcesCmd(&pkg.ResourcesOptions{}))
+
+			output := path.Join(t.TempDir(), "resources")
+			args := append([]string{"resources", "-o", output}, c.args...)
+
+			_, err := test.Execute(rootCmd, args...)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+
+			result, err := os.ReadFile(output)
+			require.NoError(t, err)
+			expected, err := os.ReadFile(c.expected)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path"
@@ -13,10 +15,12 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/khulnasoft-lab/driftctl/build"
+	"github.com/khulnasoft-lab/driftctl/enumeration"
 	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
 	"github.com/khulnasoft-lab/driftctl/enumeration/remote"
 	"github.com/khulnasoft-lab/driftctl/enumeration/remote/aws"
 	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
 	"github.com/khulnasoft-lab/driftctl/enumeration/terraform"
 	"github.com/khulnasoft-lab/driftctl/enumeration/terraform/lock"
 	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
@@ -31,14 +35,19 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/khulnasoft-lab/driftctl/pkg"
 	cmderrors "github.com/khulnasoft-lab/driftctl/pkg/cmd/errors"
+	"github.com/khulnasoft-lab/driftctl/pkg/cmd/scan"
 	"github.com/khulnasoft-lab/driftctl/pkg/cmd/scan/output"
+	"github.com/khulnasoft-lab/driftctl/pkg/explain"
 	"github.com/khulnasoft-lab/driftctl/pkg/filter"
 	"github.com/khulnasoft-lab/driftctl/pkg/iac/supplier"
 	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
 	globaloutput "github.com/khulnasoft-lab/driftctl/pkg/output"
+	"github.com/khulnasoft-lab/driftctl/pkg/policy"
+	"github.com/khulnasoft-lab/driftctl/pkg/risk"
 )
 
 func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
@@ -59,14 +68,12 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 
 			opts.From = iacSource
 
-			to, _ := cmd.Flags().GetString("to")
-			if !remote.IsSupported(to) {
-				return errors.Errorf(
-					"unsupported cloud provider '%s'\nValid values are: %s",
-					to,
-					strings.Join(remote.GetSupportedRemotes(), ","),
-				)
+			toFlag, _ := cmd.Flags().GetStringSlice("to")
+			to, err := parseToFlag(toFlag)
+			if err != nil {
+				return err
 			}
+			opts.To = to
 
 			outputFlag, _ := cmd.Flags().GetStringSlice("output")
 
@@ -90,24 +97,30 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 				opts.Filter = expr
 			}
 
-			providerVersion, _ := cmd.Flags().GetString("tf-provider-version")
-			if err := validateTfProviderVersionString(providerVersion); err != nil {
+			failurePolicy, err := parsePolicyFlags(cmd)
+			if err != nil {
 				return err
 			}
-			opts.ProviderVersion = providerVersion
+			opts.Policy = failurePolicy
 
-			if opts.ProviderVersion == "" {
-				lockfilePath, _ := cmd.Flags().GetString("tf-lockfile")
+			riskRules, err := parseRiskRulesFlag(cmd)
+			if err != nil {
+				return err
+			}
+			opts.RiskRules = riskRules
 
-				// Attempt to read the provider version from a terraform lock file
-				lockFile, err := lock.ReadLocksFromFile(lockfilePath)
+			explainFlag, _ := cmd.Flags().GetStringArray("explain")
+			for _, value := range explainFlag {
+				target, err := explain.ParseTarget(value)
 				if err != nil {
-					logrus.WithField("error", err.Error()).Debug("Error while parsing terraform lock file")
-				}
-				if provider := lockFile.GetProviderByAddress(common.RemoteParameter(to).GetProviderAddress()); provider != nil {
-					opts.ProviderVersion = provider.Version
-					logrus.WithFields(logrus.Fields{"version": opts.ProviderVersion, "provider": to}).Debug("Found provider version in terraform lock file")
+					return err
 				}
+				opts.Explain = append(opts.Explain, target)
+			}
+
+			opts.ProviderVersions, err = parseProviderVersions(cmd, opts.To)
+			if err != nil {
+				return err
 			}
 
 			opts.Quiet, _ = cmd.Flags().GetBool("quiet")
@@ -144,58 +157,15 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 		"o",
 		[]string{output.Example(output.ConsoleOutputType)},
 		"Output format, by default it will write to the console\n"+
-			"Accepted formats are: "+strings.Join(output.SupportedOutputsExample(), ",")+"\n",
-	)
-	fl.StringSliceP(
-		"from",
-		"f",
-		[]string{},
-		"IaC sources, by default try to find local terraform.tfstate file\n"+
-			"Accepted schemes are: "+strings.Join(supplier.GetSupportedSchemes(), ",")+"\n",
-	)
-	supportedRemotes := remote.GetSupportedRemotes()
-	fl.StringVarP(
-		&opts.To,
-		"to",
-		"t",
-		supportedRemotes[0],
-		"Cloud provider source\n"+
-			"Accepted values are: "+strings.Join(supportedRemotes, ",")+"\n",
-	)
-	fl.StringToStringVarP(&opts.BackendOptions.Headers,
-		"headers",
-		"H",
-		map[string]string{},
-		"Use those HTTP headers to query the provided URL.\n"+
-			"Only used with tfstate+http(s) backend for now.\n",
-	)
-	fl.StringVar(&opts.BackendOptions.TFCloudToken,
-		"tfc-token",
-		"",
-		"Terraform Cloud / Enterprise API token.\n"+
-			"Only used with tfstate+tfcloud backend.\n",
-	)
-	fl.StringVar(&opts.BackendOptions.TFCloudEndpoint,
-		"tfc-endpoint",
-		"https://app.terraform.io/api/v2",
-		"Terraform Cloud / Enterprise API endpoint.\n"+
-			"Only used with tfstate+tfcloud backend.\n",
-	)
-	fl.StringVar(&opts.BackendOptions.AzureRMBackendOptions.StorageAccount,
-		"azurerm-storage-account",
-		os.Getenv("AZURE_STORAGE_ACCOUNT"),
-		"Azure storage account name for state backend.\n",
-	)
-	fl.StringVar(&opts.BackendOptions.AzureRMBackendOptions.StorageKey,
-		"azurerm-account-key",
-		os.Getenv("AZURE_STORAGE_KEY"),
-		"Azure storage account key for state backend.\n",
-	)
-	fl.String(
-		"tf-provider-version",
-		"",
-		"Terraform provider version to use.\n",
+			"Accepted formats are: "+strings.Join(output.SupportedOutputsExample(), ",")+"\n"+
+			"The markdown output is truncated with the max-size and max-resources options, e.g. markdown://report.md?max-size=30000\n"+
+			"The template output renders a Go template, html/template is used for .html templates, e.g. template://report.html.tmpl:report.html\n"+
+			"Outputs can be written to S3, Google Storage or Azure Blob Storage with the credentials of state backends, e.g. json://s3://bucket/key.json\n"+
+			"Dates are expanded in those paths with %Y, %m, %d, %H, %M and %S, e.g. html://gs://bucket/%Y-%m-%d/report.html\n",
 	)
+	addFromFlag(fl)
+	addRemoteFlags(fl)
+	addBackendFlags(fl, opts.BackendOptions)
 	fl.BoolVar(&opts.StrictMode,
 		"strict",
 		false,
@@ -213,26 +183,59 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 			"Example: *,!aws_s3* (everything but resources that are prefixed with aws_s3 are ignored) \n"+
 			"When using this parameter the driftignore file is not processed\n"+
 			"When using multiple instances of this argument, order will be respected")
+	fl.BoolVar(&opts.Deep,
+		"deep",
+		false,
+		fmt.Sprintf("%s Compare attributes of managed resources with their remote counterpart and report drifted ones\n", warn("EXPERIMENTAL:"))+
+			"This mode reads the details of every scanned resource and is much slower\n",
+	)
+	fl.StringVar(&opts.BaselinePath,
+		"baseline",
+		"",
+		"Path to a JSON analysis used as a baseline.\n"+
+			"Findings are classified as new, persisting or resolved and only new findings make the scan fail\n"+
+			"The console, html, json, junit, markdown, sarif and template outputs render these groups, the dot, graph, hcl and plan outputs ignore them\n",
+	)
 	fl.String(
-		"tf-lockfile",
-		".terraform.lock.hcl",
-		"Terraform lock file to get the provider's version from. Will be ignored if the file doesn't exist.\n",
+		"policy",
+		"",
+		"Path to a failure policy file (YAML or JSON) deciding when the scan fails\n"+
+			"Each rule sets thresholds on coverage and findings, optionally for resources selected by a JMESPath filter, and the exit code used when it is violated\n",
 	)
-
-	configDir, err := homedir.Dir()
-	if err != nil {
-		configDir = os.TempDir()
-	}
 	fl.String(
-		"config-dir",
-		configDir,
-		"Directory path that driftctl uses for configuration.\n",
+		"risk-rules",
+		"",
+		"Path to a risk rules file (YAML or JSON) extending the default rules used to classify unmanaged and missing resources by severity\n"+
+			"A rule with the same id as a default one replaces it\n"+
+			"Conditions on attributes such as policies or ACLs only match unmanaged resources when scanning with --deep\n",
+	)
+	fl.StringArray(
+		"explain",
+		[]string{},
+		"Print the decision trail of a resource through the scan, from its enumeration to the category it is reported in\n"+
+			"Example: --explain aws_iam_role.my-role\n",
+	)
+	fl.StringSlice(
+		"fail-on",
+		[]string{},
+		"Only fail when there are findings of the given categories\n"+
+			"Accepted values are: "+strings.Join(failOnCategories, ",")+"\n",
+	)
+	fl.Int(
+		"min-coverage",
+		0,
+		fmt.Sprintf("Fail with exit code %d when the coverage is below the given percentage\n", scan.EXIT_COVERAGE_TOO_LOW),
+	)
+	fl.BoolVar(&opts.JSONAttributes,
+		"json-attributes",
+		false,
+		"Include the attributes of every resource in the JSON output\n",
 	)
 	var deprecatedOnlyUnmanaged bool
 	fl.BoolVar(&deprecatedOnlyUnmanaged,
 		"only-unmanaged",
 		false,
-		fmt.Sprintf("%s Report only what's not managed by your IaC.\nThis option is a no-op as unmanaged is the only supported mode.\n", warn("DEPRECATED:")),
+		fmt.Sprintf("%s Report only what's not managed by your IaC.\nThis option is a no-op, drifts on managed resources are only reported with --deep.\n", warn("DEPRECATED:")),
 	)
 
 	return cmd
@@ -244,80 +247,140 @@ func scanRun(opts *pkg.ScanOptions) error {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
-	alerter := alerter.NewAlerter()
-
 	// For now, we only use the global printer to print progress and information about the current scan, so unless one
 	// of the configured output should silence global output we simply use console by default.
 	if output.ShouldPrint(opts.Output, opts.Quiet) {
 		globaloutput.ChangePrinter(globaloutput.NewConsolePrinter())
 	}
 
-	if len(opts.From) == 0 {
-		supplierConfigs, err := retrieveBackendsFromHCL("")
-		if err != nil {
+	analysis, err := analyze(opts, store, c)
+	if err != nil {
+		return err
+	}
+
+	validOutput := false
+	for _, o := range opts.Output {
+		if err = output.GetOutput(o, opts.BackendOptions).Write(analysis); err != nil {
+			logrus.Errorf("Error writing to output %s: %v", o.String(), err.Error())
+			continue
+		}
+		validOutput = true
+	}
+
+	// Fallback to console output if all output failed
+	if !validOutput {
+		logrus.Debug("All outputs failed, fallback to console output")
+		if err = output.NewConsole().Write(analysis); err != nil {
 			return err
 		}
-		opts.From = append(opts.From, supplierConfigs...)
 	}
 
-	if len(opts.From) == 0 {
-		opts.From = append(opts.From, config.SupplierConfig{
-			Key:     state.TerraformStateReaderSupplier,
-			Backend: backend.BackendKeyFile,
-			Path:    "terraform.tfstate",
-		})
+	globaloutput.Printf(color.WhiteString("Scan duration: %s\n", analysis.Duration.Round(time.Second)))
+	if providers := analysis.Providers(); len(providers) > 1 {
+		versions := make([]string, 0, len(providers))
+		for _, provider := range providers {
+			versions = append(versions, fmt.Sprintf("%s %s", provider.Name, provider.Version))
+		}
+		globaloutput.Printf(color.WhiteString("Provider versions used to scan: %s. Use --tf-provider-version to use other versions.\n"), strings.Join(versions, ", "))
+	} else {
+		globaloutput.Printf(color.WhiteString("Provider version used to scan: %s. Use --tf-provider-version to use another version.\n"), analysis.ProviderVersion)
 	}
 
-	providerLibrary := terraform.NewProviderLibrary()
-	remoteLibrary := common.NewRemoteLibrary()
+	if !opts.DisableTelemetry {
+		tl := telemetry.NewTelemetry(&build.Build{})
+		tl.SendTelemetry(store.Bucket(memstore.TelemetryBucket))
+	}
 
-	iacProgress := globaloutput.NewProgress("Scanning states", "Scanned states", true)
-	scanProgress := globaloutput.NewProgress("Scanning resources", "Scanned resources", false)
+	if evaluation := analysis.PolicyEvaluation(); evaluation != nil {
+		if evaluation.Violated() {
+			return cmderrors.PolicyViolation{
+				Rules:    evaluation.ViolatedRules(),
+				ExitCode: evaluation.ExitCode,
+			}
+		}
+		return nil
+	}
 
-	resourceSchemaRepository := schemas.NewSchemaRepository()
+	if analysis.HasNewFindings() {
+		return cmderrors.InfrastructureNotInSync{}
+	}
 
-	resFactory := dctlresource.NewDriftctlResourceFactory(resourceSchemaRepository)
+	return nil
+}
 
-	err := remote.Activate(opts.To, opts.ProviderVersion, alerter, providerLibrary, remoteLibrary, scanProgress, resFactory, opts.ConfigDir)
-	if err != nil {
-		if err == aws.AWSCredentialsNotFoundError {
-			// special case command-line advice, because AWS is the default cloud
-			// provider, and users may be confused by a cloud-specific error out of
-			// the box
-			return fmt.Errorf("%s\n\n%s", err, "To use a different cloud provider, use --to=\"gcp+tf\" for GCP or --to=\"azure+tf\" for Azure.")
+// analyze scans the cloud provider and the IaC sources of the given options and returns the resulting analysis,
+// the scan is stopped when a signal is received on interrupt
+func analyze(opts *pkg.ScanOptions, store memstore.Store, interrupt <-chan os.Signal) (*analyser.Analysis, error) {
+	alerter := alerter.NewAlerter()
+
+	var baseline *analyser.Analysis
+	if opts.BaselinePath != "" {
+		var err error
+		baseline, err = readAnalysis(opts.BaselinePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read baseline %s", opts.BaselinePath)
 		}
-		return err
 	}
 
-	providerName := common.RemoteParameter(opts.To).GetProviderAddress().Type
-	err = resourceSchemaRepository.Init(providerName, opts.ProviderVersion, providerLibrary.Provider(providerName).Schema())
+	from, err := defaultIaCSources(opts.From)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	opts.From = from
+
+	providerLibrary := terraform.NewProviderLibrary()
+	remoteLibrary := common.NewRemoteLibrary()
 
-	// Teardown
+	iacProgress := globaloutput.NewProgress("Scanning states", "Scanned states", true)
+	scanProgress := globaloutput.NewProgress("Scanning resources", "Scanned resources", false)
+
+	resourceSchemaRepository := schemas.NewSchemaRepository()
+
+	resFactory := dctlresource.NewDriftctlResourceFactory(resourceSchemaRepository)
+
+	// Teardown, providers activated before a failing one must be closed too
 	defer func() {
 		logrus.Trace("Exiting scan cmd")
 		providerLibrary.Cleanup()
 		logrus.Trace("Exited")
 	}()
 
+	// Every remote shares the libraries, the scanner enumerates the resources of all of them and the state reader
+	// decodes the resources of each activated provider
+	providers := make([]analyser.Provider, 0, len(opts.To))
+	for _, to := range opts.To {
+		provider, err := activateRemote(to, opts.ProviderVersions[to], opts.ConfigDir, alerter, providerLibrary, remoteLibrary, scanProgress, resFactory, resourceSchemaRepository)
+		if err != nil {
+			if err == aws.AWSCredentialsNotFoundError && len(opts.To) == 1 {
+				// special case command-line advice, because AWS is the default cloud
+				// provider, and users may be confused by a cloud-specific error out of
+				// the box
+				return nil, fmt.Errorf("%s\n\n%s", err, "To use a different cloud provider, use --to=\"gcp+tf\" for GCP or --to=\"azure+tf\" for Azure.")
+			}
+			if len(opts.To) > 1 {
+				return nil, errors.Wrapf(err, "unable to activate cloud provider '%s'", to)
+			}
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
 	logrus.Debug("Checking for driftignore")
 	driftIgnore := filter.NewDriftIgnore(opts.DriftignorePath, opts.Driftignores...)
 
 	// TODO use enum library interface here
-	scanner := remote.NewScanner(remoteLibrary, alerter, driftIgnore)
+	scanner := remote.NewScannerWithOptions(remoteLibrary, alerter, remote.ScannerOptions{Deep: opts.Deep}, driftIgnore)
 
 	iacSupplier, err := supplier.GetIACSupplier(opts.From, providerLibrary, opts.BackendOptions, iacProgress, alerter, resFactory, driftIgnore)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctl := pkg.NewDriftCTL(
 		scanner,
 		iacSupplier,
 		alerter,
-		analyser.NewAnalyzer(alerter, driftIgnore),
+		analyser.NewAnalyzer(alerter, analyser.AnalyzerOptions{Deep: opts.Deep}, driftIgnore),
 		resFactory,
 		opts,
 		scanProgress,
@@ -326,51 +389,297 @@ func scanRun(opts *pkg.ScanOptions) error {
 		store,
 	)
 
+	if len(opts.Explain) > 0 {
+		ctl.SetTracer(explain.NewTracer(opts.Explain, remoteLibrary.Enumerators(), driftIgnore))
+	}
+
+	done := make(chan struct{})
+	defer close(done)
 	go func() {
-		<-c
-		logrus.Warn("Detected interrupt, cleanup ...")
-		ctl.Stop()
+		select {
+		case <-interrupt:
+			logrus.Warn("Detected interrupt, cleanup ...")
+			ctl.Stop()
+		case <-done:
+		}
 	}()
 
 	analysis, err := ctl.Run()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	analysis.ProviderVersion = opts.ProviderVersion
-	analysis.ProviderName = opts.To
+	analysis.SetProviders(providers...)
 	store.Bucket(memstore.TelemetryBucket).Set("provider_name", analysis.ProviderName)
 
-	validOutput := false
-	for _, o := range opts.Output {
-		if err = output.GetOutput(o).Write(analysis); err != nil {
-			logrus.Errorf("Error writing to output %s: %v", o.String(), err.Error())
-			continue
+	analysis.SetWithAttributes(opts.JSONAttributes)
+
+	if baseline != nil {
+		analysis.ApplyBaseline(baseline)
+	}
+
+	if opts.RiskRules != nil {
+		analysis.SetRisks(risk.NewClassifier(opts.RiskRules).Classify(analysis))
+	}
+
+	if opts.Policy != nil {
+		evaluation, err := opts.Policy.Evaluate(analysis)
+		if err != nil {
+			return nil, err
 		}
-		validOutput = true
+		analysis.SetPolicyEvaluation(evaluation)
 	}
 
-	// Fallback to console output if all output failed
-	if !validOutput {
-		logrus.Debug("All outputs failed, fallback to console output")
-		if err = output.NewConsole().Write(analysis); err != nil {
-			return err
+	return analysis, nil
+}
+
+// readAnalysis reads an analysis written by the JSON output from a file, or from stdin when the path is -
+func readAnalysis(path string) (*analyser.Analysis, error) {
+	var input []byte
+	var err error
+	if path == "-" {
+		input, err = io.ReadAll(os.Stdin)
+	} else {
+		input, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := analyser.NewAnalysis()
+	if err := json.Unmarshal(input, analysis); err != nil {
+		return nil, err
+	}
+
+	return analysis, nil
+}
+
+var failOnCategories = []string{"unmanaged", "missing", "changed", "duplicated"}
+
+// parsePolicyFlags builds the failure policy from the policy file and the shorthand flags,
+// a nil policy means the scan fails on any new finding
+func parsePolicyFlags(cmd *cobra.Command) (*policy.Policy, error) {
+	failurePolicy := &policy.Policy{}
+
+	policyPath, _ := cmd.Flags().GetString("policy")
+	if policyPath != "" {
+		p, err := policy.Read(policyPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read policy %s", policyPath)
 		}
+		failurePolicy = p
 	}
 
-	globaloutput.Printf(color.WhiteString("Scan duration: %s\n", analysis.Duration.Round(time.Second)))
-	globaloutput.Printf(color.WhiteString("Provider version used to scan: %s. Use --tf-provider-version to use another version.\n"), opts.ProviderVersion)
+	failOn, _ := cmd.Flags().GetStringSlice("fail-on")
+	if len(failOn) > 0 {
+		zero := 0
+		rule := &policy.Rule{Name: "fail-on", ExitCode: scan.EXIT_NOT_IN_SYNC}
+		for _, category := range failOn {
+			switch category {
+			case "unmanaged":
+				rule.MaxUnmanaged = &zero
+			case "missing":
+				rule.MaxMissing = &zero
+			case "changed":
+				rule.MaxChanged = &zero
+			case "duplicated":
+				rule.MaxDuplicated = &zero
+			default:
+				return nil, errors.Errorf(
+					"Unsupported value '%s' for --fail-on\nAccepted values are: %s",
+					category,
+					strings.Join(failOnCategories, ","),
+				)
+			}
+		}
+		failurePolicy.Rules = append(failurePolicy.Rules, rule)
+	}
 
-	if !opts.DisableTelemetry {
-		tl := telemetry.NewTelemetry(&build.Build{})
-		tl.SendTelemetry(store.Bucket(memstore.TelemetryBucket))
+	if cmd.Flags().Changed("min-coverage") {
+		minCoverage, _ := cmd.Flags().GetInt("min-coverage")
+		failurePolicy.Rules = append(failurePolicy.Rules, &policy.Rule{
+			Name:        "min-coverage",
+			MinCoverage: &minCoverage,
+			ExitCode:    scan.EXIT_COVERAGE_TOO_LOW,
+		})
 	}
 
-	if !analysis.IsSync() {
-		return cmderrors.InfrastructureNotInSync{}
+	if len(failurePolicy.Rules) == 0 {
+		return nil, nil
 	}
+	if err := failurePolicy.Compile(scan.EXIT_NOT_IN_SYNC, scan.EXIT_ERROR); err != nil {
+		return nil, err
+	}
+	return failurePolicy, nil
+}
 
-	return nil
+// addFromFlag registers the IaC sources flag, parsed with parseFromFlag
+func addFromFlag(fl *pflag.FlagSet) {
+	fl.StringSliceP(
+		"from",
+		"f",
+		[]string{},
+		"IaC sources, by default try to find local terraform.tfstate file\n"+
+			"Accepted schemes are: "+strings.Join(supplier.GetSupportedSchemes(), ",")+"\n",
+	)
+}
+
+// addRemoteFlags registers the flags selecting the cloud providers and their provider versions, parsed with
+// parseToFlag and parseProviderVersions
+func addRemoteFlags(fl *pflag.FlagSet) {
+	supportedRemotes := remote.GetSupportedRemotes()
+	fl.StringSliceP(
+		"to",
+		"t",
+		[]string{supportedRemotes[0]},
+		"Cloud provider sources, several providers are scanned in a single run when given more than once\n"+
+			"Accepted values are: "+strings.Join(supportedRemotes, ",")+"\n",
+	)
+	fl.String(
+		"tf-provider-version",
+		"",
+		"Terraform provider version to use.\n"+
+			"When scanning several cloud providers, give the version of each one (e.g. aws+tf=3.19.0,github+tf=4.4.0)\n",
+	)
+	fl.String(
+		"tf-lockfile",
+		".terraform.lock.hcl",
+		"Terraform lock file to get the provider's version from. Will be ignored if the file doesn't exist.\n",
+	)
+
+	configDir, err := homedir.Dir()
+	if err != nil {
+		configDir = os.TempDir()
+	}
+	fl.String(
+		"config-dir",
+		configDir,
+		"Directory path that driftctl uses for configuration.\n",
+	)
+}
+
+// addBackendFlags registers the flags configuring the state backends
+func addBackendFlags(fl *pflag.FlagSet, opts *backend.Options) {
+	fl.StringToStringVarP(&opts.Headers,
+		"headers",
+		"H",
+		map[string]string{},
+		"Use those HTTP headers to query the provided URL.\n"+
+			"Only used with tfstate+http(s) backend for now.\n",
+	)
+	fl.StringVar(&opts.TFCloudToken,
+		"tfc-token",
+		"",
+		"Terraform Cloud / Enterprise API token.\n"+
+			"Only used with tfstate+tfcloud backend.\n",
+	)
+	fl.StringVar(&opts.TFCloudEndpoint,
+		"tfc-endpoint",
+		"https://app.terraform.io/api/v2",
+		"Terraform Cloud / Enterprise API endpoint.\n"+
+			"Only used with tfstate+tfcloud backend.\n",
+	)
+	fl.StringVar(&opts.AzureRMBackendOptions.StorageAccount,
+		"azurerm-storage-account",
+		os.Getenv("AZURE_STORAGE_ACCOUNT"),
+		"Azure storage account name for state backend.\n",
+	)
+	fl.StringVar(&opts.AzureRMBackendOptions.StorageKey,
+		"azurerm-account-key",
+		os.Getenv("AZURE_STORAGE_KEY"),
+		"Azure storage account key for state backend.\n",
+	)
+}
+
+// parseProviderVersions returns the provider version of each cloud provider, read from the flags or, when missing,
+// from the terraform lock file
+func parseProviderVersions(cmd *cobra.Command, to []string) (map[string]string, error) {
+	providerVersion, _ := cmd.Flags().GetString("tf-provider-version")
+	versions, err := parseTfProviderVersionFlag(providerVersion, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == len(to) {
+		return versions, nil
+	}
+
+	lockfilePath, _ := cmd.Flags().GetString("tf-lockfile")
+
+	// Attempt to read the provider versions that were not given from a terraform lock file
+	lockFile, err := lock.ReadLocksFromFile(lockfilePath)
+	if err != nil {
+		logrus.WithField("error", err.Error()).Debug("Error while parsing terraform lock file")
+	}
+	for _, name := range to {
+		if _, exist := versions[name]; exist {
+			continue
+		}
+		if provider := lockFile.GetProviderByAddress(common.RemoteParameter(name).GetProviderAddress()); provider != nil {
+			versions[name] = provider.Version
+			logrus.WithFields(logrus.Fields{"version": provider.Version, "provider": name}).Debug("Found provider version in terraform lock file")
+		}
+	}
+	return versions, nil
+}
+
+// parseToFlag validates the cloud providers to scan, a provider given more than once is only scanned once
+func parseToFlag(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return nil, errors.New("at least one cloud provider is required")
+	}
+	to := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		if !remote.IsSupported(value) {
+			return nil, errors.Errorf(
+				"unsupported cloud provider '%s'\nValid values are: %s",
+				value,
+				strings.Join(remote.GetSupportedRemotes(), ","),
+			)
+		}
+		if _, exist := seen[value]; exist {
+			continue
+		}
+		seen[value] = struct{}{}
+		to = append(to, value)
+	}
+	return to, nil
+}
+
+// parseTfProviderVersionFlag returns the provider version of each scanned cloud provider. A single version is only
+// accepted when scanning a single provider, otherwise versions are given as REMOTE=VERSION pairs.
+func parseTfProviderVersionFlag(value string, to []string) (map[string]string, error) {
+	versions := make(map[string]string)
+	if value == "" {
+		return versions, nil
+	}
+
+	if !strings.Contains(value, "=") {
+		if err := validateTfProviderVersionString(value); err != nil {
+			return nil, err
+		}
+		if len(to) > 1 {
+			return nil, errors.Errorf("--tf-provider-version must give the version of each cloud provider when scanning several of them (e.g. %s=%s)", to[0], value)
+		}
+		versions[to[0]] = value
+		return versions, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("Invalid version argument %s, expected REMOTE=VERSION (e.g. aws+tf=3.19.0)", pair)
+		}
+		remoteName, version := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if !contains(to, remoteName) {
+			return nil, errors.Errorf("cloud provider '%s' given to --tf-provider-version is not scanned\nScanned cloud providers are: %s", remoteName, strings.Join(to, ","))
+		}
+		if err := validateTfProviderVersionString(version); err != nil {
+			return nil, err
+		}
+		versions[remoteName] = version
+	}
+	return versions, nil
 }
 
 func validateTfProviderVersionString(version string) error {
@@ -383,6 +692,45 @@ func validateTfProviderVersionString(version string) error {
 	return nil
 }
 
+// activateRemote initializes the Terraform provider of a cloud provider, registering it and its enumerators in the
+// shared libraries, and loads its resource schemas
+func activateRemote(to, version, configDir string, alerter alerter.AlerterInterface, providerLibrary *terraform.ProviderLibrary, remoteLibrary *common.RemoteLibrary, progress enumeration.ProgressCounter, resFactory resource.ResourceFactory, schemaRepository *schemas.SchemaRepository) (analyser.Provider, error) {
+	err := remote.Activate(to, version, alerter, providerLibrary, remoteLibrary, progress, resFactory, configDir)
+	if err != nil {
+		return analyser.Provider{}, err
+	}
+
+	providerName := common.RemoteParameter(to).GetProviderAddress().Type
+	provider := providerLibrary.Provider(providerName)
+	err = schemaRepository.Init(providerName, version, provider.Schema())
+	if err != nil {
+		return analyser.Provider{}, err
+	}
+	return analyser.Provider{Name: to, Type: providerName, Version: provider.Version()}, nil
+}
+
+// defaultIaCSources returns the given IaC sources or, when there is none, the backends declared in the Terraform
+// files of the working directory, falling back to the local terraform.tfstate file
+func defaultIaCSources(from []config.SupplierConfig) ([]config.SupplierConfig, error) {
+	if len(from) > 0 {
+		return from, nil
+	}
+
+	supplierConfigs, err := retrieveBackendsFromHCL("")
+	if err != nil {
+		return nil, err
+	}
+	if len(supplierConfigs) > 0 {
+		return supplierConfigs, nil
+	}
+
+	return []config.SupplierConfig{{
+		Key:     state.TerraformStateReaderSupplier,
+		Backend: backend.BackendKeyFile,
+		Path:    "terraform.tfstate",
+	}}, nil
+}
+
 func retrieveBackendsFromHCL(workdir string) ([]config.SupplierConfig, error) {
 	matches, err := filepath.Glob(path.Join(workdir, "*.tf"))
 	if err != nil {
@@ -417,3 +765,25 @@ func retrieveBackendsFromHCL(workdir string) ([]config.SupplierConfig, error) {
 
 	return supplierConfigs, nil
 }
+
+// parseRiskRulesFlag returns the default risk rules, extended with the rules of the given file if any
+func parseRiskRulesFlag(cmd *cobra.Command) (*risk.Ruleset, error) {
+	ruleset, err := risk.Default()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read default risk rules")
+	}
+
+	rulesPath, _ := cmd.Flags().GetString("risk-rules")
+	if rulesPath != "" {
+		rules, err := risk.Read(rulesPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read risk rules %s", rulesPath)
+		}
+		ruleset.Extend(rules)
+	}
+
+	if err := ruleset.Compile(); err != nil {
+		return nil, err
+	}
+	return ruleset, nil
+}
@@ -4,4 +4,6 @@ const (
 	EXIT_IN_SYNC     = 0
 	EXIT_NOT_IN_SYNC = 1
 	EXIT_ERROR       = 2
+	// EXIT_COVERAGE_TOO_LOW is used when the coverage is below the one given to --min-coverage
+	EXIT_COVERAGE_TOO_LOW = 3
 )
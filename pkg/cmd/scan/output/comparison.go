@@ -0,0 +1,105 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+// ComparisonOutput is implemented by outputs able to render a comparison between two analyses
+type ComparisonOutput interface {
+	WriteComparison(comparison *analyser.AnalysisComparison) error
+}
+
+func GetComparisonOutput(config OutputConfig) (ComparisonOutput, error) {
+	switch config.Key {
+	case JSONOutputType:
+		return NewJSON(config.Path), nil
+	case ConsoleOutputType:
+		return NewConsole(), nil
+	}
+	return nil, errors.Errorf("Output '%s' does not support comparisons, use %s or %s", config.Key, ConsoleOutputType, JSONOutputType)
+}
+
+func (c *Console) WriteComparison(comparison *analyser.AnalysisComparison) error {
+	groups := []struct {
+		title     string
+		resources []*resource.Resource
+	}{
+		{"Resources that became unmanaged:", comparison.BecameUnmanaged},
+		{"Resources that became missing:", comparison.BecameMissing},
+		{"Resources adopted into IaC:", comparison.Adopted},
+		{"Resources that disappeared:", comparison.Disappeared},
+	}
+	for _, group := range groups {
+		if len(group.resources) == 0 {
+			continue
+		}
+		fmt.Println(group.title)
+		resourcesByType, keys := groupByType(group.resources)
+		for _, ty := range keys {
+			fmt.Printf("  %s:\n", ty)
+			for _, res := range resourcesByType[ty] {
+				fmt.Printf("    - %s\n", res.ResourceId())
+			}
+		}
+	}
+
+	fmt.Printf("Coverage: %s\n", formatCoverageDelta(comparison.Coverage))
+	c.writeCoverageDeltas("Coverage changes by resource type:", comparison.CoverageByType)
+	c.writeCoverageDeltas("Coverage changes by IaC source:", comparison.CoverageBySource)
+
+	return nil
+}
+
+// writeCoverageDeltas only prints groups of resources whose coverage changed
+func (c *Console) writeCoverageDeltas(title string, deltas []analyser.CoverageDelta) {
+	header := false
+	for _, delta := range deltas {
+		if delta.Delta == 0 {
+			continue
+		}
+		if !header {
+			fmt.Println(title)
+			header = true
+		}
+		fmt.Printf("  - %s: %s\n", delta.Key, formatCoverageDelta(delta))
+	}
+}
+
+func formatCoverageDelta(delta analyser.CoverageDelta) string {
+	change := fmt.Sprintf("%+d%%", delta.Delta)
+	if delta.Delta > 0 {
+		change = color.GreenString(change)
+	} else if delta.Delta < 0 {
+		change = color.RedString(change)
+	}
+	return fmt.Sprintf("%d%% => %d%% (%s)", delta.Before, delta.After, change)
+}
+
+func (c *JSON) WriteComparison(comparison *analyser.AnalysisComparison) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	json, err := json.MarshalIndent(comparison, "", "\t")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(json); err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,12 +1,44 @@
 package output
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+)
 
 type OutputConfig struct {
 	Key  string
 	Path string
+	// Options are given as a query string after the path, e.g. markdown://report.md?max-size=30000
+	Options map[string]string
 }
 
 func (o *OutputConfig) String() string {
-	return fmt.Sprintf("%s://%s", o.Key, o.Path)
+	if len(o.Options) == 0 {
+		return fmt.Sprintf("%s://%s", o.Key, o.Path)
+	}
+	query := url.Values{}
+	for key, value := range o.Options {
+		query.Set(key, value)
+	}
+	return fmt.Sprintf("%s://%s?%s", o.Key, o.Path, query.Encode())
+}
+
+// Destination is the path the output is written to, the path of a template output also holds the template path
+func (o OutputConfig) Destination() string {
+	if o.Key == TemplateOutputType {
+		_, path, _ := SplitTemplatePath(o.Path)
+		return path
+	}
+	return o.Path
+}
+
+// WithDestination returns a copy of the config writing to another path
+func (o OutputConfig) WithDestination(path string) OutputConfig {
+	if o.Key == TemplateOutputType {
+		templatePath, _, _ := SplitTemplatePath(o.Path)
+		o.Path = templatePath + ":" + path
+		return o
+	}
+	o.Path = path
+	return o
 }
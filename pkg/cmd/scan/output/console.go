@@ -1,11 +1,17 @@
 package output
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 	"sort"
+	"strings"
 
-	"github.com/khulnasoft-lab/driftctl/enumeration/remote/alerts"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
+	"github.com/r3labs/diff/v2"
 
 	"github.com/fatih/color"
 
@@ -16,6 +22,9 @@ import (
 const ConsoleOutputType = "console"
 const ConsoleOutputExample = "console://"
 
+// consoleCoverageTypeLimit is the count of resource types listed in the coverage section of the console output
+const consoleCoverageTypeLimit = 5
+
 type Console struct {
 	summary string
 }
@@ -88,13 +97,53 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 		}
 	}
 
+	if analysis.Summary().TotalDrifted > 0 {
+		fmt.Println("Found changed resources:")
+		for _, difference := range analysis.Differences() {
+			humanStringSource := difference.Res.ResourceType()
+			if difference.Res.SourceString() != "" {
+				humanStringSource = difference.Res.SourceString()
+			}
+			humanString := fmt.Sprintf("  - %s (%s):", difference.Res.ResourceId(), humanStringSource)
+			whiteSpace := "    "
+			if humanAttrs := formatResourceAttributes(difference.Res); humanAttrs != "" {
+				humanString += fmt.Sprintf("\n    %s", humanAttrs)
+				whiteSpace = "        "
+			}
+			fmt.Println(humanString)
+			for _, change := range difference.Changelog {
+				fmt.Println(formatChange(whiteSpace, change))
+			}
+		}
+	}
+
+	if analysis.Summary().TotalDuplicated > 0 {
+		fmt.Println("Found resources managed by several Terraform states:")
+		for _, duplicate := range analysis.Duplicates() {
+			humanString := fmt.Sprintf("  - %s (%s):", duplicate.Res.ResourceId(), duplicate.Res.ResourceType())
+			if humanAttrs := formatResourceAttributes(duplicate.Res); humanAttrs != "" {
+				humanString += fmt.Sprintf("\n      %s", humanAttrs)
+			}
+			fmt.Println(humanString)
+			for _, src := range duplicate.Sources {
+				fmt.Printf("    - %s\n", formatSource(duplicate.Res.ResourceType(), src))
+			}
+		}
+	}
+
+	c.writeRisks(analysis)
+	c.writeExplanations(analysis)
 	c.writeSummary(analysis)
+	c.writeProviders(analysis)
+	c.writeCoverage(analysis)
+	c.writeBaseline(analysis)
+	c.writePolicy(analysis)
 
 	enumerationErrorMessage := ""
 	for _, a := range analysis.Alerts() {
 		for _, alert := range a {
 			fmt.Println(color.YellowString(alert.Message()))
-			if alert, ok := alert.(*alerts.RemoteAccessDeniedAlert); ok && enumerationErrorMessage == "" {
+			if alert, ok := alert.(alerter.ProviderMessageAlert); ok && enumerationErrorMessage == "" {
 				enumerationErrorMessage = alert.GetProviderMessage()
 			}
 		}
@@ -107,6 +156,53 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 	return nil
 }
 
+// writeRisks prints the classified findings grouped by severity, from the most to the least severe
+func (c Console) writeRisks(analysis *analyser.Analysis) {
+	risks := analysis.Risks()
+	if len(risks) == 0 {
+		return
+	}
+	severityWriters := map[analyser.Severity]*color.Color{
+		analyser.SeverityCritical: color.New(color.Bold, color.FgRed),
+		analyser.SeverityHigh:     color.New(color.FgRed),
+		analyser.SeverityMedium:   color.New(color.FgYellow),
+		analyser.SeverityLow:      color.New(),
+	}
+	kinds := map[string]string{
+		analyser.RiskKindUnmanaged: "not covered by IaC",
+		analyser.RiskKindMissing:   "missing on the cloud provider",
+	}
+
+	fmt.Println("Found security risks:")
+	var severity analyser.Severity
+	for _, risk := range risks {
+		if risk.Severity != severity {
+			severity = risk.Severity
+			writer, exist := severityWriters[severity]
+			if !exist {
+				writer = color.New()
+			}
+			fmt.Printf("  %s:\n", writer.Sprint(severity))
+		}
+		humanString := fmt.Sprintf("    - %s (%s, %s)", risk.Res.ResourceId(), risk.Res.ResourceType(), kinds[risk.Kind])
+		if risk.Description != "" {
+			humanString += fmt.Sprintf(": %s", risk.Description)
+		}
+		fmt.Println(humanString)
+	}
+}
+
+// writeExplanations prints the decision trail of the resources explained during the scan
+func (c Console) writeExplanations(analysis *analyser.Analysis) {
+	for _, explanation := range analysis.Explanations() {
+		fmt.Printf("Explanation for %s (%s):\n", explanation.Id, explanation.Type)
+		for _, step := range explanation.Steps {
+			fmt.Printf("  - %s: %s\n", step.Stage, step.Message)
+		}
+		fmt.Printf("  %s\n", color.New(color.Bold).Sprintf("=> %s", explanation.Outcome))
+	}
+}
+
 func (c Console) writeSummary(analysis *analyser.Analysis) {
 	boldWriter := color.New(color.Bold)
 	successWriter := color.New(color.Bold, color.FgGreen)
@@ -131,6 +227,13 @@ func (c Console) writeSummary(analysis *analyser.Analysis) {
 			managed = warningWriter.Sprintf("%d", analysis.Summary().TotalManaged)
 		}
 		fmt.Printf(" - %s resource(s) managed by Terraform\n", managed)
+		if analysis.Options().Deep {
+			drifted := successWriter.Sprintf("0")
+			if analysis.Summary().TotalDrifted > 0 {
+				drifted = errorWriter.Sprintf("%d", analysis.Summary().TotalDrifted)
+			}
+			fmt.Printf("     - %s/%d resource(s) out of sync with Terraform state\n", drifted, analysis.Summary().TotalManaged)
+		}
 
 		unmanaged := successWriter.Sprintf("0")
 		if analysis.Summary().TotalUnmanaged > 0 {
@@ -142,12 +245,209 @@ func (c Console) writeSummary(analysis *analyser.Analysis) {
 		}
 		fmt.Printf(" - %s resource(s) not managed by Terraform\n", unmanaged)
 		fmt.Printf(" - %s resource(s) found in a Terraform state but missing on the cloud provider\n", deleted)
+		if analysis.Summary().TotalDuplicated > 0 {
+			duplicated := errorWriter.Sprintf("%d", analysis.Summary().TotalDuplicated)
+			fmt.Printf(" - %s resource(s) managed by more than one Terraform state\n", duplicated)
+		}
 	}
 	if analysis.IsSync() {
 		fmt.Println(color.GreenString("Congrats! Your infrastructure is fully in sync."))
 	}
 }
 
+// writeProviders prints the summary of each cloud provider when several of them were scanned
+func (c Console) writeProviders(analysis *analyser.Analysis) {
+	providers := analysis.Providers()
+	if len(providers) < 2 {
+		return
+	}
+	boldWriter := color.New(color.Bold)
+	fmt.Println("Summary by cloud provider:")
+	for _, provider := range providers {
+		humanString := fmt.Sprintf(
+			" - %s (%s): %s%% coverage, %d/%d resource(s) managed by Terraform, %d not managed, %d missing",
+			provider.Name,
+			provider.Version,
+			boldWriter.Sprintf("%d", provider.Coverage),
+			provider.TotalManaged,
+			provider.TotalResources,
+			provider.TotalUnmanaged,
+			provider.TotalDeleted,
+		)
+		if analysis.Options().Deep {
+			humanString += fmt.Sprintf(", %d out of sync", provider.TotalDrifted)
+		}
+		fmt.Println(humanString)
+	}
+}
+
+// writeCoverage prints the coverage of each provider service and IaC source. Only the consoleCoverageTypeLimit least
+// covered resource types are printed, the full coverage by resource type is available in the JSON and HTML outputs.
+func (c Console) writeCoverage(analysis *analyser.Analysis) {
+	if analysis.IsSync() {
+		return
+	}
+	boldWriter := color.New(color.Bold)
+	summary := analysis.Summary()
+	types, hiddenTypes := leastCoveredTypes(summary.CoverageByType)
+	groups := []struct {
+		title      string
+		breakdowns []analyser.CoverageBreakdown
+		hidden     int
+	}{
+		{"Coverage by provider service:", summary.CoverageByService, 0},
+		{"Least covered resource types:", types, hiddenTypes},
+		{"Coverage by IaC source:", summary.CoverageBySource, 0},
+	}
+	for _, group := range groups {
+		if len(group.breakdowns) == 0 {
+			continue
+		}
+		fmt.Println(group.title)
+		for _, breakdown := range group.breakdowns {
+			fmt.Printf(" - %s: %s%% (%d/%d)\n", breakdown.Key, boldWriter.Sprintf("%d", breakdown.Coverage), breakdown.Managed, breakdown.Total)
+		}
+		if group.hidden > 0 {
+			fmt.Printf(" - ... and %d more\n", group.hidden)
+		}
+	}
+	if len(summary.StatesWithoutResources) > 0 {
+		fmt.Printf("Found %s Terraform state(s) without any managed resource:\n", color.YellowString("%d", len(summary.StatesWithoutResources)))
+		for _, state := range summary.StatesWithoutResources {
+			fmt.Printf(" - %s\n", state)
+		}
+	}
+}
+
+// leastCoveredTypes returns at most consoleCoverageTypeLimit resource types sorted by coverage, along with the count of
+// types left out
+func leastCoveredTypes(breakdowns []analyser.CoverageBreakdown) ([]analyser.CoverageBreakdown, int) {
+	types := make([]analyser.CoverageBreakdown, len(breakdowns))
+	copy(types, breakdowns)
+	sort.SliceStable(types, func(i, j int) bool {
+		return types[i].Coverage < types[j].Coverage
+	})
+	if len(types) <= consoleCoverageTypeLimit {
+		return types, 0
+	}
+	return types[:consoleCoverageTypeLimit], len(types) - consoleCoverageTypeLimit
+}
+
+func (c Console) writeBaseline(analysis *analyser.Analysis) {
+	baseline := analysis.Baseline()
+	if baseline == nil {
+		return
+	}
+
+	fmt.Println("Compared to baseline:")
+	groups := []struct {
+		title    string
+		findings analyser.Findings
+		colorize func(format string, a ...interface{}) string
+	}{
+		{"new", baseline.New, color.RedString},
+		{"persisting", baseline.Persisting, color.YellowString},
+		{"resolved", baseline.Resolved, color.GreenString},
+	}
+	for _, group := range groups {
+		fmt.Printf(" - %s %s finding(s)\n", group.colorize("%d", group.findings.Count()), group.title)
+		for _, res := range group.findings.Deleted {
+			fmt.Printf("     - %s.%s (missing)\n", res.ResourceType(), res.ResourceId())
+		}
+		for _, res := range group.findings.Unmanaged {
+			fmt.Printf("     - %s.%s (not covered by IaC)\n", res.ResourceType(), res.ResourceId())
+		}
+		for _, difference := range group.findings.Differences {
+			fmt.Printf("     - %s.%s (changed)\n", difference.Res.ResourceType(), difference.Res.ResourceId())
+		}
+		for _, duplicate := range group.findings.Duplicates {
+			fmt.Printf("     - %s.%s (managed by several Terraform states)\n", duplicate.Res.ResourceType(), duplicate.Res.ResourceId())
+		}
+	}
+	if !analysis.HasNewFindings() {
+		fmt.Println(color.GreenString("No new drift since baseline."))
+	}
+}
+
+func (c Console) writePolicy(analysis *analyser.Analysis) {
+	evaluation := analysis.PolicyEvaluation()
+	if evaluation == nil {
+		return
+	}
+
+	fmt.Println("Failure policy:")
+	for _, rule := range evaluation.Rules {
+		if !rule.Violated {
+			fmt.Printf(" - %s: %s\n", rule.Name, color.GreenString("passed"))
+			continue
+		}
+		fmt.Printf(" - %s: %s (exit code %d)\n", rule.Name, color.RedString("violated"), rule.ExitCode)
+		for _, reason := range rule.Reasons {
+			fmt.Printf("     - %s\n", reason)
+		}
+	}
+}
+
+func formatSource(ty string, src resource.Source) string {
+	name := fmt.Sprintf("%s.%s", ty, src.InternalName())
+	if src.Namespace() != "" {
+		name = fmt.Sprintf("%s.%s", src.Namespace(), name)
+	}
+	if src.Source() == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, color.BlueString(src.Source()))
+}
+
+func formatChange(indent string, change analyser.Change) string {
+	path := strings.Join(change.Path, ".")
+	pref := fmt.Sprintf("%s %s:", color.YellowString("~"), path)
+	if change.Type == diff.CREATE {
+		pref = fmt.Sprintf("%s %s:", color.GreenString("+"), path)
+	} else if change.Type == diff.DELETE {
+		pref = fmt.Sprintf("%s %s:", color.RedString("-"), path)
+	}
+
+	if change.Type == diff.UPDATE && change.JsonString {
+		subIndent := indent + "    "
+		return fmt.Sprintf(
+			"%s%s\n%s%s\n%s=>\n%s%s",
+			indent, pref,
+			subIndent, indentJson(subIndent, change.From),
+			subIndent,
+			subIndent, indentJson(subIndent, change.To),
+		)
+	}
+
+	humanString := fmt.Sprintf("%s%s %s => %s", indent, pref, prettify(change.From), prettify(change.To))
+	if change.Computed {
+		humanString += fmt.Sprintf(" %s", color.YellowString("(computed)"))
+	}
+	return humanString
+}
+
+func prettify(value interface{}) string {
+	v := reflect.ValueOf(value)
+	if value == nil || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return "<nil>"
+	}
+	return awsutil.Prettify(value)
+}
+
+// indentJson pretty prints a JSON string attribute, it falls back to the raw value
+// when the attribute is not a valid JSON document
+func indentJson(prefix string, value interface{}) string {
+	str, ok := value.(string)
+	if !ok {
+		return prettify(value)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(str), prefix, "  "); err != nil {
+		return str
+	}
+	return out.String()
+}
+
 func groupByType(resources []*resource.Resource) (map[string][]*resource.Resource, []string) {
 	result := map[string][]*resource.Resource{}
 	for _, res := range resources {
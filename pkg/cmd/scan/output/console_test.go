@@ -95,6 +95,48 @@ func TestConsole_Write(t *testing.T) {
 			args:       args{analysis: fakeAnalysisWithoutDeep()},
 			wantErr:    false,
 		},
+		{
+			name:       "test console output with deep mode",
+			goldenfile: "output_deep.txt",
+			args:       args{analysis: fakeAnalysisWithDeep()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output with duplicated ownership",
+			goldenfile: "output_duplicates.txt",
+			args:       args{analysis: fakeAnalysisWithDuplicates()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output with baseline",
+			goldenfile: "output_baseline.txt",
+			args:       args{analysis: fakeAnalysisWithBaseline()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output with failure policy",
+			goldenfile: "output_policy.txt",
+			args:       args{analysis: fakeAnalysisWithPolicy()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output with security risks",
+			goldenfile: "output_risks.txt",
+			args:       args{analysis: fakeAnalysisWithRisks()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output with explanations",
+			goldenfile: "output_explanations.txt",
+			args:       args{analysis: fakeAnalysisWithExplanations()},
+			wantErr:    false,
+		},
+		{
+			name:       "test console output with several cloud providers",
+			goldenfile: "output_providers.txt",
+			args:       args{analysis: fakeAnalysisWithProviders()},
+			wantErr:    false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/graph"
+)
+
+const GraphOutputType = "graph"
+const GraphOutputExample = "graph://PATH/TO/FILE.json"
+const DOTOutputType = "dot"
+const DOTOutputExample = "dot://PATH/TO/FILE.dot"
+
+// Graph writes the relationship graph of the scanned resources as JSON
+type Graph struct {
+	path string
+}
+
+func NewGraph(path string) *Graph {
+	return &Graph{path}
+}
+
+func (c *Graph) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	json, err := json.MarshalIndent(graph.Build(analysis), "", "\t")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(json); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DOT writes the relationship graph of the scanned resources in the Graphviz DOT language
+type DOT struct {
+	path string
+}
+
+func NewDOT(path string) *DOT {
+	return &DOT{path}
+}
+
+func (c *DOT) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	return graph.Build(analysis).WriteDOT(file)
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/test/goldenfile"
+)
+
+func TestGraph_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		output     func(path string) Output
+		analysis   *analyser.Analysis
+	}{
+		{
+			name:       "test graph output",
+			goldenfile: "output_graph.json",
+			output:     func(path string) Output { return NewGraph(path) },
+			analysis:   fakeAnalysisWithRelationships(),
+		},
+		{
+			name:       "test graph output when no infra",
+			goldenfile: "output_graph_empty.json",
+			output:     func(path string) Output { return NewGraph(path) },
+			analysis:   &analyser.Analysis{},
+		},
+		{
+			name:       "test dot output",
+			goldenfile: "output_graph.dot",
+			output:     func(path string) Output { return NewDOT(path) },
+			analysis:   fakeAnalysisWithRelationships(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			tempFile, err := os.CreateTemp(tempDir, "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.output(tempFile.Name()).Write(tt.analysis); err != nil {
+				t.Fatal(err)
+			}
+			result, err := os.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := os.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package output
+
+import (
+	"os"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/codegen"
+)
+
+const HCLOutputType = "hcl"
+const HCLOutputExample = "hcl://PATH/TO/FILE.tf"
+
+// HCL writes Terraform resource blocks for unmanaged resources, resource attributes are only complete in deep mode
+type HCL struct {
+	path string
+}
+
+func NewHCL(path string) *HCL {
+	return &HCL{path}
+}
+
+func (c *HCL) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	return codegen.NewResourceGenerator(analysis.Unmanaged(), analysis.Managed()).Write(file)
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+func TestHCL_Write(t *testing.T) {
+	analysis := &analyser.Analysis{}
+	analysis.AddUnmanaged(&resource.Resource{
+		Id:    "vpc-1",
+		Type:  "aws_vpc",
+		Attrs: &resource.Attributes{"id": "vpc-1", "arn": "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1", "cidr_block": "10.0.0.0/16"},
+		Sch: &resource.Schema{Attributes: map[string]resource.AttributeSchema{
+			"id":         {ConfigSchema: configschema.Attribute{Optional: true, Computed: true}},
+			"arn":        {ConfigSchema: configschema.Attribute{Computed: true}},
+			"cidr_block": {ConfigSchema: configschema.Attribute{Required: true}},
+		}},
+	})
+
+	tempFile, err := os.CreateTemp(t.TempDir(), "result")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewHCL(tempFile.Name()).Write(analysis); err != nil {
+		t.Fatal(err)
+	}
+	result, err := os.ReadFile(tempFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `# Generated by driftctl
+
+resource "aws_vpc" "vpc-1" {
+  cidr_block = "10.0.0.0/16"
+}
+`, string(result))
+}
@@ -4,7 +4,6 @@ import (
 	"embed"
 	"encoding/base64"
 	"html/template"
-	"math"
 	"os"
 	"time"
 
@@ -27,19 +26,32 @@ type HTML struct {
 }
 
 type HTMLTemplateParams struct {
-	IsSync          bool
-	ScanDate        string
-	Coverage        int
-	Summary         analyser.Summary
-	Unmanaged       []*resource.Resource
-	Deleted         []*resource.Resource
-	Alerts          alerter.Alerts
-	Stylesheet      template.CSS
-	ScanDuration    string
-	ProviderName    string
-	ProviderVersion string
-	LogoSvg         template.HTML
-	FaviconBase64   string
+	IsSync           bool
+	ScanDate         string
+	Coverage         int
+	Summary          analyser.Summary
+	Unmanaged        []*resource.Resource
+	Deleted          []*resource.Resource
+	Differences      []analyser.Difference
+	Duplicates       []analyser.Duplicate
+	Deep             bool
+	Risks            []analyser.Risk
+	HasBaseline      bool
+	BaselineFindings []HTMLBaselineFinding
+	Alerts           alerter.Alerts
+	Stylesheet       template.CSS
+	ScanDuration     string
+	ProviderName     string
+	ProviderVersion  string
+	LogoSvg          template.HTML
+	FaviconBase64    string
+}
+
+// HTMLBaselineFinding is a finding classified against a baseline analysis
+type HTMLBaselineFinding struct {
+	Res    *resource.Resource
+	Kind   string
+	Status string
 }
 
 func NewHTML(path string) *HTML {
@@ -77,31 +89,7 @@ func (c *HTML) Write(analysis *analyser.Analysis) error {
 		return err
 	}
 
-	funcMap := template.FuncMap{
-		"getResourceTypes": func() []string {
-			resources := make([]*resource.Resource, 0)
-			resources = append(resources, analysis.Unmanaged()...)
-			resources = append(resources, analysis.Deleted()...)
-
-			return distinctResourceTypes(resources)
-		},
-		"getIaCSources": func() []string {
-			resources := make([]*resource.Resource, 0)
-			resources = append(resources, analysis.Deleted()...)
-			resources = append(resources, analysis.Managed()...)
-
-			return distinctIaCSources(resources)
-		},
-		"rate": func(count int) float64 {
-			if analysis.Summary().TotalResources == 0 {
-				return 0
-			}
-			rate := 100 * float64(count) / float64(analysis.Summary().TotalResources)
-			return math.Floor(rate*100) / 100
-		},
-	}
-
-	tmpl, err := template.New("main").Funcs(funcMap).Parse(string(tmplFile))
+	tmpl, err := template.New("main").Funcs(templateFuncs(analysis)).Parse(string(tmplFile))
 	if err != nil {
 		return err
 	}
@@ -113,6 +101,11 @@ func (c *HTML) Write(analysis *analyser.Analysis) error {
 		Summary:         analysis.Summary(),
 		Unmanaged:       analysis.Unmanaged(),
 		Deleted:         analysis.Deleted(),
+		Differences:     analysis.Differences(),
+		Duplicates:      analysis.Duplicates(),
+		Deep:            analysis.Options().Deep,
+		Risks:           analysis.Risks(),
+		HasBaseline:     analysis.Baseline() != nil,
 		Alerts:          analysis.Alerts(),
 		Stylesheet:      template.CSS(styleFile),
 		ScanDuration:    analysis.Duration.Round(time.Second).String(),
@@ -122,6 +115,10 @@ func (c *HTML) Write(analysis *analyser.Analysis) error {
 		FaviconBase64:   base64.StdEncoding.EncodeToString(faviconFile),
 	}
 
+	if analysis.Baseline() != nil {
+		data.BaselineFindings = baselineFindings(analysis.Baseline())
+	}
+
 	err = tmpl.Execute(file, data)
 	if err != nil {
 		return err
@@ -130,6 +127,33 @@ func (c *HTML) Write(analysis *analyser.Analysis) error {
 	return nil
 }
 
+func baselineFindings(baseline *analyser.BaselineComparison) []HTMLBaselineFinding {
+	findings := make([]HTMLBaselineFinding, 0)
+	groups := []struct {
+		status   string
+		findings analyser.Findings
+	}{
+		{"New", baseline.New},
+		{"Persisting", baseline.Persisting},
+		{"Resolved", baseline.Resolved},
+	}
+	for _, group := range groups {
+		for _, res := range group.findings.Unmanaged {
+			findings = append(findings, HTMLBaselineFinding{res, "Unmanaged", group.status})
+		}
+		for _, res := range group.findings.Deleted {
+			findings = append(findings, HTMLBaselineFinding{res, "Missing", group.status})
+		}
+		for _, d := range group.findings.Differences {
+			findings = append(findings, HTMLBaselineFinding{d.Res, "Changed", group.status})
+		}
+		for _, d := range group.findings.Duplicates {
+			findings = append(findings, HTMLBaselineFinding{d.Res, "Duplicated", group.status})
+		}
+	}
+	return findings
+}
+
 func distinctResourceTypes(resources []*resource.Resource) []string {
 	types := make([]string, 0)
 
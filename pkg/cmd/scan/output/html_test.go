@@ -128,6 +128,50 @@ func TestHTML_Write(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name:       "test html output with deep mode",
+			goldenfile: "output_deep.html",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysisWithDeep()
+				a.Date = time.Date(2021, 06, 10, 0, 0, 0, 0, &time.Location{})
+				a.Duration = 91 * time.Second
+				return a
+			},
+			err: nil,
+		},
+		{
+			name:       "test html output with duplicated ownership",
+			goldenfile: "output_duplicates.html",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysisWithDuplicates()
+				a.Date = time.Date(2021, 06, 10, 0, 0, 0, 0, &time.Location{})
+				a.Duration = 91 * time.Second
+				return a
+			},
+			err: nil,
+		},
+		{
+			name:       "test html output with baseline",
+			goldenfile: "output_baseline.html",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysisWithBaseline()
+				a.Date = time.Date(2021, 06, 10, 0, 0, 0, 0, &time.Location{})
+				a.Duration = 91 * time.Second
+				return a
+			},
+			err: nil,
+		},
+		{
+			name:       "test html output with security risks",
+			goldenfile: "output_risks.html",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysisWithRisks()
+				a.Date = time.Date(2021, 06, 10, 0, 0, 0, 0, &time.Location{})
+				a.Duration = 91 * time.Second
+				return a
+			},
+			err: nil,
+		},
 		{
 			name:       "test html output when coverage is 100",
 			goldenfile: "output_coverage_100.html",
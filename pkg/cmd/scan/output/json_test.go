@@ -31,6 +31,54 @@ func TestJSON_Write(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:       "test json output with deep mode",
+			goldenfile: "output_deep.json",
+			args: args{
+				analysis: fakeAnalysisWithDeep(),
+			},
+			wantErr: false,
+		},
+		{
+			name:       "test json output with duplicated ownership",
+			goldenfile: "output_duplicates.json",
+			args: args{
+				analysis: fakeAnalysisWithDuplicates(),
+			},
+			wantErr: false,
+		},
+		{
+			name:       "test json output with baseline",
+			goldenfile: "output_baseline.json",
+			args: args{
+				analysis: fakeAnalysisWithBaseline(),
+			},
+			wantErr: false,
+		},
+		{
+			name:       "test json output with failure policy",
+			goldenfile: "output_policy.json",
+			args: args{
+				analysis: fakeAnalysisWithPolicy(),
+			},
+			wantErr: false,
+		},
+		{
+			name:       "test json output with security risks",
+			goldenfile: "output_risks.json",
+			args: args{
+				analysis: fakeAnalysisWithRisks(),
+			},
+			wantErr: false,
+		},
+		{
+			name:       "test json output with explanations",
+			goldenfile: "output_explanations.json",
+			args: args{
+				analysis: fakeAnalysisWithExplanations(),
+			},
+			wantErr: false,
+		},
 		{
 			name:       "test json output with AWS enumeration alerts",
 			goldenfile: "output_access_denied_alert_aws.json",
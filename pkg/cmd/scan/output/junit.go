@@ -0,0 +1,293 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/r3labs/diff/v2"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+const JUnitOutputType = "junit"
+const JUnitOutputExample = "junit://PATH/TO/FILE.xml"
+
+// junitAlertsSuite is the suite of alerts not related to a resource type
+const junitAlertsSuite = "alerts"
+
+// junitResolvedSuite is the suite of the findings of the baseline that are resolved
+const junitResolvedSuite = "resolved"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Body    string `xml:",cdata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnit writes a JUnit report where each resource type is a test suite. Managed resources are passing test cases,
+// unmanaged, missing, changed and duplicated resources are failures and alerts are skipped test cases. When the scan
+// is compared to a
+// baseline only new findings are failures, persisting findings are skipped and resolved findings are passing test
+// cases of their own suite.
+type JUnit struct {
+	path string
+}
+
+func NewJUnit(path string) *JUnit {
+	return &JUnit{path}
+}
+
+func (c *JUnit) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	content, err := xml.MarshalIndent(newJUnitReport(analysis), "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(xml.Header); err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		return err
+	}
+	if _, err := file.WriteString("\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newJUnitReport(analysis *analyser.Analysis) junitTestSuites {
+	suites := make(map[string]*junitTestSuite)
+	suite := func(name string) *junitTestSuite {
+		if _, exist := suites[name]; !exist {
+			suites[name] = &junitTestSuite{Name: name, Cases: make([]junitTestCase, 0)}
+		}
+		return suites[name]
+	}
+	addCase := func(name, ty string, testCase junitTestCase) {
+		testCase.Name = name
+		testCase.ClassName = ty
+		s := suite(ty)
+		s.Tests++
+		if testCase.Failure != nil {
+			s.Failures++
+		}
+		if testCase.Skipped != nil {
+			s.Skipped++
+		}
+		s.Cases = append(s.Cases, testCase)
+	}
+	persisting := junitPersistingFindings(analysis.Baseline())
+	addFailure := func(res *resource.Resource, failure *junitFailure) {
+		testCase := junitTestCase{Failure: failure}
+		if _, exist := persisting[junitFindingKey(failure.Type, res)]; exist {
+			testCase = junitTestCase{Skipped: &junitSkipped{Message: fmt.Sprintf("%s, already found in the baseline", failure.Message)}}
+		}
+		addCase(res.ResourceId(), res.ResourceType(), testCase)
+	}
+
+	// Changed and duplicated resources are managed too, they are reported as failures instead of passing test cases
+	failing := make(map[string]struct{})
+	for _, difference := range analysis.Differences() {
+		failing[difference.Res.ResourceType()+"."+difference.Res.ResourceId()] = struct{}{}
+	}
+	for _, duplicate := range analysis.Duplicates() {
+		failing[duplicate.Res.ResourceType()+"."+duplicate.Res.ResourceId()] = struct{}{}
+	}
+	for _, res := range analysis.Managed() {
+		if _, exist := failing[res.ResourceType()+"."+res.ResourceId()]; exist {
+			continue
+		}
+		addCase(res.ResourceId(), res.ResourceType(), junitTestCase{})
+	}
+	for _, res := range analysis.Unmanaged() {
+		addFailure(res, &junitFailure{
+			Type:    "unmanaged",
+			Message: "Resource found on the cloud provider but not managed by Terraform",
+			Body:    junitFailureBody(res, false),
+		})
+	}
+	for _, res := range analysis.Deleted() {
+		addFailure(res, &junitFailure{
+			Type:    "missing",
+			Message: "Resource managed by Terraform but missing on the cloud provider",
+			Body:    junitFailureBody(res, true),
+		})
+	}
+	for _, difference := range analysis.Differences() {
+		addFailure(difference.Res, &junitFailure{
+			Type:    "changed",
+			Message: "Resource managed by Terraform whose attributes changed on the cloud provider",
+			Body:    junitChangesBody(difference),
+		})
+	}
+	for _, duplicate := range analysis.Duplicates() {
+		addFailure(duplicate.Res, &junitFailure{
+			Type:    "duplicated",
+			Message: "Resource managed by several Terraform resources",
+			Body:    junitSourcesBody(duplicate),
+		})
+	}
+	if analysis.Baseline() != nil {
+		for _, finding := range baselineFindings(analysis.Baseline()) {
+			if finding.Status == "Resolved" {
+				addCase(junitFindingKey(strings.ToLower(finding.Kind), finding.Res), junitResolvedSuite, junitTestCase{})
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(analysis.Alerts()))
+	for key := range analysis.Alerts() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		// Alerts are keyed by the resource type or the resource they relate to, e.g. aws_s3_bucket or aws_s3_bucket.id
+		ty, name := junitAlertsSuite, ""
+		if key != "" {
+			parts := strings.SplitN(key, ".", 2)
+			ty = parts[0]
+			name = parts[len(parts)-1]
+		}
+		for i, alert := range analysis.Alerts()[key] {
+			if key == "" {
+				name = fmt.Sprintf("alert %d", i+1)
+			}
+			addCase(name, ty, junitTestCase{Skipped: &junitSkipped{Message: alert.Message()}})
+		}
+	}
+
+	report := junitTestSuites{
+		Name:   "driftctl",
+		Time:   fmt.Sprintf("%.3f", analysis.Duration.Seconds()),
+		Suites: make([]junitTestSuite, 0, len(suites)),
+	}
+	for _, s := range suites {
+		if !analysis.Date.IsZero() {
+			s.Timestamp = analysis.Date.UTC().Format("2006-01-02T15:04:05")
+		}
+		report.Tests += s.Tests
+		report.Failures += s.Failures
+		report.Skipped += s.Skipped
+		report.Suites = append(report.Suites, *s)
+	}
+	sort.Slice(report.Suites, func(i, j int) bool {
+		return report.Suites[i].Name < report.Suites[j].Name
+	})
+	return report
+}
+
+// junitPersistingFindings indexes the findings already found in the baseline, nil when the scan has no baseline
+func junitPersistingFindings(baseline *analyser.BaselineComparison) map[string]struct{} {
+	if baseline == nil {
+		return nil
+	}
+	persisting := make(map[string]struct{})
+	for _, finding := range baselineFindings(baseline) {
+		if finding.Status == "Persisting" {
+			persisting[junitFindingKey(strings.ToLower(finding.Kind), finding.Res)] = struct{}{}
+		}
+	}
+	return persisting
+}
+
+// junitFindingKey identifies a finding by its failure type and its resource, e.g. unmanaged aws_s3_bucket.logs
+func junitFindingKey(failureType string, res *resource.Resource) string {
+	return fmt.Sprintf("%s %s.%s", failureType, res.ResourceType(), res.ResourceId())
+}
+
+// junitChangesBody lists the changes of a drifted resource, one per line, e.g. ~ Tags.env: "prod" => "dev"
+func junitChangesBody(difference analyser.Difference) string {
+	lines := make([]string, 0, len(difference.Changelog))
+	for _, change := range difference.Changelog {
+		op := "~"
+		switch change.Type {
+		case diff.CREATE:
+			op = "+"
+		case diff.DELETE:
+			op = "-"
+		}
+		line := fmt.Sprintf("%s %s: %s => %s", op, strings.Join(change.Path, "."), prettify(change.From), prettify(change.To))
+		if change.Computed {
+			line += " (computed)"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// junitSourcesBody lists the Terraform resources declaring a duplicated resource, one per line
+func junitSourcesBody(duplicate analyser.Duplicate) string {
+	lines := make([]string, 0, len(duplicate.Sources))
+	for _, source := range duplicate.Sources {
+		address := fmt.Sprintf("%s.%s", duplicate.Res.ResourceType(), source.InternalName())
+		if source.Namespace() != "" {
+			address = fmt.Sprintf("%s.%s", source.Namespace(), address)
+		}
+		lines = append(lines, fmt.Sprintf("Declared by %s in %s", address, source.Source()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// junitFailureBody lists the human readable attributes of the resource, one per line, and the Terraform resource
+// declaring it when asked
+func junitFailureBody(res *resource.Resource, withSource bool) string {
+	lines := make([]string, 0)
+	if withSource && res.Source != nil {
+		lines = append(lines, fmt.Sprintf("Declared by %s in %s", res.SourceString(), res.Source.Source()))
+	}
+	if res.Schema() != nil && res.Schema().HumanReadableAttributesFunc != nil {
+		attributes := res.Schema().HumanReadableAttributesFunc(res)
+		keys := make([]string, 0, len(attributes))
+		for k := range attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("%s: %s", k, attributes[k]))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/test/goldenfile"
+)
+
+func TestJUnit_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		analysis   *analyser.Analysis
+	}{
+		{
+			name:       "test junit output",
+			goldenfile: "output.xml",
+			analysis:   fakeAnalysisForJUnit(),
+		},
+		{
+			name:       "test junit output without drift",
+			goldenfile: "output_no_drift.xml",
+			analysis:   fakeAnalysisNoDrift(),
+		},
+		{
+			name:       "test junit output with baseline",
+			goldenfile: "output_baseline.xml",
+			analysis:   fakeAnalysisWithBaseline(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp(t.TempDir(), "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := NewJUnit(tempFile.Name()).Write(tt.analysis); err != nil {
+				t.Fatal(err)
+			}
+			result, err := os.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := os.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
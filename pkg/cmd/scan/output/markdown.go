@@ -0,0 +1,339 @@
+package output
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/r3labs/diff/v2"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+const MarkdownOutputType = "markdown"
+const MarkdownOutputExample = "markdown://PATH/TO/FILE.md"
+
+// Options of the markdown output, given as a query string, e.g. markdown://report.md?max-size=30000&max-resources=20
+const (
+	MarkdownMaxSizeOption      = "max-size"
+	MarkdownMaxResourcesOption = "max-resources"
+)
+
+const (
+	// DefaultMarkdownMaxSize keeps the report under the 65536 characters of a GitHub comment
+	DefaultMarkdownMaxSize = 65000
+	// DefaultMarkdownMaxResources is the number of resources listed in each section
+	DefaultMarkdownMaxResources = 100
+)
+
+// Markdown writes a report meant to be posted as a pull request comment. Findings are grouped in collapsible sections
+// and the report is truncated to stay under the size limits of code hosting platforms.
+type Markdown struct {
+	path         string
+	maxSize      int
+	maxResources int
+}
+
+func NewMarkdown(path string, options map[string]string) *Markdown {
+	m := &Markdown{path, DefaultMarkdownMaxSize, DefaultMarkdownMaxResources}
+	if v, err := strconv.Atoi(options[MarkdownMaxSizeOption]); err == nil {
+		m.maxSize = v
+	}
+	if v, err := strconv.Atoi(options[MarkdownMaxResourcesOption]); err == nil {
+		m.maxResources = v
+	}
+	return m
+}
+
+// ParseMarkdownOptions parses the query string of a markdown output, options must be positive integers
+func ParseMarkdownOptions(query string) (map[string]string, error) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	options := make(map[string]string, len(values))
+	for key, value := range values {
+		if key != MarkdownMaxSizeOption && key != MarkdownMaxResourcesOption {
+			return nil, errors.Errorf("unknown option '%s'", key)
+		}
+		if n, err := strconv.Atoi(value[len(value)-1]); err != nil || n <= 0 {
+			return nil, errors.Errorf("option '%s' must be a positive integer", key)
+		}
+		options[key] = value[len(value)-1]
+	}
+	return options, nil
+}
+
+func (c *Markdown) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	_, err := file.WriteString(c.render(analysis))
+	return err
+}
+
+// render adds the sections of the report while they fit in the maximum size, the summary is always kept
+func (c *Markdown) render(analysis *analyser.Analysis) string {
+	var b strings.Builder
+	b.WriteString("## driftctl scan report\n\n")
+	b.WriteString(markdownSummary(analysis))
+
+	sections := make([]string, 0)
+	sections = append(sections, c.baselineSections(analysis)...)
+	sections = append(sections, c.missingSections(analysis)...)
+	sections = append(sections, c.unmanagedSections(analysis)...)
+	sections = append(sections, c.changedSections(analysis)...)
+	sections = append(sections, c.duplicateSections(analysis)...)
+	if alerts := markdownAlertsSection(analysis); alerts != "" {
+		sections = append(sections, alerts)
+	}
+
+	for i, section := range sections {
+		note := fmt.Sprintf("\n> **Report truncated**, %d section(s) left out to stay under %d characters. Use the json output for the full report.\n", len(sections)-i, c.maxSize)
+		if b.Len()+len(section)+len(note) > c.maxSize {
+			b.WriteString(note)
+			break
+		}
+		b.WriteString(section)
+	}
+	return b.String()
+}
+
+func markdownSummary(analysis *analyser.Analysis) string {
+	summary := analysis.Summary()
+	var b strings.Builder
+	if analysis.IsSync() {
+		b.WriteString("Congrats! Your infrastructure is fully in sync.\n\n")
+	}
+	b.WriteString("| Coverage | Resources | Managed | Unmanaged | Missing | Changed | Duplicated |\n")
+	b.WriteString("|---:|---:|---:|---:|---:|---:|---:|\n")
+	fmt.Fprintf(&b, "| %d%% | %d | %d | %d | %d | %d | %d |\n",
+		analysis.Coverage(),
+		summary.TotalResources,
+		summary.TotalManaged,
+		summary.TotalUnmanaged,
+		summary.TotalDeleted,
+		summary.TotalDrifted,
+		summary.TotalDuplicated,
+	)
+	if baseline := analysis.Baseline(); baseline != nil {
+		fmt.Fprintf(&b, "\nCompared to baseline: %d new, %d persisting and %d resolved finding(s).",
+			baseline.New.Count(),
+			baseline.Persisting.Count(),
+			baseline.Resolved.Count(),
+		)
+		if !analysis.HasNewFindings() {
+			b.WriteString(" No new drift since baseline.")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// baselineSections lists the new, persisting and resolved findings, they come first as they are what a pull request
+// changes
+func (c *Markdown) baselineSections(analysis *analyser.Analysis) []string {
+	if analysis.Baseline() == nil {
+		return nil
+	}
+	linesByStatus := make(map[string][]string)
+	for _, finding := range baselineFindings(analysis.Baseline()) {
+		line := fmt.Sprintf("- %s (%s)", markdownCode(fmt.Sprintf("%s.%s", finding.Res.ResourceType(), finding.Res.ResourceId())), strings.ToLower(finding.Kind))
+		linesByStatus[finding.Status] = append(linesByStatus[finding.Status], line)
+	}
+
+	sections := make([]string, 0, 3)
+	for _, status := range []string{"New", "Persisting", "Resolved"} {
+		if len(linesByStatus[status]) == 0 {
+			continue
+		}
+		sections = append(sections, c.details(len(sections) == 0, "Compared to baseline", fmt.Sprintf("%s findings", status), linesByStatus[status]))
+	}
+	return sections
+}
+
+// missingSections groups missing resources by state file, as the console output does
+func (c *Markdown) missingSections(analysis *analyser.Analysis) []string {
+	groupedBySource := make(map[string][]*resource.Resource)
+	for _, res := range analysis.Deleted() {
+		key := ""
+		if res.Source != nil {
+			key = res.Source.Source()
+		}
+		groupedBySource[key] = append(groupedBySource[key], res)
+	}
+	sources := make([]string, 0, len(groupedBySource))
+	for source := range groupedBySource {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	sections := make([]string, 0, len(sources))
+	for i, source := range sources {
+		title := "Without state file"
+		if source != "" {
+			title = fmt.Sprintf("From %s", markdownCode(source))
+		}
+		lines := make([]string, 0, len(groupedBySource[source]))
+		for _, res := range groupedBySource[source] {
+			address := res.ResourceType()
+			if res.SourceString() != "" {
+				address = res.SourceString()
+			}
+			lines = append(lines, fmt.Sprintf("- %s (%s)%s", markdownCode(res.ResourceId()), markdownCode(address), markdownAttributes(res)))
+		}
+		sections = append(sections, c.details(i == 0, "Missing resources", title, lines))
+	}
+	return sections
+}
+
+func (c *Markdown) unmanagedSections(analysis *analyser.Analysis) []string {
+	unmanagedByType, types := groupByType(analysis.Unmanaged())
+	sections := make([]string, 0, len(types))
+	for i, ty := range types {
+		lines := make([]string, 0, len(unmanagedByType[ty]))
+		for _, res := range unmanagedByType[ty] {
+			lines = append(lines, fmt.Sprintf("- %s%s", markdownCode(res.ResourceId()), markdownAttributes(res)))
+		}
+		sections = append(sections, c.details(i == 0, "Resources not covered by IaC", markdownCode(ty), lines))
+	}
+	return sections
+}
+
+func (c *Markdown) changedSections(analysis *analyser.Analysis) []string {
+	differencesByType := make(map[string][]analyser.Difference)
+	for _, difference := range analysis.Differences() {
+		ty := difference.Res.ResourceType()
+		differencesByType[ty] = append(differencesByType[ty], difference)
+	}
+	types := make([]string, 0, len(differencesByType))
+	for ty := range differencesByType {
+		types = append(types, ty)
+	}
+	sort.Strings(types)
+
+	sections := make([]string, 0, len(types))
+	for i, ty := range types {
+		lines := make([]string, 0, len(differencesByType[ty]))
+		for _, difference := range differencesByType[ty] {
+			line := fmt.Sprintf("- %s%s", markdownCode(difference.Res.ResourceId()), markdownAttributes(difference.Res))
+			for _, change := range difference.Changelog {
+				line += "\n" + markdownChange(change)
+			}
+			lines = append(lines, line)
+		}
+		sections = append(sections, c.details(i == 0, "Changed resources", markdownCode(ty), lines))
+	}
+	return sections
+}
+
+func (c *Markdown) duplicateSections(analysis *analyser.Analysis) []string {
+	duplicatesByType := make(map[string][]analyser.Duplicate)
+	for _, duplicate := range analysis.Duplicates() {
+		ty := duplicate.Res.ResourceType()
+		duplicatesByType[ty] = append(duplicatesByType[ty], duplicate)
+	}
+	types := make([]string, 0, len(duplicatesByType))
+	for ty := range duplicatesByType {
+		types = append(types, ty)
+	}
+	sort.Strings(types)
+
+	sections := make([]string, 0, len(types))
+	for i, ty := range types {
+		lines := make([]string, 0, len(duplicatesByType[ty]))
+		for _, duplicate := range duplicatesByType[ty] {
+			line := fmt.Sprintf("- %s%s", markdownCode(duplicate.Res.ResourceId()), markdownAttributes(duplicate.Res))
+			for _, src := range duplicate.Sources {
+				address := fmt.Sprintf("%s.%s", ty, src.InternalName())
+				if src.Namespace() != "" {
+					address = fmt.Sprintf("%s.%s", src.Namespace(), address)
+				}
+				line += fmt.Sprintf("\n  - %s in %s", markdownCode(address), markdownCode(src.Source()))
+			}
+			lines = append(lines, line)
+		}
+		sections = append(sections, c.details(i == 0, "Resources managed by several Terraform states", markdownCode(ty), lines))
+	}
+	return sections
+}
+
+func markdownAlertsSection(analysis *analyser.Analysis) string {
+	keys := make([]string, 0, len(analysis.Alerts()))
+	for key := range analysis.Alerts() {
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("\n### Alerts\n\n")
+	for _, key := range keys {
+		for _, alert := range analysis.Alerts()[key] {
+			fmt.Fprintf(&b, "- :warning: %s\n", alert.Message())
+		}
+	}
+	return b.String()
+}
+
+// details renders a collapsible section, a heading is written before the first section of a category. Only the first
+// resources of the section are listed.
+func (c *Markdown) details(first bool, category, title string, lines []string) string {
+	var b strings.Builder
+	if first {
+		fmt.Fprintf(&b, "\n### %s\n", category)
+	}
+	fmt.Fprintf(&b, "\n<details><summary>%s (%d)</summary>\n\n", title, len(lines))
+	for i, line := range lines {
+		if i == c.maxResources {
+			fmt.Fprintf(&b, "- ... and %d more\n", len(lines)-i)
+			break
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n</details>\n")
+	return b.String()
+}
+
+func markdownChange(change analyser.Change) string {
+	op := "~"
+	if change.Type == diff.CREATE {
+		op = "+"
+	} else if change.Type == diff.DELETE {
+		op = "-"
+	}
+	line := fmt.Sprintf("  - %s %s: %s => %s", op, markdownCode(strings.Join(change.Path, ".")), markdownCode(prettify(change.From)), markdownCode(prettify(change.To)))
+	if change.Computed {
+		line += " (computed)"
+	}
+	return line
+}
+
+func markdownAttributes(res *resource.Resource) string {
+	if attrs := formatResourceAttributes(res); attrs != "" {
+		return fmt.Sprintf(" %s", attrs)
+	}
+	return ""
+}
+
+// markdownCode formats an inline code span, using a longer delimiter when the value contains backticks
+func markdownCode(value string) string {
+	if strings.Contains(value, "`") {
+		return fmt.Sprintf("`` %s ``", value)
+	}
+	return fmt.Sprintf("`%s`", value)
+}
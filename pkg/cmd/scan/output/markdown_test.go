@@ -0,0 +1,116 @@
+package output
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/test/goldenfile"
+)
+
+func TestMarkdown_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		options    map[string]string
+		analysis   *analyser.Analysis
+	}{
+		{
+			name:       "test markdown output",
+			goldenfile: "output.md",
+			analysis:   fakeAnalysisForSARIF(),
+		},
+		{
+			name:       "test markdown output truncated",
+			goldenfile: "output_truncated.md",
+			options: map[string]string{
+				MarkdownMaxSizeOption:      "800",
+				MarkdownMaxResourcesOption: "1",
+			},
+			analysis: fakeAnalysisForSARIF(),
+		},
+		{
+			name:       "test markdown output without drift",
+			goldenfile: "output_no_drift.md",
+			analysis:   fakeAnalysisNoDrift(),
+		},
+		{
+			name:       "test markdown output with baseline",
+			goldenfile: "output_baseline.md",
+			analysis:   fakeAnalysisWithBaseline(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp(t.TempDir(), "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := NewMarkdown(tempFile.Name(), tt.options).Write(tt.analysis); err != nil {
+				t.Fatal(err)
+			}
+			result, err := os.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := os.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+func TestParseMarkdownOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected map[string]string
+		err      string
+	}{
+		{
+			name:     "test no options",
+			query:    "",
+			expected: map[string]string{},
+		},
+		{
+			name:  "test valid options",
+			query: "max-size=30000&max-resources=20",
+			expected: map[string]string{
+				MarkdownMaxSizeOption:      "30000",
+				MarkdownMaxResourcesOption: "20",
+			},
+		},
+		{
+			name:  "test unknown option",
+			query: "max-lines=10",
+			err:   "unknown option 'max-lines'",
+		},
+		{
+			name:  "test invalid value",
+			query: "max-size=-1",
+			err:   "option 'max-size' must be a positive integer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := ParseMarkdownOptions(tt.query)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, options)
+		})
+	}
+}
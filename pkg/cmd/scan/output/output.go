@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/khulnasoft-lab/driftctl/pkg/output"
 )
 
@@ -16,13 +17,27 @@ var supportedOutputTypes = []string{
 	JSONOutputType,
 	HTMLOutputType,
 	PlanOutputType,
+	GraphOutputType,
+	DOTOutputType,
+	HCLOutputType,
+	SARIFOutputType,
+	JUnitOutputType,
+	MarkdownOutputType,
+	TemplateOutputType,
 }
 
 var supportedOutputExample = map[string]string{
-	ConsoleOutputType: ConsoleOutputExample,
-	JSONOutputType:    JSONOutputExample,
-	HTMLOutputType:    HTMLOutputExample,
-	PlanOutputType:    PlanOutputExample,
+	ConsoleOutputType:  ConsoleOutputExample,
+	JSONOutputType:     JSONOutputExample,
+	HTMLOutputType:     HTMLOutputExample,
+	PlanOutputType:     PlanOutputExample,
+	GraphOutputType:    GraphOutputExample,
+	DOTOutputType:      DOTOutputExample,
+	HCLOutputType:      HCLOutputExample,
+	SARIFOutputType:    SARIFOutputExample,
+	JUnitOutputType:    JUnitOutputExample,
+	MarkdownOutputType: MarkdownOutputExample,
+	TemplateOutputType: TemplateOutputExample,
 }
 
 func SupportedOutputsExample() []string {
@@ -47,7 +62,13 @@ func IsSupported(key string) bool {
 	return false
 }
 
-func GetOutput(config OutputConfig) Output {
+// GetOutput returns the output of a config, backend options are used to authenticate against the object storage of
+// remote destinations
+func GetOutput(config OutputConfig, opts *backend.Options) Output {
+	if _, _, ok := SplitRemotePath(config.Destination()); ok {
+		return NewRemote(config, opts)
+	}
+
 	switch config.Key {
 	case JSONOutputType:
 		return NewJSON(config.Path)
@@ -55,6 +76,21 @@ func GetOutput(config OutputConfig) Output {
 		return NewHTML(config.Path)
 	case PlanOutputType:
 		return NewPlan(config.Path)
+	case GraphOutputType:
+		return NewGraph(config.Path)
+	case DOTOutputType:
+		return NewDOT(config.Path)
+	case HCLOutputType:
+		return NewHCL(config.Path)
+	case SARIFOutputType:
+		return NewSARIF(config.Path)
+	case JUnitOutputType:
+		return NewJUnit(config.Path)
+	case MarkdownOutputType:
+		return NewMarkdown(config.Path, config.Options)
+	case TemplateOutputType:
+		templatePath, path, _ := SplitTemplatePath(config.Path)
+		return NewTemplate(templatePath, path)
 	case ConsoleOutputType:
 		fallthrough
 	default:
@@ -83,6 +119,20 @@ func GetPrinter(config OutputConfig, quiet bool) output.Printer {
 		fallthrough
 	case PlanOutputType:
 		fallthrough
+	case GraphOutputType:
+		fallthrough
+	case DOTOutputType:
+		fallthrough
+	case HCLOutputType:
+		fallthrough
+	case SARIFOutputType:
+		fallthrough
+	case JUnitOutputType:
+		fallthrough
+	case MarkdownOutputType:
+		fallthrough
+	case TemplateOutputType:
+		fallthrough
 	case HTMLOutputType:
 		fallthrough
 	case ConsoleOutputType:
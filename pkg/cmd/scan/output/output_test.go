@@ -15,6 +15,7 @@ import (
 	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
 	"github.com/khulnasoft-lab/driftctl/pkg/output"
 	"github.com/pkg/errors"
+	"github.com/r3labs/diff/v2"
 )
 
 func fakeAnalysis() *analyser.Analysis {
@@ -192,6 +193,33 @@ func fakeAnalysisForJSONPlan() *analyser.Analysis {
 	return &a
 }
 
+func fakeAnalysisForJSONPlanWithDeep() *analyser.Analysis {
+	a := fakeAnalysisForJSONPlan()
+	a.SetOptions(analyser.AnalyzerOptions{Deep: true})
+	a.AddDifference(analyser.Difference{
+		Res: a.Managed()[0],
+		Changelog: analyser.Changelog{
+			{
+				Change: diff.Change{
+					Type: diff.UPDATE,
+					Path: []string{"name"},
+					From: "First managed resource",
+					To:   "First managed resource renamed",
+				},
+			},
+			{
+				Change: diff.Change{
+					Type: diff.CREATE,
+					Path: []string{"tags", "env"},
+					From: nil,
+					To:   "prod",
+				},
+			},
+		},
+	})
+	return a
+}
+
 func fakeAnalysisWithoutDeep() *analyser.Analysis {
 	a := analyser.Analysis{}
 	a.Date = time.Date(2022, 4, 8, 10, 35, 0, 0, time.UTC)
@@ -209,6 +237,381 @@ func fakeAnalysisWithoutDeep() *analyser.Analysis {
 	return &a
 }
 
+func fakeAnalysisWithDeep() *analyser.Analysis {
+	a := analyser.Analysis{}
+	a.Date = time.Date(2022, 4, 8, 10, 35, 0, 0, time.UTC)
+	a.SetOptions(analyser.AnalyzerOptions{Deep: true})
+	a.AddUnmanaged(
+		&resource.Resource{
+			Id:   "unmanaged-id-1",
+			Type: "aws_unmanaged_resource",
+			Attrs: &resource.Attributes{
+				"name": "First unmanaged resource",
+			},
+		},
+	)
+	driftedRes := &resource.Resource{
+		Id:   "diff-id-1",
+		Type: "aws_diff_resource",
+		Attrs: &resource.Attributes{
+			"description":   "foo",
+			"instance_type": "t2.micro",
+		},
+	}
+	a.AddManaged(
+		driftedRes,
+		&resource.Resource{
+			Id:   "no-diff-id-1",
+			Type: "aws_no_diff_resource",
+		},
+	)
+	a.AddDifference(analyser.Difference{
+		Res: driftedRes,
+		Changelog: analyser.Changelog{
+			{
+				Change: diff.Change{
+					Type: diff.DELETE,
+					Path: []string{"description"},
+					From: "foo",
+					To:   nil,
+				},
+				Computed: true,
+			},
+			{
+				Change: diff.Change{
+					Type: diff.UPDATE,
+					Path: []string{"instance_type"},
+					From: "t2.micro",
+					To:   "t2.small",
+				},
+			},
+			{
+				Change: diff.Change{
+					Type: diff.CREATE,
+					Path: []string{"tags", "env"},
+					From: nil,
+					To:   "prod",
+				},
+			},
+		},
+	})
+	a.ProviderName = "AWS"
+	a.ProviderVersion = "3.19.0"
+	return &a
+}
+
+func fakeAnalysisWithDuplicates() *analyser.Analysis {
+	a := analyser.Analysis{}
+	a.Date = time.Date(2022, 4, 8, 10, 35, 0, 0, time.UTC)
+	a.AddManaged(
+		&resource.Resource{
+			Id:     "role-1",
+			Type:   "aws_iam_role",
+			Source: resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+		},
+	)
+	a.AddDuplicate(analyser.Duplicate{
+		Res: &resource.Resource{
+			Id:   "role-1",
+			Type: "aws_iam_role",
+		},
+		Sources: []resource.Source{
+			resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+			resource.NewTerraformStateSource("tfstate://second.tfstate", "module.iam", "admin"),
+		},
+	})
+	a.ProviderName = "AWS"
+	a.ProviderVersion = "3.19.0"
+	return &a
+}
+
+func fakeAnalysisWithBaseline() *analyser.Analysis {
+	baseline := &analyser.Analysis{}
+	baseline.AddUnmanaged(
+		&resource.Resource{
+			Id:   "unmanaged-id-1",
+			Type: "aws_unmanaged_resource",
+		},
+		&resource.Resource{
+			Id:   "unmanaged-id-3",
+			Type: "aws_unmanaged_resource",
+		},
+	)
+
+	a := analyser.Analysis{}
+	a.Date = time.Date(2022, 4, 8, 10, 35, 0, 0, time.UTC)
+	a.AddManaged(
+		&resource.Resource{
+			Id:   "managed-id-1",
+			Type: "aws_managed_resource",
+		},
+	)
+	a.AddUnmanaged(
+		&resource.Resource{
+			Id:   "unmanaged-id-1",
+			Type: "aws_unmanaged_resource",
+		},
+		&resource.Resource{
+			Id:   "unmanaged-id-2",
+			Type: "aws_unmanaged_resource",
+		},
+	)
+	a.AddDeleted(
+		&resource.Resource{
+			Id:   "deleted-id-1",
+			Type: "aws_deleted_resource",
+		},
+	)
+	a.ApplyBaseline(baseline)
+	a.ProviderName = "AWS"
+	a.ProviderVersion = "3.19.0"
+	return &a
+}
+
+func fakeAnalysisWithPolicy() *analyser.Analysis {
+	a := fakeAnalysis()
+	a.SetPolicyEvaluation(&analyser.PolicyEvaluation{
+		ExitCode: 3,
+		Rules: []analyser.RuleEvaluation{
+			{
+				Name:     "iam",
+				ExitCode: 4,
+			},
+			{
+				Name:     "min-coverage",
+				Violated: true,
+				ExitCode: 3,
+				Reasons:  []string{"coverage is 33%, expected at least 85%"},
+			},
+		},
+	})
+	return a
+}
+
+func fakeAnalysisWithRisks() *analyser.Analysis {
+	a := fakeAnalysis()
+	a.SetRisks([]analyser.Risk{
+		{
+			Res:      a.Unmanaged()[1],
+			Kind:     analyser.RiskKindUnmanaged,
+			Severity: analyser.SeverityMedium,
+			Rule:     "unmanaged-medium",
+		},
+		{
+			Res:         a.Deleted()[0],
+			Kind:        analyser.RiskKindMissing,
+			Severity:    analyser.SeverityHigh,
+			Rule:        "deleted-high",
+			Description: "Deleted resource",
+		},
+		{
+			Res:         a.Unmanaged()[0],
+			Kind:        analyser.RiskKindUnmanaged,
+			Severity:    analyser.SeverityCritical,
+			Rule:        "unmanaged-critical",
+			Description: "Unmanaged resource open to the internet",
+		},
+	})
+	return a
+}
+
+func fakeAnalysisWithProviders() *analyser.Analysis {
+	a := fakeAnalysis()
+	a.SetProviders(
+		analyser.Provider{Name: "aws+tf", Type: "aws", Version: "3.19.0"},
+		analyser.Provider{Name: "github+tf", Type: "github", Version: "4.4.0"},
+	)
+	return a
+}
+
+func fakeAnalysisWithExplanations() *analyser.Analysis {
+	a := fakeAnalysis()
+	a.SetExplanations([]analyser.Explanation{
+		{
+			Type: "aws_unmanaged_resource",
+			Id:   "unmanaged-id-1",
+			Steps: []analyser.ExplanationStep{
+				{Stage: analyser.ExplanationStageEnumeration, Message: "found on the cloud provider by aws.UnmanagedResourceEnumerator"},
+				{Stage: analyser.ExplanationStageIaC, Message: "not declared in any IaC source"},
+				{Stage: analyser.ExplanationStageDriftignore, Message: "kept by .driftignore:2: !aws_unmanaged_resource.unmanaged-id-1"},
+			},
+			Outcome: "reported as unmanaged, no IaC resource matches the cloud resource",
+		},
+		{
+			Type: "aws_iam_role",
+			Id:   "AWSServiceRoleForSupport",
+			Steps: []analyser.ExplanationStep{
+				{Stage: analyser.ExplanationStageEnumeration, Message: "found on the cloud provider by aws.IamRoleEnumerator"},
+				{Stage: analyser.ExplanationStageIaC, Message: "not declared in any IaC source"},
+				{Stage: analyser.ExplanationStageMiddleware, Message: "middlewares.AwsDefaults removed the cloud resource"},
+			},
+			Outcome: "not reported, the resource was removed before the analysis",
+		},
+	})
+	return a
+}
+
+func fakeAnalysisWithRelationships() *analyser.Analysis {
+	a := analyser.NewAnalysis()
+	a.AddManaged(
+		&resource.Resource{
+			Id:    "vpc-1",
+			Type:  "aws_vpc",
+			Attrs: &resource.Attributes{},
+			Source: &resource.TerraformStateSource{
+				State: "tfstate://network.tfstate",
+				Name:  "main",
+			},
+		},
+	)
+	a.AddUnmanaged(
+		&resource.Resource{
+			Id:   "subnet-1",
+			Type: "aws_subnet",
+			Attrs: &resource.Attributes{
+				"vpc_id": "vpc-1",
+			},
+		},
+		&resource.Resource{
+			Id:   "sg-1",
+			Type: "aws_security_group",
+			Attrs: &resource.Attributes{
+				"vpc_id": "vpc-1",
+			},
+		},
+	)
+	a.AddDeleted(
+		&resource.Resource{
+			Id:   "sgrule-1",
+			Type: "aws_security_group_rule",
+			Attrs: &resource.Attributes{
+				"security_group_id": "sg-1",
+			},
+			Source: &resource.TerraformStateSource{
+				State: "tfstate://network.tfstate",
+				Name:  "ingress",
+			},
+		},
+	)
+	return a
+}
+
+func fakeAnalysisForSARIF() *analyser.Analysis {
+	a := fakeAnalysis()
+	driftedRes := &resource.Resource{
+		Id:     "diff-id-1",
+		Type:   "aws_diff_resource",
+		Source: resource.NewTerraformStateSource("tfstate://diff_state.tfstate", "module.app", "diff"),
+	}
+	a.AddDifference(analyser.Difference{
+		Res: driftedRes,
+		Changelog: analyser.Changelog{
+			{
+				Change: diff.Change{
+					Type: diff.UPDATE,
+					Path: []string{"instance_type"},
+					From: "t2.micro",
+					To:   "t2.small",
+				},
+			},
+			{
+				Change: diff.Change{
+					Type: diff.CREATE,
+					Path: []string{"tags", "env"},
+					From: nil,
+					To:   "prod",
+				},
+			},
+		},
+	})
+	a.AddDuplicate(analyser.Duplicate{
+		Res: &resource.Resource{
+			Id:   "role-1",
+			Type: "aws_iam_role",
+		},
+		Sources: []resource.Source{
+			resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+			resource.NewTerraformStateSource("tfstate://second.tfstate", "module.iam", "admin"),
+		},
+	})
+	a.SetAlerts(alerter.Alerts{
+		"": []alerter.Alert{
+			alerts.NewRemoteAccessDeniedAlert(common.RemoteAWSTerraform, remoteerr.NewResourceListingErrorWithType(errors.New("dummy error"), "aws_vpc", "aws_vpc"), alerts.EnumerationPhase),
+		},
+	})
+	return a
+}
+
+func fakeAnalysisForJUnit() *analyser.Analysis {
+	a := fakeAnalysis()
+	a.AddUnmanaged(
+		&resource.Resource{
+			Id:   "unmanaged-id-3",
+			Type: "aws_unmanaged_resource",
+			Sch: &resource.Schema{
+				HumanReadableAttributesFunc: func(*resource.Resource) map[string]string {
+					return map[string]string{"name": "Third unmanaged resource", "cidr": "10.0.0.0/16"}
+				},
+			},
+		},
+	)
+	a.AddDifference(analyser.Difference{
+		Res: a.Managed()[0],
+		Changelog: analyser.Changelog{
+			{
+				Change: diff.Change{
+					Type: diff.UPDATE,
+					Path: []string{"tags", "env"},
+					From: "prod",
+					To:   "dev",
+				},
+			},
+			{
+				Change: diff.Change{
+					Type: diff.DELETE,
+					Path: []string{"description"},
+					From: "foo",
+					To:   nil,
+				},
+				Computed: true,
+			},
+		},
+	})
+	a.AddManaged(&resource.Resource{Id: "role-1", Type: "aws_iam_role"})
+	a.AddDuplicate(analyser.Duplicate{
+		Res: &resource.Resource{Id: "role-1", Type: "aws_iam_role"},
+		Sources: []resource.Source{
+			resource.NewTerraformStateSource("tfstate://first.tfstate", "", "role"),
+			resource.NewTerraformStateSource("tfstate://second.tfstate", "module.iam", "admin"),
+		},
+	})
+	a.SetAlerts(alerter.Alerts{
+		"aws_vpc": []alerter.Alert{
+			alerts.NewRemoteAccessDeniedAlert(common.RemoteAWSTerraform, remoteerr.NewResourceListingErrorWithType(errors.New("dummy error"), "aws_vpc", "aws_vpc"), alerts.EnumerationPhase),
+		},
+		"": []alerter.Alert{
+			analyser.NewComputedDiffAlert(),
+		},
+	})
+	return a
+}
+
+func fakeAnalysisForTemplate() *analyser.Analysis {
+	a := fakeAnalysis()
+	a.AddUnmanaged(
+		&resource.Resource{
+			Id:   "unmanaged-id-3",
+			Type: "aws_unmanaged_resource",
+			Sch: &resource.Schema{
+				HumanReadableAttributesFunc: func(*resource.Resource) map[string]string {
+					return map[string]string{"name": "<b>bucket</b>"}
+				},
+			},
+		},
+	)
+	return a
+}
+
 func TestGetPrinter(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+
+	"github.com/r3labs/diff/v2"
 
 	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
 	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
@@ -47,6 +50,8 @@ type rsc struct {
 	AttributeValues map[string]interface{} `json:"values,omitempty"`
 }
 
+// Plan writes the analysis as a Terraform JSON plan, so that tools reading plans can consume it. The format has no
+// place for the new, persisting and resolved groups of a baseline, they are left out and every finding is written.
 type Plan struct {
 	path string
 }
@@ -101,16 +106,21 @@ func listRsc(resources []*resource.Resource) []rsc {
 }
 
 func addResourceChanges(analysis *analyser.Analysis) []rscChange {
-	managedRsc := listRscChange(analysis.Managed(), "no-op")
-	unmanagedRsc := listRscChange(analysis.Unmanaged(), "create")
+	differences := make(map[string]analyser.Difference, len(analysis.Differences()))
+	for _, d := range analysis.Differences() {
+		differences[fmt.Sprintf("%s.%s", d.Res.ResourceType(), d.Res.ResourceId())] = d
+	}
+	managedRsc := listRscChange(analysis.Managed(), "no-op", differences)
+	unmanagedRsc := listRscChange(analysis.Unmanaged(), "create", differences)
 	return append(managedRsc, unmanagedRsc...)
 }
 
-func listRscChange(resources []*resource.Resource, action string) []rscChange {
+func listRscChange(resources []*resource.Resource, action string, differences map[string]analyser.Difference) []rscChange {
 	var ret []rscChange
 	for _, res := range resources {
+		address := fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId())
 		r := rscChange{
-			Address: fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()),
+			Address: address,
 			Type:    res.ResourceType(),
 			Name:    res.ResourceId(),
 			Change: change{
@@ -120,9 +130,81 @@ func listRscChange(resources []*resource.Resource, action string) []rscChange {
 		}
 		if action == "no-op" {
 			r.Change.Before = *res.Attributes()
+			// A drifted managed resource needs to be updated to match its state, the remote values
+			// are rebuilt by applying the changelog to the state attributes
+			if d, exist := differences[address]; exist {
+				r.Change.Actions = []string{"update"}
+				r.Change.Before = patchAttributes(*res.Attributes(), d.Changelog)
+			}
 		}
 		ret = append(ret, r)
 
 	}
 	return ret
 }
+
+func patchAttributes(attrs map[string]interface{}, changelog analyser.Changelog) map[string]interface{} {
+	patched := make(map[string]interface{}, len(attrs))
+	raw, err := json.Marshal(attrs)
+	if err != nil {
+		return attrs
+	}
+	if err := json.Unmarshal(raw, &patched); err != nil {
+		return attrs
+	}
+
+	for _, c := range changelog {
+		if len(c.Path) == 0 {
+			continue
+		}
+		var value interface{} = patched
+		patchValue(&value, c.Path, c.Type, c.To)
+	}
+
+	return patched
+}
+
+func patchValue(target *interface{}, path []string, changeType string, to interface{}) {
+	key := path[0]
+	last := len(path) == 1
+
+	switch node := (*target).(type) {
+	case map[string]interface{}:
+		if last {
+			if changeType == diff.DELETE {
+				delete(node, key)
+				return
+			}
+			node[key] = to
+			return
+		}
+		child, exist := node[key]
+		if !exist || child == nil {
+			child = map[string]interface{}{}
+		}
+		patchValue(&child, path[1:], changeType, to)
+		node[key] = child
+	case []interface{}:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 {
+			return
+		}
+		if last {
+			switch {
+			case changeType == diff.DELETE && i < len(node):
+				*target = append(node[:i], node[i+1:]...)
+			case changeType != diff.DELETE && i < len(node):
+				node[i] = to
+			case changeType != diff.DELETE:
+				*target = append(node, to)
+			}
+			return
+		}
+		if i >= len(node) {
+			return
+		}
+		child := node[i]
+		patchValue(&child, path[1:], changeType, to)
+		node[i] = child
+	}
+}
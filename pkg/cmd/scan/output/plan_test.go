@@ -25,6 +25,12 @@ func TestPlan_Write(t *testing.T) {
 			analysis:   fakeAnalysisForJSONPlan(),
 			wantErr:    false,
 		},
+		{
+			name:       "test jsonplan output with drifted resources",
+			goldenfile: "output_plan_deep.json",
+			analysis:   fakeAnalysisForJSONPlanWithDeep(),
+			wantErr:    false,
+		},
 		{
 			name:       "test jsonplan output when no infra",
 			goldenfile: "output_plan_empty.json",
@@ -0,0 +1,109 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
+)
+
+var remoteOutputExample = map[string]string{
+	backend.BackendKeyS3:      "s3://BUCKET/PATH/TO/OBJECT",
+	backend.BackendKeyGS:      "gs://BUCKET/PATH/TO/OBJECT",
+	backend.BackendKeyAzureRM: "azurerm://CONTAINER/PATH/TO/OBJECT",
+}
+
+// datePlaceholders maps the placeholders of remote paths to time layouts, e.g. s3://bucket/%Y/%m/%d/scan.json
+var datePlaceholders = []string{"%Y", "2006", "%m", "01", "%d", "02", "%H", "15", "%M", "04", "%S", "05"}
+
+// Remote renders an output to a temporary file then uploads it to an object storage. The destination is only written
+// once the whole report is rendered, so a failing output never leaves a truncated report behind.
+type Remote struct {
+	config      OutputConfig
+	backend     string
+	path        string
+	opts        *backend.Options
+	getUploader func(backendKey, path string, opts *backend.Options) (backend.Uploader, error)
+}
+
+func NewRemote(config OutputConfig, opts *backend.Options) *Remote {
+	backendKey, path, _ := SplitRemotePath(config.Destination())
+	return &Remote{config, backendKey, path, opts, backend.GetUploader}
+}
+
+// IsRemoteScheme returns true when the scheme is the one of an object storage outputs can be written to
+func IsRemoteScheme(scheme string) bool {
+	return backend.IsUploaderSupported(scheme)
+}
+
+// SplitRemotePath splits a destination like s3://bucket/key.json into the key of the backend and the path of the
+// object, ok is false for local paths
+func SplitRemotePath(path string) (backendKey, objectPath string, ok bool) {
+	parts := strings.SplitN(path, "://", 2)
+	if len(parts) != 2 || !IsRemoteScheme(parts[0]) {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// IsValidRemotePath returns true when the path of an object has a bucket and a key, e.g. bucket/key.json
+func IsValidRemotePath(objectPath string) bool {
+	parts := strings.SplitN(objectPath, "/", 2)
+	return len(parts) == 2 && parts[0] != "" && parts[1] != "" && !strings.HasSuffix(parts[1], "/")
+}
+
+func RemoteExample(backendKey string) string {
+	return remoteOutputExample[backendKey]
+}
+
+// FormatRemotePath expands the date placeholders %Y, %m, %d, %H, %M and %S of a remote path, in UTC. A literal
+// percent sign is written %%.
+func FormatRemotePath(path string, date time.Time) string {
+	replacements := []string{"%%", "%"}
+	for i := 0; i < len(datePlaceholders); i += 2 {
+		replacements = append(replacements, datePlaceholders[i], date.UTC().Format(datePlaceholders[i+1]))
+	}
+	return strings.NewReplacer(replacements...).Replace(path)
+}
+
+func (c *Remote) Write(analysis *analyser.Analysis) error {
+	date := analysis.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	path := FormatRemotePath(c.path, date)
+
+	uploader, err := c.getUploader(c.backend, path, c.opts)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp("", "driftctl-output-*"+filepath.Ext(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	if err := GetOutput(c.config.WithDestination(tmpFile.Name()), nil).Write(analysis); err != nil {
+		return err
+	}
+
+	content, err := os.Open(tmpFile.Name())
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if err := uploader.Upload(content); err != nil {
+		return errors.Wrapf(err, "unable to upload to %s://%s", c.backend, path)
+	}
+	return nil
+}
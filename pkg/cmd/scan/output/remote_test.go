@@ -0,0 +1,159 @@
+package output
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
+)
+
+type fakeUploader struct {
+	content []byte
+	err     error
+}
+
+func (u *fakeUploader) Upload(content io.ReadSeeker) error {
+	if u.err != nil {
+		return u.err
+	}
+	b, err := io.ReadAll(content)
+	u.content = b
+	return err
+}
+
+func TestRemote_Write(t *testing.T) {
+	uploader := &fakeUploader{}
+	var gotBackend, gotPath string
+	remote := NewRemote(OutputConfig{Key: JSONOutputType, Path: "s3://bucket/reports/%Y/%m/%d/scan-%H%M.json"}, nil)
+	remote.getUploader = func(backendKey, path string, opts *backend.Options) (backend.Uploader, error) {
+		gotBackend, gotPath = backendKey, path
+		return uploader, nil
+	}
+
+	if err := remote.Write(fakeAnalysis()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := os.ReadFile("./testdata/output.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "s3", gotBackend)
+	assert.Equal(t, "bucket/reports/2022/04/08/scan-1035.json", gotPath)
+	assert.Equal(t, string(expected), string(uploader.content))
+}
+
+func TestRemote_WriteUploadError(t *testing.T) {
+	remote := NewRemote(OutputConfig{Key: JSONOutputType, Path: "gs://bucket/scan.json"}, nil)
+	remote.getUploader = func(backendKey, path string, opts *backend.Options) (backend.Uploader, error) {
+		return &fakeUploader{err: errors.New("permission denied")}, nil
+	}
+
+	err := remote.Write(fakeAnalysis())
+	assert.EqualError(t, err, "unable to upload to gs://bucket/scan.json: permission denied")
+}
+
+func TestGetOutput_Remote(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  OutputConfig
+		backend string
+		path    string
+	}{
+		{
+			name:    "test json output to s3",
+			config:  OutputConfig{Key: JSONOutputType, Path: "s3://bucket/scan.json"},
+			backend: "s3",
+			path:    "bucket/scan.json",
+		},
+		{
+			name:    "test template output to azure blob storage",
+			config:  OutputConfig{Key: TemplateOutputType, Path: "report.tmpl:azurerm://container/report.md"},
+			backend: "azurerm",
+			path:    "container/report.md",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote, ok := GetOutput(tt.config, nil).(*Remote)
+			if !ok {
+				t.Fatalf("expected a remote output for %s", tt.config.Path)
+			}
+			assert.Equal(t, tt.backend, remote.backend)
+			assert.Equal(t, tt.path, remote.path)
+		})
+	}
+
+	_, ok := GetOutput(OutputConfig{Key: JSONOutputType, Path: "result.json"}, nil).(*Remote)
+	assert.False(t, ok)
+}
+
+func TestFormatRemotePath(t *testing.T) {
+	date := time.Date(2022, 4, 8, 10, 35, 7, 0, time.FixedZone("CEST", 2*60*60))
+
+	assert.Equal(t, "bucket/2022/04/08/08-35-07.json", FormatRemotePath("bucket/%Y/%m/%d/%H-%M-%S.json", date))
+	assert.Equal(t, "bucket/100%/scan.json", FormatRemotePath("bucket/100%%/scan.json", date))
+	assert.Equal(t, "bucket/scan.json", FormatRemotePath("bucket/scan.json", date))
+}
+
+func TestSplitRemotePath(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		backend    string
+		objectPath string
+		ok         bool
+	}{
+		{
+			name:       "test s3",
+			path:       "s3://bucket/path/to/scan.json",
+			backend:    "s3",
+			objectPath: "bucket/path/to/scan.json",
+			ok:         true,
+		},
+		{
+			name:       "test google storage",
+			path:       "gs://bucket/report.html",
+			backend:    "gs",
+			objectPath: "bucket/report.html",
+			ok:         true,
+		},
+		{
+			name:       "test azure blob storage",
+			path:       "azurerm://container/path",
+			backend:    "azurerm",
+			objectPath: "container/path",
+			ok:         true,
+		},
+		{
+			name: "test local path",
+			path: "/tmp/scan.json",
+		},
+		{
+			name: "test unsupported backend",
+			path: "https://example.com/scan.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backendKey, objectPath, ok := SplitRemotePath(tt.path)
+			assert.Equal(t, tt.backend, backendKey)
+			assert.Equal(t, tt.objectPath, objectPath)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestIsValidRemotePath(t *testing.T) {
+	assert.True(t, IsValidRemotePath("bucket/scan.json"))
+	assert.True(t, IsValidRemotePath("bucket/path/to/scan.json"))
+	assert.False(t, IsValidRemotePath("bucket"))
+	assert.False(t, IsValidRemotePath("bucket/"))
+	assert.False(t, IsValidRemotePath("/scan.json"))
+	assert.False(t, IsValidRemotePath("bucket/path/"))
+}
@@ -0,0 +1,100 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+// TestJSON_RoundTrip ensures that rendering an analysis read back from the JSON output
+// gives the same report as rendering the analysis directly
+func TestJSON_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		analysis func() *analyser.Analysis
+	}{
+		{
+			name:     "analysis with drifts",
+			analysis: fakeAnalysis,
+		},
+		{
+			name:     "analysis in deep mode",
+			analysis: fakeAnalysisWithDeep,
+		},
+		{
+			name:     "analysis with duplicates",
+			analysis: fakeAnalysisWithDuplicates,
+		},
+		{
+			name:     "analysis with baseline",
+			analysis: fakeAnalysisWithBaseline,
+		},
+		{
+			name:     "analysis with failure policy",
+			analysis: fakeAnalysisWithPolicy,
+		},
+		{
+			name:     "analysis with security risks",
+			analysis: fakeAnalysisWithRisks,
+		},
+		{
+			name:     "analysis with access denied alerts",
+			analysis: fakeAnalysisWithAWSEnumerationError,
+		},
+		{
+			name: "analysis with human readable attributes",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysis()
+				a.AddUnmanaged(&resource.Resource{
+					Id:   "unmanaged-id-3",
+					Type: "aws_unmanaged_resource",
+					Sch: &resource.Schema{
+						HumanReadableAttributesFunc: func(res *resource.Resource) map[string]string {
+							return map[string]string{"name": "unmanaged"}
+						},
+					},
+				})
+				return a
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+
+			analysis := tt.analysis()
+			analysis.SetWithAttributes(true)
+
+			expectedPath := path.Join(tempDir, "expected.html")
+			require.NoError(t, NewHTML(expectedPath).Write(analysis))
+
+			jsonPath := path.Join(tempDir, "analysis.json")
+			require.NoError(t, NewJSON(jsonPath).Write(analysis))
+			rawJSON, err := os.ReadFile(jsonPath)
+			require.NoError(t, err)
+
+			readAnalysis := &analyser.Analysis{}
+			require.NoError(t, json.Unmarshal(rawJSON, readAnalysis))
+
+			resultPath := path.Join(tempDir, "result.html")
+			require.NoError(t, NewHTML(resultPath).Write(readAnalysis))
+
+			expected, err := os.ReadFile(expectedPath)
+			require.NoError(t, err)
+			result, err := os.ReadFile(resultPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(result))
+
+			rewrittenJSON, err := json.MarshalIndent(readAnalysis, "", "\t")
+			require.NoError(t, err)
+			assert.Equal(t, string(rawJSON), string(rewrittenJSON))
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/version"
+)
+
+const SARIFOutputType = "sarif"
+const SARIFOutputExample = "sarif://PATH/TO/FILE.sarif"
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifFingerprint is the key of the fingerprint of results, its version must be bumped when the computation changes
+	sarifFingerprint = "driftctlResource/v1"
+	// sarifCloudResourcesURI is a placeholder artifact for results of resources without state: unmanaged resources are
+	// not declared in any file, but code scanning dashboards reject results without physical location. The resource
+	// itself is the logical location of these results.
+	sarifCloudResourcesURI = "cloud-resources"
+)
+
+// Baseline states of results, only set when the scan is compared to a baseline
+const (
+	sarifBaselineNew       = "new"
+	sarifBaselineUnchanged = "unchanged"
+	sarifBaselineAbsent    = "absent"
+)
+
+// Categories of findings, a rule is defined for each category and resource type
+const (
+	sarifCategoryUnmanaged = "unmanaged"
+	sarifCategoryMissing   = "missing"
+	sarifCategoryChanged   = "changed"
+	sarifCategoryDuplicate = "duplicate"
+)
+
+var sarifCategoryDescriptions = map[string]string{
+	sarifCategoryUnmanaged: "Resource found on the cloud provider but not managed by Terraform",
+	sarifCategoryMissing:   "Resource managed by Terraform but missing on the cloud provider",
+	sarifCategoryChanged:   "Resource managed by Terraform whose attributes changed on the cloud provider",
+	sarifCategoryDuplicate: "Resource managed by several Terraform resources",
+}
+
+var sarifCategoryLevels = map[string]string{
+	sarifCategoryUnmanaged: "warning",
+	sarifCategoryMissing:   "error",
+	sarifCategoryChanged:   "warning",
+	sarifCategoryDuplicate: "warning",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications"`
+}
+
+type sarifNotification struct {
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID        string                 `json:"ruleId"`
+	RuleIndex     int                    `json:"ruleIndex"`
+	Level         string                 `json:"level"`
+	Message       sarifMessage           `json:"message"`
+	Locations     []sarifLocation        `json:"locations"`
+	Fingerprints  map[string]string      `json:"fingerprints"`
+	BaselineState string                 `json:"baselineState,omitempty"`
+	Properties    map[string]interface{} `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind"`
+}
+
+// SARIF writes findings as a SARIF log, the format of code scanning dashboards. Unmanaged, missing, changed and
+// duplicated resources are results of a rule per category and resource type, alerts are tool notifications. Results
+// are located in the state declaring the resource, or on a placeholder artifact when there is none. When the scan is
+// compared to a baseline, results carry their baseline state and resolved findings are absent results.
+type SARIF struct {
+	path string
+}
+
+func NewSARIF(path string) *SARIF {
+	return &SARIF{path}
+}
+
+func (c *SARIF) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	content, err := json.MarshalIndent(newSARIFLog(analysis), "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newSARIFLog(analysis *analyser.Analysis) sarifLog {
+	results := sarifResults(analyser.Findings{
+		Unmanaged:   analysis.Unmanaged(),
+		Deleted:     analysis.Deleted(),
+		Differences: analysis.Differences(),
+		Duplicates:  analysis.Duplicates(),
+	})
+	if baseline := analysis.Baseline(); baseline != nil {
+		results = withSARIFBaselineStates(results, baseline)
+	}
+
+	rules := sarifRules(results)
+	ruleIndexes := make(map[string]int, len(rules))
+	for i, rule := range rules {
+		ruleIndexes[rule.ID] = i
+	}
+	for i := range results {
+		results[i].RuleIndex = ruleIndexes[results[i].RuleID]
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{Driver: sarifDriver{
+					Name:           "driftctl",
+					Version:        version.Current(),
+					InformationURI: "https://docs.driftctl.com",
+					Rules:          rules,
+				}},
+				Invocations: []sarifInvocation{
+					{
+						ExecutionSuccessful:        true,
+						ToolExecutionNotifications: sarifNotifications(analysis.Alerts()),
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+// sarifResults returns a result per finding
+func sarifResults(findings analyser.Findings) []sarifResult {
+	results := make([]sarifResult, 0)
+	for _, res := range findings.Unmanaged {
+		results = append(results, newSARIFResult(sarifCategoryUnmanaged, res,
+			fmt.Sprintf("%s %s is not managed by Terraform", res.ResourceType(), res.ResourceId()),
+			sarifSourceLocations(res, nil),
+		))
+	}
+	for _, res := range findings.Deleted {
+		results = append(results, newSARIFResult(sarifCategoryMissing, res,
+			fmt.Sprintf("%s %s is managed by Terraform but missing on the cloud provider", res.ResourceType(), res.ResourceId()),
+			sarifSourceLocations(res, res.Source),
+		))
+	}
+	for _, difference := range findings.Differences {
+		paths := make([]string, 0, len(difference.Changelog))
+		for _, change := range difference.Changelog {
+			paths = append(paths, strings.Join(change.Path, "."))
+		}
+		res := difference.Res
+		results = append(results, newSARIFResult(sarifCategoryChanged, res,
+			fmt.Sprintf("%s %s changed on the cloud provider: %s", res.ResourceType(), res.ResourceId(), strings.Join(paths, ", ")),
+			sarifSourceLocations(res, res.Source),
+		))
+	}
+	for _, duplicate := range findings.Duplicates {
+		locations := make([]sarifLocation, 0, len(duplicate.Sources))
+		for _, source := range duplicate.Sources {
+			locations = append(locations, sarifSourceLocations(duplicate.Res, source)...)
+		}
+		res := duplicate.Res
+		results = append(results, newSARIFResult(sarifCategoryDuplicate, res,
+			fmt.Sprintf("%s %s is managed by %d Terraform resources", res.ResourceType(), res.ResourceId(), len(duplicate.Sources)),
+			locations,
+		))
+	}
+	return results
+}
+
+// withSARIFBaselineStates sets the baseline state of the results of a scan compared to a baseline: new findings are
+// new, the others are unchanged. Resolved findings are added as absent results, so that dashboards close them.
+func withSARIFBaselineStates(results []sarifResult, baseline *analyser.BaselineComparison) []sarifResult {
+	newResults := make(map[string]struct{})
+	for _, result := range sarifResults(baseline.New) {
+		newResults[sarifResultKey(result)] = struct{}{}
+	}
+	for i := range results {
+		results[i].BaselineState = sarifBaselineUnchanged
+		if _, exist := newResults[sarifResultKey(results[i])]; exist {
+			results[i].BaselineState = sarifBaselineNew
+		}
+	}
+	for _, result := range sarifResults(baseline.Resolved) {
+		result.BaselineState = sarifBaselineAbsent
+		results = append(results, result)
+	}
+	return results
+}
+
+// sarifResultKey identifies the result of a rule for a resource
+func sarifResultKey(result sarifResult) string {
+	return fmt.Sprintf("%s#%s", result.RuleID, result.Fingerprints[sarifFingerprint])
+}
+
+// sarifRuleID is stable across scans: dashboards track results by rule
+func sarifRuleID(category, ty string) string {
+	return fmt.Sprintf("%s/%s", category, ty)
+}
+
+// sarifResourceFingerprint only depends on the type and id of the resource, so that a result keeps its history in
+// dashboards as long as the resource exists
+func sarifResourceFingerprint(res *resource.Resource) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId())))
+	return hex.EncodeToString(sum[:])
+}
+
+func newSARIFResult(category string, res *resource.Resource, message string, locations []sarifLocation) sarifResult {
+	return sarifResult{
+		RuleID:       sarifRuleID(category, res.ResourceType()),
+		Level:        sarifCategoryLevels[category],
+		Message:      sarifMessage{Text: message},
+		Locations:    locations,
+		Fingerprints: map[string]string{sarifFingerprint: sarifResourceFingerprint(res)},
+		Properties: map[string]interface{}{
+			"category":     category,
+			"resourceType": res.ResourceType(),
+			"resourceId":   res.ResourceId(),
+		},
+	}
+}
+
+// sarifSourceLocations points at the state and module declaring a resource, a resource without source is only
+// located by its type and id
+func sarifSourceLocations(res *resource.Resource, source resource.Source) []sarifLocation {
+	if source == nil {
+		return []sarifLocation{{
+			PhysicalLocation: newSARIFPhysicalLocation(sarifCloudResourcesURI),
+			LogicalLocations: []sarifLogicalLocation{{
+				Name:               res.ResourceId(),
+				FullyQualifiedName: fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()),
+				Kind:               "resource",
+			}},
+		}}
+	}
+
+	address := fmt.Sprintf("%s.%s", res.ResourceType(), source.InternalName())
+	logicalLocations := []sarifLogicalLocation{
+		{Name: source.Source(), Kind: "namespace"},
+	}
+	if source.Namespace() != "" {
+		address = fmt.Sprintf("%s.%s", source.Namespace(), address)
+		logicalLocations = append(logicalLocations, sarifLogicalLocation{
+			Name:               source.Namespace(),
+			FullyQualifiedName: fmt.Sprintf("%s#%s", source.Source(), source.Namespace()),
+			Kind:               "module",
+		})
+	}
+	logicalLocations = append(logicalLocations, sarifLogicalLocation{
+		Name:               source.InternalName(),
+		FullyQualifiedName: fmt.Sprintf("%s#%s", source.Source(), address),
+		Kind:               "resource",
+	})
+
+	return []sarifLocation{{
+		PhysicalLocation: newSARIFPhysicalLocation(sarifStateURI(source.Source())),
+		LogicalLocations: logicalLocations,
+	}}
+}
+
+func newSARIFPhysicalLocation(uri string) sarifPhysicalLocation {
+	return sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}
+}
+
+// sarifStateURI turns an IaC source into the URI of its state file, e.g. tfstate+s3://bucket/key.tfstate becomes
+// s3://bucket/key.tfstate and tfstate://states/prod.tfstate becomes the relative path states/prod.tfstate
+func sarifStateURI(source string) string {
+	parts := strings.SplitN(source, "://", 2)
+	if len(parts) != 2 {
+		return (&url.URL{Path: filepath.ToSlash(source)}).String()
+	}
+	if i := strings.Index(parts[0], "+"); i >= 0 {
+		return fmt.Sprintf("%s://%s", parts[0][i+1:], parts[1])
+	}
+	return (&url.URL{Path: filepath.ToSlash(parts[1])}).String()
+}
+
+// sarifRules returns the rules of the results, sorted by id
+func sarifRules(results []sarifResult) []sarifRule {
+	rules := make([]sarifRule, 0)
+	seen := make(map[string]struct{})
+	for _, result := range results {
+		if _, exist := seen[result.RuleID]; exist {
+			continue
+		}
+		seen[result.RuleID] = struct{}{}
+		category := result.Properties["category"].(string)
+		ty := result.Properties["resourceType"].(string)
+		rules = append(rules, sarifRule{
+			ID:                   result.RuleID,
+			Name:                 sarifRuleName(category, ty),
+			ShortDescription:     sarifMessage{Text: fmt.Sprintf("%s (%s)", sarifCategoryDescriptions[category], ty)},
+			DefaultConfiguration: sarifRuleConfiguration{Level: sarifCategoryLevels[category]},
+			Properties: map[string]interface{}{
+				"category":     category,
+				"resourceType": ty,
+			},
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+// sarifRuleName turns a rule into a PascalCase name as the SARIF spec recommends, e.g. UnmanagedAwsS3Bucket
+func sarifRuleName(category, ty string) string {
+	var b strings.Builder
+	for _, word := range append([]string{category}, strings.Split(ty, "_")...) {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// sarifNotifications returns alerts sorted by their key, alerts of a resource type are keyed by that type
+func sarifNotifications(alerts alerter.Alerts) []sarifNotification {
+	keys := make([]string, 0, len(alerts))
+	for key := range alerts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	notifications := make([]sarifNotification, 0)
+	for _, key := range keys {
+		for _, alert := range alerts[key] {
+			notification := sarifNotification{Level: "warning", Message: sarifMessage{Text: alert.Message()}}
+			if key != "" {
+				notification.Properties = map[string]interface{}{"key": key}
+			}
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/test/goldenfile"
+)
+
+func TestSARIF_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		analysis   *analyser.Analysis
+	}{
+		{
+			name:       "test sarif output",
+			goldenfile: "output.sarif",
+			analysis:   fakeAnalysisForSARIF(),
+		},
+		{
+			name:       "test sarif output without drift",
+			goldenfile: "output_empty.sarif",
+			analysis:   fakeAnalysisNoDrift(),
+		},
+		{
+			name:       "test sarif output with baseline",
+			goldenfile: "output_baseline.sarif",
+			analysis:   fakeAnalysisWithBaseline(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp(t.TempDir(), "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := NewSARIF(tempFile.Name()).Write(tt.analysis); err != nil {
+				t.Fatal(err)
+			}
+			result, err := os.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := os.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+func TestSARIF_Write_Schema(t *testing.T) {
+	schema, err := os.ReadFile("./testdata/sarif-schema-2.1.0.json")
+	require.NoError(t, err)
+
+	for name, analysis := range map[string]*analyser.Analysis{
+		"drift":    fakeAnalysisForSARIF(),
+		"no drift": fakeAnalysisNoDrift(),
+		"baseline": fakeAnalysisWithBaseline(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			resultPath := path.Join(t.TempDir(), "result.sarif")
+			require.NoError(t, NewSARIF(resultPath).Write(analysis))
+			result, err := os.ReadFile(resultPath)
+			require.NoError(t, err)
+
+			validation, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(result))
+			require.NoError(t, err)
+			for _, e := range validation.Errors() {
+				t.Error(e.String())
+			}
+		})
+	}
+}
+
+func Test_sarifStateURI(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{source: "tfstate://terraform.tfstate", expected: "terraform.tfstate"},
+		{source: "tfstate://states/prod env.tfstate", expected: "states/prod%20env.tfstate"},
+		{source: "tfstate+s3://bucket/path/to/state.tfstate", expected: "s3://bucket/path/to/state.tfstate"},
+		{source: "tfstate+https://example.com/state.tfstate", expected: "https://example.com/state.tfstate"},
+		{source: "terraform.tfstate", expected: "terraform.tfstate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sarifStateURI(tt.source))
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package output
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/r3labs/diff/v2"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+const TemplateOutputType = "template"
+const TemplateOutputExample = "template://PATH/TO/TEMPLATE.tmpl:PATH/TO/FILE"
+
+// TemplateData is the data model user templates are rendered against, see docs/output-templates.md
+type TemplateData struct {
+	IsSync          bool
+	ScanDate        time.Time
+	ScanDuration    time.Duration
+	Coverage        int
+	Summary         analyser.Summary
+	ProviderName    string
+	ProviderVersion string
+	// Providers is only set when several cloud providers were scanned
+	Providers []analyser.ProviderSummary
+	Deep      bool
+	Managed   []*resource.Resource
+	Unmanaged []*resource.Resource
+	// Deleted are the resources found in a state but missing on the cloud provider
+	Deleted     []*resource.Resource
+	Differences []analyser.Difference
+	Duplicates  []analyser.Duplicate
+	Risks       []analyser.Risk
+	Alerts      alerter.Alerts
+	// Baseline is only set when the scan was compared to a baseline
+	Baseline *analyser.BaselineComparison
+}
+
+// Template renders a user template. Templates whose name ends with .html, .htm or .gohtml, optionally followed by
+// .tmpl, are rendered with html/template to escape resource attributes, others with text/template.
+type Template struct {
+	templatePath string
+	path         string
+}
+
+func NewTemplate(templatePath, path string) *Template {
+	return &Template{templatePath, path}
+}
+
+// SplitTemplatePath splits the path of a template output into the path of the template and the path of the rendered
+// file, the first colon separates them
+func SplitTemplatePath(path string) (templatePath, outputPath string, ok bool) {
+	parts := strings.SplitN(path, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func (c *Template) Write(analysis *analyser.Analysis) error {
+	content, err := os.ReadFile(c.templatePath)
+	if err != nil {
+		return err
+	}
+
+	var tmpl interface {
+		Execute(w io.Writer, data interface{}) error
+	}
+	name := filepath.Base(c.templatePath)
+	if isHTMLTemplate(name) {
+		tmpl, err = htmltemplate.New(name).Funcs(templateFuncs(analysis)).Parse(string(content))
+	} else {
+		tmpl, err = template.New(name).Funcs(templateFuncs(analysis)).Parse(string(content))
+	}
+	if err != nil {
+		return err
+	}
+
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	return tmpl.Execute(file, newTemplateData(analysis))
+}
+
+func newTemplateData(analysis *analyser.Analysis) *TemplateData {
+	data := &TemplateData{
+		IsSync:          analysis.IsSync(),
+		ScanDate:        analysis.Date,
+		ScanDuration:    analysis.Duration,
+		Coverage:        analysis.Coverage(),
+		Summary:         analysis.Summary(),
+		ProviderName:    analysis.ProviderName,
+		ProviderVersion: analysis.ProviderVersion,
+		Deep:            analysis.Options().Deep,
+		Managed:         analysis.Managed(),
+		Unmanaged:       analysis.Unmanaged(),
+		Deleted:         analysis.Deleted(),
+		Differences:     analysis.Differences(),
+		Duplicates:      analysis.Duplicates(),
+		Risks:           analysis.Risks(),
+		Alerts:          analysis.Alerts(),
+		Baseline:        analysis.Baseline(),
+	}
+	if providers := analysis.Providers(); len(providers) > 1 {
+		data.Providers = providers
+	}
+	return data
+}
+
+func isHTMLTemplate(name string) bool {
+	name = strings.TrimSuffix(name, ".tmpl")
+	switch filepath.Ext(name) {
+	case ".html", ".htm", ".gohtml":
+		return true
+	}
+	return false
+}
+
+// templateFuncs are the helpers of the html output and of user templates. The underlying type of html/template and
+// text/template func maps is the same, so the returned map fits both.
+func templateFuncs(analysis *analyser.Analysis) map[string]interface{} {
+	return map[string]interface{}{
+		"getResourceTypes": func() []string {
+			resources := make([]*resource.Resource, 0)
+			resources = append(resources, analysis.Unmanaged()...)
+			resources = append(resources, analysis.Deleted()...)
+			for _, d := range analysis.Differences() {
+				resources = append(resources, d.Res)
+			}
+			for _, d := range analysis.Duplicates() {
+				resources = append(resources, d.Res)
+			}
+			if analysis.Baseline() != nil {
+				for _, f := range baselineFindings(analysis.Baseline()) {
+					resources = append(resources, f.Res)
+				}
+			}
+
+			return distinctResourceTypes(resources)
+		},
+		"getIaCSources": func() []string {
+			resources := make([]*resource.Resource, 0)
+			resources = append(resources, analysis.Deleted()...)
+			resources = append(resources, analysis.Managed()...)
+			for _, d := range analysis.Duplicates() {
+				for _, src := range d.Sources {
+					resources = append(resources, &resource.Resource{Source: src})
+				}
+			}
+
+			return distinctIaCSources(resources)
+		},
+		"rate": func(count int) float64 {
+			if analysis.Summary().TotalResources == 0 {
+				return 0
+			}
+			rate := 100 * float64(count) / float64(analysis.Summary().TotalResources)
+			return math.Floor(rate*100) / 100
+		},
+		"joinPath": func(path []string) string {
+			return strings.Join(path, ".")
+		},
+		"prettify": prettify,
+		"formatChangeType": func(changeType string) string {
+			switch changeType {
+			case diff.CREATE:
+				return "+"
+			case diff.DELETE:
+				return "-"
+			}
+			return "~"
+		},
+		"groupByType": func(resources []*resource.Resource) map[string][]*resource.Resource {
+			grouped, _ := groupByType(resources)
+			return grouped
+		},
+		"groupBySource": groupBySource,
+		"sortResources": sortResources,
+		"sortStrings": func(values []string) []string {
+			sorted := append([]string{}, values...)
+			sort.Strings(sorted)
+			return sorted
+		},
+		"attributes": func(res *resource.Resource) map[string]string {
+			if res.Schema() == nil || res.Schema().HumanReadableAttributesFunc == nil {
+				return map[string]string{}
+			}
+			return res.Schema().HumanReadableAttributesFunc(res)
+		},
+		"sourceString": func(res *resource.Resource) string {
+			return res.SourceString()
+		},
+	}
+}
+
+// groupBySource groups resources by the state declaring them, resources without state are under an empty key
+func groupBySource(resources []*resource.Resource) map[string][]*resource.Resource {
+	grouped := make(map[string][]*resource.Resource)
+	for _, res := range resources {
+		key := ""
+		if res.Source != nil {
+			key = res.Source.Source()
+		}
+		grouped[key] = append(grouped[key], res)
+	}
+	return grouped
+}
+
+// sortResources returns a copy of the resources sorted by type then id
+func sortResources(resources []*resource.Resource) []*resource.Resource {
+	sorted := append([]*resource.Resource{}, resources...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ResourceType() != sorted[j].ResourceType() {
+			return sorted[i].ResourceType() < sorted[j].ResourceType()
+		}
+		return sorted[i].ResourceId() < sorted[j].ResourceId()
+	})
+	return sorted
+}
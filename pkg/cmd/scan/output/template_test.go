@@ -0,0 +1,126 @@
+package output
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/test/goldenfile"
+)
+
+func TestTemplate_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		template   string
+		goldenfile string
+		analysis   *analyser.Analysis
+	}{
+		{
+			name:       "test text template",
+			template:   "report.md.tmpl",
+			goldenfile: "output_template.md",
+			analysis:   fakeAnalysis(),
+		},
+		{
+			name:       "test html template escapes attributes",
+			template:   "report.html.tmpl",
+			goldenfile: "output_template.html",
+			analysis:   fakeAnalysisForTemplate(),
+		},
+		{
+			name:       "test template with baseline",
+			template:   "baseline.md.tmpl",
+			goldenfile: "output_template_baseline.md",
+			analysis:   fakeAnalysisWithBaseline(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp(t.TempDir(), "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := NewTemplate(path.Join("./testdata/templates/", tt.template), tempFile.Name()).Write(tt.analysis); err != nil {
+				t.Fatal(err)
+			}
+			result, err := os.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := os.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+func TestTemplate_WriteInvalidTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := path.Join(dir, "invalid.tmpl")
+	if err := os.WriteFile(templatePath, []byte("{{ .Unknown }}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := NewTemplate(templatePath, path.Join(dir, "result")).Write(fakeAnalysis())
+	assert.EqualError(t, err, `template: invalid.tmpl:1:3: executing "invalid.tmpl" at <.Unknown>: can't evaluate field Unknown in type *output.TemplateData`)
+}
+
+func TestSplitTemplatePath(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		templatePath string
+		outputPath   string
+		ok           bool
+	}{
+		{
+			name:         "test template and output paths",
+			path:         "report.tmpl:report.md",
+			templatePath: "report.tmpl",
+			outputPath:   "report.md",
+			ok:           true,
+		},
+		{
+			name:         "test output path containing a colon",
+			path:         "/tmp/report.tmpl:/tmp/report:2022.md",
+			templatePath: "/tmp/report.tmpl",
+			outputPath:   "/tmp/report:2022.md",
+			ok:           true,
+		},
+		{
+			name: "test missing output path",
+			path: "report.tmpl",
+		},
+		{
+			name: "test empty template path",
+			path: ":report.md",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templatePath, outputPath, ok := SplitTemplatePath(tt.path)
+			assert.Equal(t, tt.templatePath, templatePath)
+			assert.Equal(t, tt.outputPath, outputPath)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestIsHTMLTemplate(t *testing.T) {
+	assert.True(t, isHTMLTemplate("report.html"))
+	assert.True(t, isHTMLTemplate("report.html.tmpl"))
+	assert.True(t, isHTMLTemplate("report.gohtml"))
+	assert.False(t, isHTMLTemplate("report.md.tmpl"))
+	assert.False(t, isHTMLTemplate("report.tmpl"))
+}
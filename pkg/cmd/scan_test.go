@@ -49,6 +49,14 @@ func TestScanCmd_Valid(t *testing.T) {
 		{args: []string{"scan", "-o", "html://result.html", "-o", "json://result.json"}},
 		{args: []string{"scan", "--tf-lockfile", "../.terraform.lock.hcl"}},
 		{args: []string{"scan", "--only-unmanaged"}},
+		{args: []string{"scan", "--deep"}},
+		{args: []string{"scan", "--baseline", "analysis.json"}},
+		{args: []string{"scan", "-o", "json://result.json", "--json-attributes"}},
+		{args: []string{"scan", "--fail-on", "missing,changed"}},
+		{args: []string{"scan", "--min-coverage", "85"}},
+		{args: []string{"scan", "--policy", "testdata/policy.yml"}},
+		{args: []string{"scan", "--risk-rules", "testdata/risk_rules.yml"}},
+		{args: []string{"scan", "--explain", "aws_iam_role.my.role", "--explain", "aws_s3_bucket.bucket"}},
 	}
 
 	for _, tt := range cases {
@@ -89,8 +97,17 @@ func TestScanCmd_Invalid(t *testing.T) {
 		{args: []string{"scan", "--filter", "Type='test'", "--filter", "Type='test2'"}, expected: "Filter flag should be specified only once"},
 		{args: []string{"scan", "--tf-provider-version", ".30.2"}, expected: "Invalid version argument .30.2, expected a valid semver string (e.g. 2.13.4)"},
 		{args: []string{"scan", "--tf-provider-version", "foo"}, expected: "Invalid version argument foo, expected a valid semver string (e.g. 2.13.4)"},
+		{args: []string{"scan", "--to", "aws+tf,test"}, expected: "unsupported cloud provider 'test'\nValid values are: aws+tf,github+tf,gcp+tf,azure+tf"},
+		{args: []string{"scan", "--to", "aws+tf,github+tf", "--tf-provider-version", "3.41.0"}, expected: "--tf-provider-version must give the version of each cloud provider when scanning several of them (e.g. aws+tf=3.41.0)"},
+		{args: []string{"scan", "--to", "aws+tf,github+tf", "--tf-provider-version", "gcp+tf=3.78.0"}, expected: "cloud provider 'gcp+tf' given to --tf-provider-version is not scanned\nScanned cloud providers are: aws+tf,github+tf"},
+		{args: []string{"scan", "--to", "aws+tf,github+tf", "--tf-provider-version", "github+tf=4.4"}, expected: "Invalid version argument 4.4, expected a valid semver string (e.g. 2.13.4)"},
 		{args: []string{"scan", "--driftignore"}, expected: "flag needs an argument: --driftignore"},
 		{args: []string{"scan", "--tf-lockfile"}, expected: "flag needs an argument: --tf-lockfile"},
+		{args: []string{"scan", "--fail-on", "foo"}, expected: "Unsupported value 'foo' for --fail-on\nAccepted values are: unmanaged,missing,changed,duplicated"},
+		{args: []string{"scan", "--min-coverage", "150"}, expected: "invalid minimum coverage 150 for policy rule min-coverage, expected a percentage"},
+		{args: []string{"scan", "--policy", "testdata/not_found.yml"}, expected: "unable to read policy testdata/not_found.yml: open testdata/not_found.yml: no such file or directory"},
+		{args: []string{"scan", "--risk-rules", "testdata/not_found.yml"}, expected: "unable to read risk rules testdata/not_found.yml: open testdata/not_found.yml: no such file or directory"},
+		{args: []string{"scan", "--explain", "aws_iam_role"}, expected: "invalid resource 'aws_iam_role', expected TYPE.ID (e.g. aws_iam_role.my-role)"},
 	}
 
 	for _, tt := range cases {
@@ -116,28 +133,36 @@ func Test_Options(t *testing.T) {
 			name: "lockfile should be ignored by tf-provider-version flag",
 			args: []string{"scan", "--to", "aws+tf", "--tf-lockfile", "testdata/terraform_valid.lock.hcl", "--tf-provider-version", "3.41.0"},
 			assertOptions: func(t *testing.T, opts *pkg.ScanOptions) {
-				assert.Equal(t, "3.41.0", opts.ProviderVersion)
+				assert.Equal(t, "3.41.0", opts.ProviderVersions["aws+tf"])
 			},
 		},
 		{
 			name: "should get provider version from lockfile",
 			args: []string{"scan", "--to", "aws+tf", "--tf-lockfile", "testdata/terraform_valid.lock.hcl"},
 			assertOptions: func(t *testing.T, opts *pkg.ScanOptions) {
-				assert.Equal(t, "3.47.0", opts.ProviderVersion)
+				assert.Equal(t, "3.47.0", opts.ProviderVersions["aws+tf"])
 			},
 		},
 		{
 			name: "should not find provider version in lockfile",
 			args: []string{"scan", "--to", "gcp+tf", "--tf-lockfile", "testdata/terraform_valid.lock.hcl"},
 			assertOptions: func(t *testing.T, opts *pkg.ScanOptions) {
-				assert.Equal(t, "", opts.ProviderVersion)
+				assert.Equal(t, "", opts.ProviderVersions["gcp+tf"])
 			},
 		},
 		{
 			name: "should fail to read lockfile with silent error",
 			args: []string{"scan", "--to", "gcp+tf", "--tf-lockfile", "testdata/terraform_invalid.lock.hcl"},
 			assertOptions: func(t *testing.T, opts *pkg.ScanOptions) {
-				assert.Equal(t, "", opts.ProviderVersion)
+				assert.Equal(t, "", opts.ProviderVersions["gcp+tf"])
+			},
+		},
+		{
+			name: "should scan several cloud providers",
+			args: []string{"scan", "--to", "aws+tf,github+tf", "--to", "aws+tf", "--tf-lockfile", "testdata/terraform_valid.lock.hcl", "--tf-provider-version", "github+tf=4.4.0"},
+			assertOptions: func(t *testing.T, opts *pkg.ScanOptions) {
+				assert.Equal(t, []string{"aws+tf", "github+tf"}, opts.To)
+				assert.Equal(t, map[string]string{"aws+tf": "3.47.0", "github+tf": "4.4.0"}, opts.ProviderVersions)
 			},
 		},
 	}
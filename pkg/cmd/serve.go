@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/khulnasoft-lab/driftctl/build"
+	"github.com/khulnasoft-lab/driftctl/pkg"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/cmd/scan/output"
+	"github.com/khulnasoft-lab/driftctl/pkg/memstore"
+	"github.com/khulnasoft-lab/driftctl/pkg/serve"
+)
+
+func NewServeCmd(opts *pkg.ServeOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run scheduled scans and serve their results over HTTP",
+		Long: "This command runs the scans of a configuration file at regular intervals, keeps the latest and previous analysis " +
+			"of each target and exposes them over an HTTP API, with a /metrics endpoint for Prometheus\n\n" +
+			"Example configuration:\n\n" +
+			"  listen: :8080\n" +
+			"  data_dir: /var/lib/driftctl\n" +
+			"  targets:\n" +
+			"    - name: production\n" +
+			"      interval: 6h\n" +
+			"      args: [\"--from\", \"tfstate+s3://bucket/prod.tfstate\", \"--to\", \"aws+tf\"]\n\n" +
+			"Target args are the flags of the scan command, file outputs they configure are written after each scan. " +
+			"Flags missing from the args are read from the project configuration file and environment variables, as for a scan",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serveRun(opts)
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringVarP(&opts.ConfigPath, "config", "c", ".driftctl-serve.yml", "Path to the configuration file of the scheduled scans")
+	fl.StringVar(&opts.Listen, "listen", "", "Address of the HTTP API, overrides the listen address of the configuration file")
+
+	return cmd
+}
+
+func serveRun(opts *pkg.ServeOptions) error {
+	config, err := serve.ReadConfig(opts.ConfigPath)
+	if err != nil {
+		return err
+	}
+	listen := config.Listen
+	if opts.Listen != "" {
+		listen = opts.Listen
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	targets := make([]serve.Target, 0, len(config.Targets))
+	for _, targetConfig := range config.Targets {
+		scanOpts, err := parseScanArgs(targetConfig.Args)
+		if err != nil {
+			return errors.Wrapf(err, "invalid args for target '%s'", targetConfig.Name)
+		}
+		interval, _ := targetConfig.ParseInterval()
+		targets = append(targets, serve.Target{
+			Name:     targetConfig.Name,
+			Interval: interval,
+			Scan:     scheduledScan(ctx, targetConfig.Name, scanOpts),
+		})
+	}
+
+	server := serve.NewServer(targets, config.DataDir)
+	httpServer := &http.Server{Addr: listen, Handler: server.Handler()}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.ListenAndServe()
+		cancel()
+	}()
+	logrus.WithField("address", listen).Info("Serving the HTTP API")
+
+	server.Run(ctx)
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelShutdown()
+	_ = httpServer.Shutdown(shutdownCtx)
+	if err := <-errs; err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// parseScanArgs parses the flags of the scan command into scan options. The command is added to a new root command, so
+// that the project configuration file and environment variables set the flags missing from the args as for a scan.
+func parseScanArgs(args []string) (*pkg.ScanOptions, error) {
+	root := NewDriftctlCmd(&build.Build{})
+	if scanCmd, _, err := root.Find([]string{"scan"}); err == nil {
+		root.RemoveCommand(scanCmd)
+	}
+	opts := &pkg.ScanOptions{}
+	cmd := NewScanCmd(opts)
+	root.AddCommand(cmd)
+
+	if err := cmd.ParseFlags(args); err != nil {
+		return nil, err
+	}
+	if len(cmd.Flags().Args()) > 0 {
+		return nil, errors.Errorf("unexpected arguments: %s", strings.Join(cmd.Flags().Args(), " "))
+	}
+	if err := root.PersistentPreRunE(cmd, nil); err != nil {
+		return nil, err
+	}
+	if err := cmd.PreRunE(cmd, nil); err != nil {
+		return nil, err
+	}
+	opts.Quiet = true
+	return opts, nil
+}
+
+// scheduledScan returns a scan of the given options, interrupted when the context is done.
+// Its file outputs are written after each scan, console outputs are left out as the server runs unattended.
+func scheduledScan(ctx context.Context, name string, opts *pkg.ScanOptions) serve.ScanFunc {
+	return func() (*analyser.Analysis, error) {
+		interrupt := make(chan os.Signal, 1)
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				interrupt <- os.Interrupt
+			case <-done:
+			}
+		}()
+
+		analysis, err := analyze(opts, memstore.New(), interrupt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range opts.Output {
+			if o.Key == output.ConsoleOutputType {
+				continue
+			}
+			if err := output.GetOutput(o, opts.BackendOptions).Write(analysis); err != nil {
+				logrus.WithFields(logrus.Fields{"target": name, "output": o.String(), "error": err}).Error("Error writing to output")
+			}
+		}
+		return analysis, nil
+	}
+}
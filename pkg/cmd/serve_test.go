@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/config"
+)
+
+func TestParseScanArgs_Project(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".driftctl.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("scan:\n  to: gcp+tf\n  driftignore: project.driftignore\n"), 0600))
+
+	config.Init()
+	_ = os.Setenv("DCTL_DEEP", "true")
+	defer os.Unsetenv("DCTL_DEEP")
+
+	cases := []struct {
+		name       string
+		args       []string
+		to         []string
+		deep       bool
+		ignorePath string
+	}{
+		{
+			name:       "project and env fill the flags missing from the args",
+			args:       []string{"--config-file", path, "--from", "tfstate://terraform.tfstate"},
+			to:         []string{"gcp+tf"},
+			deep:       true,
+			ignorePath: "project.driftignore",
+		},
+		{
+			name:       "args override the project",
+			args:       []string{"--config-file", path, "--from", "tfstate://terraform.tfstate", "--to", "aws+tf", "--driftignore", "target.driftignore"},
+			to:         []string{"aws+tf"},
+			deep:       true,
+			ignorePath: "target.driftignore",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			opts, err := parseScanArgs(c.args)
+			require.NoError(t, err)
+			assert.Equal(t, c.to, opts.To)
+			assert.Equal(t, c.deep, opts.Deep)
+			assert.Equal(t, c.ignorePath, opts.DriftignorePath)
+			assert.True(t, opts.Quiet)
+		})
+	}
+}
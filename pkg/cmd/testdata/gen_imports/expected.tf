@@ -0,0 +1,22 @@
+# Generated by driftctl gen-imports
+
+# aws_iam_policy_attachment
+# aws_iam_policy_attachment.attachment: aws_iam_policy_attachment does not support import
+
+# aws_iam_role
+import {
+  to = aws_iam_role.my-role
+  id = "my-role"
+}
+
+# aws_iam_role_policy_attachment
+import {
+  to = aws_iam_role_policy_attachment.AmazonS3ReadOnlyAccess-my-role
+  id = "my-role/arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"
+}
+
+# aws_s3_bucket
+import {
+  to = aws_s3_bucket.my_bucket
+  id = "my.bucket"
+}
@@ -0,0 +1,11 @@
+#!/bin/sh
+# Generated by driftctl gen-imports
+set -e
+
+# root module
+terraform import 'aws_s3_bucket.my_bucket' 'my.bucket'
+
+# module.iam
+# module.iam.aws_iam_policy_attachment.attachment: aws_iam_policy_attachment does not support import
+terraform import 'module.iam.aws_iam_role.my-role' 'my-role'
+terraform import 'module.iam.aws_iam_role_policy_attachment.AmazonS3ReadOnlyAccess-my-role' 'my-role/arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess'
@@ -0,0 +1,212 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+type importIdFunc func(res *resource.Resource) (string, error)
+
+// importIdFuncs holds the resource types whose Terraform import id differs from the driftctl resource id,
+// every other type is imported using its resource id
+var importIdFuncs = map[string]importIdFunc{
+	"aws_api_gateway_authorizer":           restApiChildImportId("rest_api_id"),
+	"aws_api_gateway_gateway_response":     prefixedImportId("aggr", 2),
+	"aws_api_gateway_integration":          prefixedImportId("agi", 3),
+	"aws_api_gateway_integration_response": prefixedImportId("agir", 4),
+	"aws_api_gateway_method":               prefixedImportId("agm", 3),
+	"aws_api_gateway_method_response":      prefixedImportId("agmr", 4),
+	"aws_api_gateway_model":                restApiChildImportId("rest_api_id"),
+	"aws_api_gateway_request_validator":    restApiChildImportId("rest_api_id"),
+	"aws_api_gateway_resource":             restApiChildImportId("rest_api_id"),
+	"aws_api_gateway_stage":                prefixedImportId("ags", 2),
+	"aws_apigatewayv2_integration":         restApiChildImportId("api_id"),
+	"aws_apigatewayv2_route":               restApiChildImportId("api_id"),
+	"aws_iam_group_policy_attachment":      attributesImportId("/", "group", "policy_arn"),
+	"aws_iam_policy_attachment":            notImportable,
+	"aws_iam_role_policy_attachment":       attributesImportId("/", "role", "policy_arn"),
+	"aws_iam_user_policy_attachment":       attributesImportId("/", "user", "policy_arn"),
+	"aws_network_acl_rule":                 attributesImportId(":", "network_acl_id", "rule_number", "protocol", "egress"),
+	"aws_route":                            routeImportId,
+	"aws_route_table_association":          routeTableAssociationImportId,
+	"aws_security_group_rule":              securityGroupRuleImportId,
+	"google_project_iam_binding":           attributesImportId(" ", "project", "role"),
+	"google_project_iam_member":            attributesImportId(" ", "project", "role", "member"),
+	"google_project_iam_policy":            attributesImportId(" ", "project"),
+	"google_storage_bucket_iam_binding":    attributesImportId(" ", "bucket", "role"),
+	"google_storage_bucket_iam_member":     attributesImportId(" ", "bucket", "role", "member"),
+	"google_storage_bucket_iam_policy":     attributesImportId(" ", "bucket"),
+}
+
+// ImportId returns the id Terraform expects to import the given resource
+func ImportId(res *resource.Resource) (string, error) {
+	if fn, exist := importIdFuncs[res.ResourceType()]; exist {
+		return fn(res)
+	}
+	if strings.HasPrefix(res.ResourceType(), "azurerm_") {
+		return azureImportId(res)
+	}
+	return res.ResourceId(), nil
+}
+
+func notImportable(res *resource.Resource) (string, error) {
+	return "", errors.Errorf("%s does not support import", res.ResourceType())
+}
+
+// attributesImportId joins the value of the given attributes
+func attributesImportId(separator string, names ...string) importIdFunc {
+	return func(res *resource.Resource) (string, error) {
+		values := make([]string, 0, len(names))
+		for _, name := range names {
+			value, err := attributeString(res, name)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, value)
+		}
+		return strings.Join(values, separator), nil
+	}
+}
+
+// prefixedImportId converts ids like agm-<api>-<resource>-<method> to <api>/<resource>/<method>
+func prefixedImportId(prefix string, parts int) importIdFunc {
+	return func(res *resource.Resource) (string, error) {
+		id := strings.TrimPrefix(res.ResourceId(), prefix+"-")
+		values := strings.SplitN(id, "-", parts)
+		if id == res.ResourceId() || len(values) != parts {
+			return "", errors.Errorf("unexpected id format %s", res.ResourceId())
+		}
+		return strings.Join(values, "/"), nil
+	}
+}
+
+// restApiChildImportId prefixes the resource id with the id of its API
+func restApiChildImportId(apiAttribute string) importIdFunc {
+	return func(res *resource.Resource) (string, error) {
+		api, err := attributeString(res, apiAttribute)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s/%s", api, res.ResourceId()), nil
+	}
+}
+
+func routeImportId(res *resource.Resource) (string, error) {
+	table, err := attributeString(res, "route_table_id")
+	if err != nil {
+		return "", err
+	}
+	destination, err := attributeString(res, "destination_cidr_block", "destination_ipv6_cidr_block", "destination_prefix_list_id")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s_%s", table, destination), nil
+}
+
+func routeTableAssociationImportId(res *resource.Resource) (string, error) {
+	associated, err := attributeString(res, "subnet_id", "gateway_id")
+	if err != nil {
+		return "", err
+	}
+	table, err := attributeString(res, "route_table_id")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", associated, table), nil
+}
+
+// securityGroupRuleImportId builds ids like sg-123_ingress_tcp_80_80_10.0.0.0/16_10.1.0.0/16
+func securityGroupRuleImportId(res *resource.Resource) (string, error) {
+	values := make([]string, 0)
+	for _, name := range []string{"security_group_id", "type", "protocol", "from_port", "to_port"} {
+		value, err := attributeString(res, name)
+		if err != nil {
+			return "", err
+		}
+		if name == "protocol" && value == "-1" {
+			value = "all"
+		}
+		values = append(values, value)
+	}
+
+	sources := 0
+	for _, name := range []string{"cidr_blocks", "ipv6_cidr_blocks", "prefix_list_ids"} {
+		for _, value := range attributeStrings(res, name) {
+			values = append(values, value)
+			sources++
+		}
+	}
+	if value, err := attributeString(res, "source_security_group_id"); err == nil {
+		values = append(values, value)
+		sources++
+	}
+	if self, exist := res.Attributes().Get("self"); exist && self == true {
+		values = append(values, "self")
+		sources++
+	}
+	if sources == 0 {
+		return "", errors.New("missing rule source attribute")
+	}
+
+	return strings.Join(values, "_"), nil
+}
+
+// azureImportId fixes the casing of resource group segments, Azure APIs may return them lower-cased
+// but Terraform only imports ids using the canonical casing
+func azureImportId(res *resource.Resource) (string, error) {
+	segments := strings.Split(res.ResourceId(), "/")
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "resourceGroups") {
+			segments[i] = "resourceGroups"
+		}
+		if strings.EqualFold(segment, "subscriptions") {
+			segments[i] = "subscriptions"
+		}
+		if strings.EqualFold(segment, "providers") {
+			segments[i] = "providers"
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// attributeString returns the value of the first non empty attribute among the given names
+func attributeString(res *resource.Resource, names ...string) (string, error) {
+	if res.Attributes() != nil {
+		for _, name := range names {
+			value, exist := res.Attributes().Get(name)
+			if !exist || value == nil {
+				continue
+			}
+			if str := fmt.Sprint(value); str != "" {
+				return str, nil
+			}
+		}
+	}
+	return "", errors.Errorf("missing %s attribute", strings.Join(names, " or "))
+}
+
+func attributeStrings(res *resource.Resource, name string) []string {
+	if res.Attributes() == nil {
+		return nil
+	}
+	value, exist := res.Attributes().Get(name)
+	if !exist {
+		return nil
+	}
+	values := make([]string, 0)
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if item != nil && fmt.Sprint(item) != "" {
+				values = append(values, fmt.Sprint(item))
+			}
+		}
+	case []string:
+		values = append(values, v...)
+	}
+	return values
+}
@@ -0,0 +1,133 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+func TestImportId(t *testing.T) {
+	tests := []struct {
+		name     string
+		res      *resource.Resource
+		expected string
+		err      string
+	}{
+		{
+			name:     "resource imported by id",
+			res:      &resource.Resource{Id: "my-bucket", Type: "aws_s3_bucket"},
+			expected: "my-bucket",
+		},
+		{
+			name: "role policy attachment",
+			res: &resource.Resource{Id: "policy-role", Type: "aws_iam_role_policy_attachment", Attrs: &resource.Attributes{
+				"role":       "role",
+				"policy_arn": "arn:aws:iam::aws:policy/policy",
+			}},
+			expected: "role/arn:aws:iam::aws:policy/policy",
+		},
+		{
+			name: "role policy attachment without attributes",
+			res:  &resource.Resource{Id: "policy-role", Type: "aws_iam_role_policy_attachment"},
+			err:  "missing role attribute",
+		},
+		{
+			name: "route",
+			res: &resource.Resource{Id: "r-rtb-1080289494", Type: "aws_route", Attrs: &resource.Attributes{
+				"route_table_id":              "rtb-1",
+				"destination_ipv6_cidr_block": "::/0",
+			}},
+			expected: "rtb-1_::/0",
+		},
+		{
+			name: "route table association",
+			res: &resource.Resource{Id: "rtbassoc-1", Type: "aws_route_table_association", Attrs: &resource.Attributes{
+				"route_table_id": "rtb-1",
+			}},
+			err: "missing subnet_id or gateway_id attribute",
+		},
+		{
+			name: "network acl rule",
+			res: &resource.Resource{Id: "nacl-1", Type: "aws_network_acl_rule", Attrs: &resource.Attributes{
+				"network_acl_id": "acl-1",
+				"rule_number":    float64(100),
+				"protocol":       "6",
+				"egress":         false,
+			}},
+			expected: "acl-1:100:6:false",
+		},
+		{
+			name: "security group rule",
+			res: &resource.Resource{Id: "sgrule-1", Type: "aws_security_group_rule", Attrs: &resource.Attributes{
+				"security_group_id":        "sg-1",
+				"type":                     "ingress",
+				"protocol":                 "-1",
+				"from_port":                0,
+				"to_port":                  0,
+				"cidr_blocks":              []interface{}{"10.0.0.0/16", "10.1.0.0/16"},
+				"source_security_group_id": "",
+				"self":                     false,
+			}},
+			expected: "sg-1_ingress_all_0_0_10.0.0.0/16_10.1.0.0/16",
+		},
+		{
+			name: "self referencing security group rule",
+			res: &resource.Resource{Id: "sgrule-2", Type: "aws_security_group_rule", Attrs: &resource.Attributes{
+				"security_group_id": "sg-1",
+				"type":              "egress",
+				"protocol":          "tcp",
+				"from_port":         443,
+				"to_port":           443,
+				"self":              true,
+			}},
+			expected: "sg-1_egress_tcp_443_443_self",
+		},
+		{
+			name:     "api gateway method",
+			res:      &resource.Resource{Id: "agm-api-resource-GET", Type: "aws_api_gateway_method"},
+			expected: "api/resource/GET",
+		},
+		{
+			name:     "api gateway stage with dashes",
+			res:      &resource.Resource{Id: "ags-api-my-stage", Type: "aws_api_gateway_stage"},
+			expected: "api/my-stage",
+		},
+		{
+			name: "api gateway method with unexpected id",
+			res:  &resource.Resource{Id: "method", Type: "aws_api_gateway_method"},
+			err:  "unexpected id format method",
+		},
+		{
+			name: "google project iam member",
+			res: &resource.Resource{Id: "project/roles/viewer/user:foo@example.com", Type: "google_project_iam_member", Attrs: &resource.Attributes{
+				"project": "project",
+				"role":    "roles/viewer",
+				"member":  "user:foo@example.com",
+			}},
+			expected: "project roles/viewer user:foo@example.com",
+		},
+		{
+			name:     "azure resource with lower cased segments",
+			res:      &resource.Resource{Id: "/subscriptions/sub/resourcegroups/rg/providers/Microsoft.Network/virtualNetworks/net", Type: "azurerm_virtual_network"},
+			expected: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/net",
+		},
+		{
+			name: "not importable resource",
+			res:  &resource.Resource{Id: "attachment", Type: "aws_iam_policy_attachment"},
+			err:  "aws_iam_policy_attachment does not support import",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := ImportId(tt.res)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
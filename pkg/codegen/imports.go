@@ -0,0 +1,215 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+const (
+	FormatHCL    = "hcl"
+	FormatScript = "script"
+
+	GroupByType   = "type"
+	GroupByModule = "module"
+)
+
+var Formats = []string{FormatHCL, FormatScript}
+var GroupByValues = []string{GroupByType, GroupByModule}
+
+var moduleAddressRegexp = regexp.MustCompile(`^module\.[A-Za-z_][A-Za-z0-9_-]*(\.module\.[A-Za-z_][A-Za-z0-9_-]*)*$`)
+var invalidNameCharsRegexp = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// ModuleMapping targets the resources whose type matches a glob pattern to a module
+type ModuleMapping struct {
+	Pattern string
+	Module  string
+}
+
+// ParseModuleMapping parses mappings written as PATTERN=MODULE, e.g. aws_iam_*=module.iam
+func ParseModuleMapping(value string) (ModuleMapping, error) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return ModuleMapping{}, errors.Errorf("invalid module mapping '%s', expected PATTERN=MODULE (e.g. aws_iam_*=module.iam)", value)
+	}
+	if _, err := path.Match(parts[0], ""); err != nil {
+		return ModuleMapping{}, errors.Errorf("invalid resource type pattern '%s' in module mapping", parts[0])
+	}
+	if !moduleAddressRegexp.MatchString(parts[1]) {
+		return ModuleMapping{}, errors.Errorf("invalid module address '%s' in module mapping, expected module.NAME", parts[1])
+	}
+	return ModuleMapping{Pattern: parts[0], Module: parts[1]}, nil
+}
+
+// Import is the import of a resource to its Terraform address, Err is set when the import id can't be computed
+type Import struct {
+	Res    *resource.Resource
+	Module string
+	Name   string
+	Id     string
+	Err    error
+}
+
+func (i Import) Address() string {
+	address := fmt.Sprintf("%s.%s", i.Res.ResourceType(), i.Name)
+	if i.Module != "" {
+		address = fmt.Sprintf("%s.%s", i.Module, address)
+	}
+	return address
+}
+
+type Group struct {
+	Name    string
+	Imports []Import
+}
+
+// Imports computes the import of every given resource, grouped by resource type or by target module.
+// Resources are targeted to the first matching module mapping, or to the root module.
+func Imports(resources []*resource.Resource, modules []ModuleMapping, groupBy string) []Group {
+	names := NewNamer()
+	groups := make([]Group, 0)
+	index := make(map[string]int)
+	for _, res := range sortResources(resources) {
+		imp := Import{
+			Res:    res,
+			Module: targetModule(res.ResourceType(), modules),
+		}
+		imp.Name = names.Name(imp.Module, res)
+		imp.Id, imp.Err = ImportId(res)
+
+		key := res.ResourceType()
+		if groupBy == GroupByModule {
+			key = imp.Module
+		}
+		i, exist := index[key]
+		if !exist {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, Group{Name: key})
+		}
+		groups[i].Imports = append(groups[i].Imports, imp)
+	}
+
+	// Resources are sorted by type so type groups already are, the root module comes first
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// sortResources sorts resources by type then id, so that generated names are stable across commands
+func sortResources(resources []*resource.Resource) []*resource.Resource {
+	sorted := make([]*resource.Resource, len(resources))
+	copy(sorted, resources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ResourceType() != sorted[j].ResourceType() {
+			return sorted[i].ResourceType() < sorted[j].ResourceType()
+		}
+		return sorted[i].ResourceId() < sorted[j].ResourceId()
+	})
+	return sorted
+}
+
+func targetModule(ty string, modules []ModuleMapping) string {
+	for _, mapping := range modules {
+		if match, _ := path.Match(mapping.Pattern, ty); match {
+			return mapping.Module
+		}
+	}
+	return ""
+}
+
+// Namer gives resources a unique Terraform name in their module, derived from their id
+type Namer struct {
+	used map[string]struct{}
+}
+
+func NewNamer() *Namer {
+	return &Namer{used: make(map[string]struct{})}
+}
+
+func (n *Namer) Name(module string, res *resource.Resource) string {
+	name := strings.Trim(invalidNameCharsRegexp.ReplaceAllString(res.ResourceId(), "_"), "_")
+	if name == "" || !isNameStart(name[0]) {
+		name = "r_" + name
+	}
+
+	candidate := name
+	for i := 2; ; i++ {
+		key := fmt.Sprintf("%s.%s.%s", module, res.ResourceType(), candidate)
+		if _, exist := n.used[key]; !exist {
+			n.used[key] = struct{}{}
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// WriteImportBlocks writes Terraform 1.5+ import blocks
+func WriteImportBlocks(w io.Writer, groups []Group) error {
+	var b strings.Builder
+	b.WriteString("# Generated by driftctl gen-imports\n")
+	for _, group := range groups {
+		fmt.Fprintf(&b, "\n# %s\n", groupTitle(group))
+		for _, imp := range group.Imports {
+			if imp.Err != nil {
+				fmt.Fprintf(&b, "# %s: %s\n", imp.Address(), imp.Err)
+				continue
+			}
+			fmt.Fprintf(&b, "import {\n  to = %s\n  id = %s\n}\n", imp.Address(), hclString(imp.Id))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteImportScript writes a shell script running terraform import for each resource, for Terraform versions
+// without import blocks
+func WriteImportScript(w io.Writer, groups []Group) error {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n# Generated by driftctl gen-imports\nset -e\n")
+	for _, group := range groups {
+		fmt.Fprintf(&b, "\n# %s\n", groupTitle(group))
+		for _, imp := range group.Imports {
+			if imp.Err != nil {
+				fmt.Fprintf(&b, "# %s: %s\n", imp.Address(), imp.Err)
+				continue
+			}
+			fmt.Fprintf(&b, "terraform import %s %s\n", shellString(imp.Address()), shellString(imp.Id))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func groupTitle(group Group) string {
+	if group.Name == "" {
+		return "root module"
+	}
+	return group.Name
+}
+
+func hclString(value string) string {
+	return fmt.Sprintf("\"%s\"", strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"${", "$${",
+		"%{", "%%{",
+	).Replace(value))
+}
+
+func shellString(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", `'\''`))
+}
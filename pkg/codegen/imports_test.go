@@ -0,0 +1,87 @@
+package codegen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+func TestNamer_Name(t *testing.T) {
+	namer := NewNamer()
+	assert.Equal(t, "my_bucket", namer.Name("", &resource.Resource{Id: "my.bucket", Type: "aws_s3_bucket"}))
+	assert.Equal(t, "my_bucket_2", namer.Name("", &resource.Resource{Id: "my/bucket", Type: "aws_s3_bucket"}))
+	assert.Equal(t, "my_bucket", namer.Name("module.s3", &resource.Resource{Id: "my.bucket", Type: "aws_s3_bucket"}))
+	assert.Equal(t, "my_bucket", namer.Name("", &resource.Resource{Id: "my.bucket", Type: "aws_s3_bucket_policy"}))
+	assert.Equal(t, "r_10_0_0_0_16", namer.Name("", &resource.Resource{Id: "10.0.0.0/16", Type: "aws_vpc"}))
+	assert.Equal(t, "arn_aws_iam_aws_policy_ReadOnly", namer.Name("", &resource.Resource{Id: "arn:aws:iam::aws:policy/ReadOnly", Type: "aws_iam_policy"}))
+}
+
+func TestParseModuleMapping(t *testing.T) {
+	mapping, err := ParseModuleMapping("aws_iam_*=module.iam.module.roles")
+	assert.NoError(t, err)
+	assert.Equal(t, ModuleMapping{Pattern: "aws_iam_*", Module: "module.iam.module.roles"}, mapping)
+
+	_, err = ParseModuleMapping("[=module.iam")
+	assert.EqualError(t, err, "invalid resource type pattern '[' in module mapping")
+}
+
+func TestImports(t *testing.T) {
+	resources := []*resource.Resource{
+		{Id: "bucket", Type: "aws_s3_bucket"},
+		{Id: "role-2", Type: "aws_iam_role"},
+		{Id: "role-1", Type: "aws_iam_role"},
+		{Id: "user", Type: "aws_iam_user"},
+	}
+	modules := []ModuleMapping{
+		{Pattern: "aws_iam_role", Module: "module.roles"},
+		{Pattern: "aws_iam_*", Module: "module.iam"},
+	}
+
+	groups := Imports(resources, modules, GroupByModule)
+	assert.Len(t, groups, 3)
+	assert.Equal(t, "", groups[0].Name)
+	assert.Equal(t, "aws_s3_bucket.bucket", groups[0].Imports[0].Address())
+	assert.Equal(t, "module.iam", groups[1].Name)
+	assert.Equal(t, "module.iam.aws_iam_user.user", groups[1].Imports[0].Address())
+	assert.Equal(t, "module.roles", groups[2].Name)
+	assert.Equal(t, "module.roles.aws_iam_role.role-1", groups[2].Imports[0].Address())
+	assert.Equal(t, "module.roles.aws_iam_role.role-2", groups[2].Imports[1].Address())
+
+	groups = Imports(resources, nil, GroupByType)
+	assert.Len(t, groups, 3)
+	assert.Equal(t, "aws_iam_role", groups[0].Name)
+	assert.Len(t, groups[0].Imports, 2)
+	assert.Equal(t, "aws_iam_user", groups[1].Name)
+	assert.Equal(t, "aws_s3_bucket", groups[2].Name)
+}
+
+func TestWriteImportBlocks_Escaping(t *testing.T) {
+	groups := []Group{{Name: "aws_ssm_parameter", Imports: []Import{
+		{Res: &resource.Resource{Id: `${param}`, Type: "aws_ssm_parameter"}, Name: "param", Id: `"${param}"`},
+	}}}
+
+	var out bytes.Buffer
+	assert.NoError(t, WriteImportBlocks(&out, groups))
+	assert.Equal(t, `# Generated by driftctl gen-imports
+
+# aws_ssm_parameter
+import {
+  to = aws_ssm_parameter.param
+  id = "\"$${param}\""
+}
+`, out.String())
+
+	out.Reset()
+	groups[0].Imports[0].Id = "it's"
+	assert.NoError(t, WriteImportScript(&out, groups))
+	assert.Equal(t, `#!/bin/sh
+# Generated by driftctl gen-imports
+set -e
+
+# aws_ssm_parameter
+terraform import 'aws_ssm_parameter.param' 'it'\''s'
+`, out.String())
+}
@@ -0,0 +1,400 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+// knownDefaults holds optional attributes whose provider default is not the zero value of their type
+var knownDefaults = map[string]map[string]interface{}{
+	"aws_iam_policy": {"path": "/"},
+	"aws_iam_role":   {"path": "/", "max_session_duration": 3600},
+	"aws_iam_user":   {"path": "/"},
+	"aws_lambda_function": {
+		"memory_size":  128,
+		"package_type": "Zip",
+		"timeout":      3,
+	},
+	"aws_s3_bucket": {"acl": "private"},
+	"aws_sqs_queue": {
+		"max_message_size":           262144,
+		"message_retention_seconds":  345600,
+		"visibility_timeout_seconds": 30,
+	},
+}
+
+// ignoredAttributes are never written in resource blocks
+var ignoredAttributes = []string{"id", "timeouts"}
+
+type reference struct {
+	ty   string
+	expr string
+}
+
+// ResourceGenerator writes Terraform resource blocks from the attributes and the schema of scanned resources
+type ResourceGenerator struct {
+	names      map[*resource.Resource]string
+	references map[string][]reference
+}
+
+// NewResourceGenerator creates a generator for the given resources. Values matching the id, or the ARN, of another
+// generated resource or of a resource managed in the root module are replaced by a reference to it.
+// Resources are named the same way gen-imports names them, so both outputs can be used together.
+func NewResourceGenerator(resources, managed []*resource.Resource) *ResourceGenerator {
+	g := &ResourceGenerator{
+		names:      make(map[*resource.Resource]string),
+		references: make(map[string][]reference),
+	}
+
+	names := NewNamer()
+	for _, res := range sortResources(resources) {
+		g.names[res] = names.Name("", res)
+		g.addReferences(res, fmt.Sprintf("%s.%s", res.ResourceType(), g.names[res]))
+	}
+	for _, res := range managed {
+		if res.Src() == nil || res.Src().Namespace() != "" || res.Src().InternalName() == "" {
+			continue
+		}
+		g.addReferences(res, fmt.Sprintf("%s.%s", res.ResourceType(), res.Src().InternalName()))
+	}
+
+	return g
+}
+
+func (g *ResourceGenerator) addReferences(res *resource.Resource, address string) {
+	g.references[res.ResourceId()] = append(g.references[res.ResourceId()], reference{res.ResourceType(), address + ".id"})
+	if res.Attributes() == nil {
+		return
+	}
+	if arn := res.Attributes().GetString("arn"); arn != nil && *arn != "" && *arn != res.ResourceId() {
+		g.references[*arn] = append(g.references[*arn], reference{res.ResourceType(), address + ".arn"})
+	}
+}
+
+// reference returns the expression referencing the resource matching the value of the given attribute, if any.
+// When several resources match, e.g. a bucket and its policy, the one whose type prefixes the others wins.
+func (g *ResourceGenerator) reference(value, self, attribute string) string {
+	candidates := make([]reference, 0)
+	for _, ref := range g.references[value] {
+		if !strings.HasPrefix(ref.expr, self+".") && isReferenceAttribute(attribute, ref.ty) {
+			candidates = append(candidates, ref)
+		}
+	}
+	for _, candidate := range candidates {
+		parent := true
+		for _, other := range candidates {
+			if !strings.HasPrefix(other.ty, candidate.ty) {
+				parent = false
+				break
+			}
+		}
+		if parent {
+			return candidate.expr
+		}
+	}
+	return ""
+}
+
+// isReferenceAttribute returns true when the name of an attribute designates the given type,
+// e.g. vpc_id references aws_vpc, policy_arn references aws_iam_policy and security_groups aws_security_group
+func isReferenceAttribute(attribute, ty string) bool {
+	stem := attribute
+	for _, suffix := range []string{"_ids", "_id", "_arns", "_arn", "_names", "_name"} {
+		if strings.HasSuffix(attribute, suffix) {
+			stem = strings.TrimSuffix(attribute, suffix)
+			break
+		}
+	}
+	if stem == attribute {
+		stem = strings.TrimSuffix(attribute, "s")
+	}
+
+	name := ty
+	if i := strings.Index(ty, "_"); i >= 0 {
+		name = ty[i+1:]
+	}
+	return stem != "" && (stem == name || strings.HasSuffix(name, "_"+stem) || strings.HasSuffix(stem, "_"+name))
+}
+
+// Write writes a resource block for every resource of the generator, sorted by type and id
+func (g *ResourceGenerator) Write(w io.Writer) error {
+	resources := make([]*resource.Resource, 0, len(g.names))
+	for res := range g.names {
+		resources = append(resources, res)
+	}
+
+	var b strings.Builder
+	b.WriteString("# Generated by driftctl\n")
+	for _, res := range sortResources(resources) {
+		address := fmt.Sprintf("%s.%s", res.ResourceType(), g.names[res])
+		b.WriteString("\n")
+		if res.Schema() == nil {
+			fmt.Fprintf(&b, "# %s: no schema available for %s, unable to generate its attributes\n", address, res.ResourceType())
+			continue
+		}
+		fmt.Fprintf(&b, "resource %s %s {\n", hclString(res.ResourceType()), hclString(g.names[res]))
+		attributes := map[string]interface{}{}
+		if res.Attributes() != nil {
+			attributes = *res.Attributes()
+		}
+		g.writeBody(&b, 1, res, address, "", attributes)
+		b.WriteString("}\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type hclAttribute struct {
+	key   string
+	value string
+}
+
+type hclBlock struct {
+	name   string
+	values map[string]interface{}
+}
+
+// writeBody writes the attributes then the nested blocks of a resource, prefix is the schema path of the body
+func (g *ResourceGenerator) writeBody(b *strings.Builder, indent int, res *resource.Resource, address, prefix string, values map[string]interface{}) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes := make([]hclAttribute, 0)
+	blocks := make([]hclBlock, 0)
+	for _, key := range keys {
+		value := values[key]
+		path := prefix + key
+		if prefix == "" && contains(ignoredAttributes, key) {
+			continue
+		}
+
+		meta, isAttribute := res.Schema().Attributes[path]
+		if !isAttribute {
+			if isBlock(res.Schema(), path) {
+				for _, element := range blockElements(value) {
+					blocks = append(blocks, hclBlock{key, element})
+				}
+			}
+			continue
+		}
+
+		if meta.ConfigSchema.Computed && !meta.ConfigSchema.Optional {
+			continue
+		}
+		if !meta.ConfigSchema.Required && isDefault(res.ResourceType(), path, value) {
+			continue
+		}
+
+		rendered := ""
+		if str, ok := value.(string); ok && meta.JsonString {
+			var document interface{}
+			if err := json.Unmarshal([]byte(str), &document); err == nil {
+				rendered = fmt.Sprintf("jsonencode(%s)", g.value(document, indent, "", ""))
+			}
+		}
+		if rendered == "" {
+			rendered = g.value(value, indent, address, key)
+		}
+		attributes = append(attributes, hclAttribute{key, rendered})
+	}
+
+	writeAttributes(b, indent, attributes)
+	for _, block := range blocks {
+		var body strings.Builder
+		g.writeBody(&body, indent+1, res, address, prefix+block.name+".", block.values)
+		if body.Len() == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "%s%s {\n%s%s}\n", indentation(indent), block.name, body.String(), indentation(indent))
+	}
+}
+
+// value renders the value of an attribute as an HCL expression, strings are replaced by references unless address is empty
+func (g *ResourceGenerator) value(value interface{}, indent int, address, attribute string) string {
+	if m, ok := asMap(value); ok {
+		if len(m) == 0 {
+			return "{}"
+		}
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		attributes := make([]hclAttribute, 0, len(keys))
+		for _, key := range keys {
+			attributes = append(attributes, hclAttribute{hclString(key), g.value(m[key], indent+1, address, attribute)})
+		}
+		var b strings.Builder
+		b.WriteString("{\n")
+		writeAttributes(&b, indent+1, attributes)
+		b.WriteString(indentation(indent) + "}")
+		return b.String()
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		if address != "" {
+			if ref := g.reference(v, address, attribute); ref != "" {
+				return ref
+			}
+		}
+		return hclString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		items := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			items = append(items, item)
+		}
+		return g.value(items, indent, address, attribute)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		multiline := false
+		for _, item := range v {
+			rendered := g.value(item, indent+1, address, attribute)
+			if strings.Contains(rendered, "\n") {
+				multiline = true
+			}
+			items = append(items, rendered)
+		}
+		if !multiline {
+			return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+		}
+		var b strings.Builder
+		b.WriteString("[\n")
+		for _, item := range items {
+			fmt.Fprintf(&b, "%s%s,\n", indentation(indent+1), item)
+		}
+		b.WriteString(indentation(indent) + "]")
+		return b.String()
+	}
+	return fmt.Sprint(value)
+}
+
+// writeAttributes aligns the equal signs of consecutive single line attributes, as terraform fmt does
+func writeAttributes(b *strings.Builder, indent int, attributes []hclAttribute) {
+	start := 0
+	for start < len(attributes) {
+		end := start
+		width := 0
+		for end < len(attributes) && !strings.Contains(attributes[end].value, "\n") {
+			if len(attributes[end].key) > width {
+				width = len(attributes[end].key)
+			}
+			end++
+		}
+		for _, attribute := range attributes[start:end] {
+			fmt.Fprintf(b, "%s%-*s = %s\n", indentation(indent), width, attribute.key, attribute.value)
+		}
+		if end < len(attributes) {
+			fmt.Fprintf(b, "%s%s = %s\n", indentation(indent), attributes[end].key, attributes[end].value)
+			end++
+		}
+		start = end
+	}
+}
+
+func indentation(indent int) string {
+	return strings.Repeat("  ", indent)
+}
+
+// isBlock returns true when the schema holds attributes nested under the given path
+func isBlock(schema *resource.Schema, path string) bool {
+	for key := range schema.Attributes {
+		if strings.HasPrefix(key, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func blockElements(value interface{}) []map[string]interface{} {
+	if m, ok := asMap(value); ok {
+		return []map[string]interface{}{m}
+	}
+	elements := make([]map[string]interface{}, 0)
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			if m, ok := asMap(item); ok {
+				elements = append(elements, m)
+			}
+		}
+	}
+	return elements
+}
+
+func asMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case resource.Attributes:
+		return v, true
+	case *resource.Attributes:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return nil, false
+}
+
+// isDefault returns true for values Terraform would set anyway when the attribute is omitted
+func isDefault(ty, path string, value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		if v == "" {
+			return true
+		}
+	case bool:
+		if !v {
+			return true
+		}
+	case float64:
+		if v == 0 {
+			return true
+		}
+	case int:
+		if v == 0 {
+			return true
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return true
+		}
+	case []string:
+		if len(v) == 0 {
+			return true
+		}
+	}
+	if m, ok := asMap(value); ok && len(m) == 0 {
+		return true
+	}
+
+	if def, exist := knownDefaults[ty][path]; exist {
+		return fmt.Sprint(def) == fmt.Sprint(value)
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
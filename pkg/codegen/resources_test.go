@@ -0,0 +1,199 @@
+package codegen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+)
+
+func TestResourceGenerator_Write(t *testing.T) {
+	required := resource.AttributeSchema{ConfigSchema: configschema.Attribute{Required: true}}
+	optional := resource.AttributeSchema{ConfigSchema: configschema.Attribute{Optional: true}}
+	computed := resource.AttributeSchema{ConfigSchema: configschema.Attribute{Computed: true}}
+	optionalComputed := resource.AttributeSchema{ConfigSchema: configschema.Attribute{Optional: true, Computed: true}}
+	jsonString := resource.AttributeSchema{ConfigSchema: configschema.Attribute{Required: true}, JsonString: true}
+
+	unmanaged := []*resource.Resource{
+		{
+			Id:   "vpc-1",
+			Type: "aws_vpc",
+			Attrs: &resource.Attributes{
+				"id":                 "vpc-1",
+				"arn":                "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1",
+				"cidr_block":         "10.0.0.0/16",
+				"enable_dns_support": true,
+				"instance_tenancy":   "default",
+				"tags":               map[string]interface{}{"Name": "main", "Environment": "production"},
+			},
+			Sch: &resource.Schema{Attributes: map[string]resource.AttributeSchema{
+				"id":                 optionalComputed,
+				"arn":                computed,
+				"cidr_block":         required,
+				"enable_dns_support": optional,
+				"instance_tenancy":   optionalComputed,
+				"tags":               optional,
+			}},
+		},
+		{
+			Id:   "subnet-1",
+			Type: "aws_subnet",
+			Attrs: &resource.Attributes{
+				"cidr_block":              "10.0.1.0/24",
+				"map_public_ip_on_launch": false,
+				"vpc_id":                  "vpc-1",
+			},
+			Sch: &resource.Schema{Attributes: map[string]resource.AttributeSchema{
+				"cidr_block":              required,
+				"map_public_ip_on_launch": optional,
+				"vpc_id":                  required,
+			}},
+		},
+		{
+			Id:   "arn:aws:iam::123456789012:policy/deploy",
+			Type: "aws_iam_policy",
+			Attrs: &resource.Attributes{
+				"name":   "deploy",
+				"path":   "/",
+				"policy": `{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Resource":"*"}]}`,
+			},
+			Sch: &resource.Schema{Attributes: map[string]resource.AttributeSchema{
+				"name":   optionalComputed,
+				"path":   optional,
+				"policy": jsonString,
+			}},
+		},
+		{
+			Id:   "role-deploy",
+			Type: "aws_iam_role_policy_attachment",
+			Attrs: &resource.Attributes{
+				"policy_arn": "arn:aws:iam::123456789012:policy/deploy",
+				"role":       "deploy",
+			},
+			Sch: &resource.Schema{Attributes: map[string]resource.AttributeSchema{
+				"policy_arn": required,
+				"role":       required,
+			}},
+		},
+		{
+			Id:   "my-bucket",
+			Type: "aws_s3_bucket",
+			Attrs: &resource.Attributes{
+				"acl":    "private",
+				"bucket": "my-bucket",
+				"versioning": []interface{}{
+					map[string]interface{}{"enabled": true, "mfa_delete": false},
+				},
+				"logging": []interface{}{
+					map[string]interface{}{"target_bucket": "", "target_prefix": ""},
+				},
+			},
+			Sch: &resource.Schema{Attributes: map[string]resource.AttributeSchema{
+				"acl":                   optional,
+				"bucket":                optionalComputed,
+				"versioning.enabled":    optional,
+				"versioning.mfa_delete": optional,
+				"logging.target_bucket": required,
+				"logging.target_prefix": optional,
+			}},
+		},
+		{
+			Id:    "my-bucket",
+			Type:  "aws_s3_bucket_policy",
+			Attrs: &resource.Attributes{"bucket": "my-bucket"},
+			Sch: &resource.Schema{Attributes: map[string]resource.AttributeSchema{
+				"bucket": required,
+			}},
+		},
+		{
+			Id:    "my-bucket",
+			Type:  "aws_s3_bucket_public_access_block",
+			Attrs: &resource.Attributes{"block_public_acls": true, "bucket": "my-bucket"},
+			Sch: &resource.Schema{Attributes: map[string]resource.AttributeSchema{
+				"block_public_acls": optional,
+				"bucket":            required,
+			}},
+		},
+		{
+			Id:    "i-1",
+			Type:  "aws_instance",
+			Attrs: &resource.Attributes{"ami": "ami-1"},
+		},
+	}
+	managed := []*resource.Resource{
+		{
+			Id:     "deploy",
+			Type:   "aws_iam_role",
+			Attrs:  &resource.Attributes{"arn": "arn:aws:iam::123456789012:role/deploy"},
+			Source: resource.NewTerraformStateSource("tfstate://terraform.tfstate", "", "deploy"),
+		},
+		{
+			Id:     "10.0.1.0/24",
+			Type:   "aws_fake",
+			Source: resource.NewTerraformStateSource("tfstate://terraform.tfstate", "module.network", "fake"),
+		},
+	}
+
+	var out bytes.Buffer
+	assert.NoError(t, NewResourceGenerator(unmanaged, managed).Write(&out))
+	assert.Equal(t, `# Generated by driftctl
+
+resource "aws_iam_policy" "arn_aws_iam_123456789012_policy_deploy" {
+  name = "deploy"
+  policy = jsonencode({
+    "Statement" = [
+      {
+        "Action"   = ["s3:GetObject"]
+        "Effect"   = "Allow"
+        "Resource" = "*"
+      },
+    ]
+    "Version" = "2012-10-17"
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "role-deploy" {
+  policy_arn = aws_iam_policy.arn_aws_iam_123456789012_policy_deploy.id
+  role       = aws_iam_role.deploy.id
+}
+
+# aws_instance.i-1: no schema available for aws_instance, unable to generate its attributes
+
+resource "aws_s3_bucket" "my-bucket" {
+  bucket = "my-bucket"
+  logging {
+    target_bucket = ""
+  }
+  versioning {
+    enabled = true
+  }
+}
+
+resource "aws_s3_bucket_policy" "my-bucket" {
+  bucket = aws_s3_bucket.my-bucket.id
+}
+
+resource "aws_s3_bucket_public_access_block" "my-bucket" {
+  block_public_acls = true
+  bucket            = aws_s3_bucket.my-bucket.id
+}
+
+resource "aws_subnet" "subnet-1" {
+  cidr_block = "10.0.1.0/24"
+  vpc_id     = aws_vpc.vpc-1.id
+}
+
+resource "aws_vpc" "vpc-1" {
+  cidr_block         = "10.0.0.0/16"
+  enable_dns_support = true
+  instance_tenancy   = "default"
+  tags = {
+    "Environment" = "production"
+    "Name"        = "main"
+  }
+}
+`, out.String())
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// DefaultProjectFile is the project configuration file read from the working directory
+const DefaultProjectFile = ".driftctl.yaml"
+
+// Options are the values of the flags of a command, keyed by flag name
+type Options map[string]interface{}
+
+// Project holds the options of each command, keyed by command path (e.g. scan, driftignore lint), and named profiles
+// overriding them:
+//
+//	scan:
+//	  to: aws+tf
+//	  output: [console://, json://result.json]
+//	profiles:
+//	  prod-us:
+//	    scan:
+//	      from: [tfstate+s3://bucket/prod-us.tfstate]
+type Project struct {
+	Path     string
+	Commands map[string]Options
+	Profiles map[string]map[string]Options
+}
+
+// ReadProject loads a project configuration file
+func ReadProject(path string) (*Project, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, errors.Wrapf(err, "%s", path)
+	}
+
+	project := &Project{Path: path, Commands: map[string]Options{}, Profiles: map[string]map[string]Options{}}
+	for key, value := range raw {
+		if key != "profiles" {
+			options, err := toOptions(value)
+			if err != nil {
+				return nil, errors.Errorf("%s: invalid options for command '%s', %s", path, key, err)
+			}
+			project.Commands[key] = options
+			continue
+		}
+
+		profiles, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("%s: profiles must be a map of profile names to commands", path)
+		}
+		for name, commands := range profiles {
+			commandsMap, ok := commands.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("%s: profile '%s' must be a map of commands to options", path, name)
+			}
+			project.Profiles[name] = map[string]Options{}
+			for command, value := range commandsMap {
+				options, err := toOptions(value)
+				if err != nil {
+					return nil, errors.Errorf("%s: invalid options for command '%s' of profile '%s', %s", path, command, name, err)
+				}
+				project.Profiles[name][command] = options
+			}
+		}
+	}
+	return project, nil
+}
+
+func toOptions(value interface{}) (Options, error) {
+	if value == nil {
+		return Options{}, nil
+	}
+	options, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("expected a map of flag names to values")
+	}
+	return options, nil
+}
+
+// CommandPaths returns every command path used in the project, including the ones of its profiles
+func (p *Project) CommandPaths() []string {
+	paths := make(map[string]struct{})
+	for command := range p.Commands {
+		paths[command] = struct{}{}
+	}
+	for _, commands := range p.Profiles {
+		for command := range commands {
+			paths[command] = struct{}{}
+		}
+	}
+	return sortedKeys(paths)
+}
+
+// Options returns the options of a command, the ones of the profile override the ones shared by every profile.
+// An empty profile selects the shared options only.
+func (p *Project) Options(command, profile string) (Options, error) {
+	options := Options{}
+	for name, value := range p.Commands[command] {
+		options[name] = value
+	}
+	if profile == "" {
+		return options, nil
+	}
+
+	commands, exist := p.Profiles[profile]
+	if !exist {
+		names := make(map[string]struct{}, len(p.Profiles))
+		for name := range p.Profiles {
+			names[name] = struct{}{}
+		}
+		return nil, errors.Errorf("unknown profile '%s' in %s\nAvailable profiles are: %s", profile, p.Path, strings.Join(sortedKeys(names), ", "))
+	}
+	for name, value := range commands[command] {
+		options[name] = value
+	}
+	return options, nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadProject(t *testing.T) {
+	cases := []struct {
+		name string
+		path string
+		err  string
+	}{
+		{
+			name: "valid project",
+			path: "testdata/project.yaml",
+		},
+		{
+			name: "missing project",
+			path: "testdata/doesnotexist.yaml",
+			err:  "open testdata/doesnotexist.yaml: no such file or directory",
+		},
+		{
+			name: "command options not being a map",
+			path: "testdata/invalid_command.yaml",
+			err:  "testdata/invalid_command.yaml: invalid options for command 'scan', expected a map of flag names to values",
+		},
+		{
+			name: "profiles not being a map",
+			path: "testdata/invalid_profiles.yaml",
+			err:  "testdata/invalid_profiles.yaml: profiles must be a map of profile names to commands",
+		},
+		{
+			name: "profile not being a map",
+			path: "testdata/invalid_profile.yaml",
+			err:  "testdata/invalid_profile.yaml: profile 'prod' must be a map of commands to options",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			project, err := ReadProject(c.path)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.path, project.Path)
+		})
+	}
+}
+
+func TestProject_Options(t *testing.T) {
+	project, err := ReadProject("testdata/project.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"driftignore lint", "gen-driftignore", "scan"}, project.CommandPaths())
+
+	cases := []struct {
+		name     string
+		command  string
+		profile  string
+		expected Options
+		err      string
+	}{
+		{
+			name:    "shared options",
+			command: "scan",
+			expected: Options{
+				"to":                  "aws+tf",
+				"output":              []interface{}{"console://", "json://result.json"},
+				"tf-provider-version": "3.19.0",
+			},
+		},
+		{
+			name:    "profile adding options",
+			command: "scan",
+			profile: "prod-us",
+			expected: Options{
+				"to":                  "aws+tf",
+				"output":              []interface{}{"console://", "json://result.json"},
+				"tf-provider-version": "3.19.0",
+				"from":                []interface{}{"tfstate+s3://states/prod-us/network.tfstate", "tfstate+s3://states/prod-us/app.tfstate"},
+				"headers":             map[string]interface{}{"Authorization": "Bearer token"},
+			},
+		},
+		{
+			name:    "profile overriding options",
+			command: "scan",
+			profile: "staging",
+			expected: Options{
+				"to":                  "gcp+tf",
+				"output":              []interface{}{"console://", "json://result.json"},
+				"tf-provider-version": "3.19.0",
+			},
+		},
+		{
+			name:     "command without options",
+			command:  "gen-driftignore",
+			profile:  "prod-us",
+			expected: Options{},
+		},
+		{
+			name:     "command only in a profile",
+			command:  "driftignore lint",
+			profile:  "staging",
+			expected: Options{"driftignore": ".driftignore.staging"},
+		},
+		{
+			name:    "unknown profile",
+			command: "scan",
+			profile: "prod-eu",
+			err:     "unknown profile 'prod-eu' in testdata/project.yaml\nAvailable profiles are: prod-us, staging",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			options, err := project.Options(c.command, c.profile)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, options)
+		})
+	}
+}
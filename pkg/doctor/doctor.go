@@ -0,0 +1,242 @@
+package doctor
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration"
+	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote"
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+	"github.com/khulnasoft-lab/driftctl/pkg/capabilities"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/config"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/enumerator"
+)
+
+const (
+	StatusOK = "ok"
+	// StatusDenied is the status of an enumerator missing permissions, its resources would be left out of a scan
+	StatusDenied = "denied"
+	StatusFailed = "failed"
+	// StatusSkipped is the status of the checks of a dry run, nothing is read
+	StatusSkipped = "skipped"
+)
+
+// enumeratorConcurrency is the number of enumerators checked at once, as many as a scan runs
+const enumeratorConcurrency = 10
+
+// StateCheck tells whether a state file a scan would read is reachable
+type StateCheck struct {
+	Source string `json:"source"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// EnumeratorCheck tells whether the resources of a type are readable on the cloud provider
+type EnumeratorCheck struct {
+	Type        string   `json:"type"`
+	Status      string   `json:"status"`
+	Error       string   `json:"error,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+// RemoteCheck holds the checks of a cloud provider. Enumerators are only checked once its provider is configured.
+type RemoteCheck struct {
+	Name        string            `json:"name"`
+	Status      string            `json:"status"`
+	Error       string            `json:"error,omitempty"`
+	Enumerators []EnumeratorCheck `json:"enumerators"`
+}
+
+// Problems returns the number of failed checks
+func (r RemoteCheck) Problems() int {
+	problems := 0
+	if r.Status == StatusFailed {
+		problems++
+	}
+	for _, check := range r.Enumerators {
+		if check.Status == StatusDenied || check.Status == StatusFailed {
+			problems++
+		}
+	}
+	return problems
+}
+
+// MissingPermissions returns the permissions of the denied enumerators, sorted and without duplicates
+func (r RemoteCheck) MissingPermissions() []string {
+	seen := make(map[string]struct{})
+	permissions := make([]string, 0)
+	for _, check := range r.Enumerators {
+		if check.Status != StatusDenied {
+			continue
+		}
+		for _, permission := range check.Permissions {
+			if _, exist := seen[permission]; !exist {
+				seen[permission] = struct{}{}
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+// TypeFilter tells whether a scan skips the enumeration of a resource type, e.g. because of a driftignore file
+type TypeFilter func(ty string) bool
+
+// CheckEnumerators checks each enumerator of the library, the library must only hold the enumerators of the given remote.
+// Enumerators implementing common.Prober make a few list calls limited to a single result, others are run as in a scan
+// and go through every page of results. The details of resources are not read. Access denied errors are reported as
+// missing permissions, as the alerts of a scan.
+func CheckEnumerators(remoteName string, library *common.RemoteLibrary, ignored TypeFilter, progress enumeration.ProgressCounter) []EnumeratorCheck {
+	permissions := typePermissions(remoteName)
+
+	enumerators := make([]common.Enumerator, 0, len(library.Enumerators()))
+	for _, e := range library.Enumerators() {
+		ty := e.SupportedType().String()
+		if ignored != nil && ignored(ty) {
+			continue
+		}
+		enumerators = append(enumerators, e)
+	}
+
+	checks := make([]EnumeratorCheck, len(enumerators))
+	sem := make(chan struct{}, enumeratorConcurrency)
+	wg := sync.WaitGroup{}
+	for i, e := range enumerators {
+		i, e := i, e
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			checks[i] = checkEnumerator(e, permissions[e.SupportedType().String()])
+			if progress != nil {
+				progress.Inc()
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].Type < checks[j].Type
+	})
+	return checks
+}
+
+func checkEnumerator(e common.Enumerator, permissions []string) EnumeratorCheck {
+	check := EnumeratorCheck{Type: e.SupportedType().String(), Status: StatusOK, Permissions: permissions}
+	var err error
+	if prober, ok := e.(common.Prober); ok {
+		err = prober.Probe()
+	} else {
+		_, err = e.Enumerate()
+	}
+	if err == nil {
+		return check
+	}
+
+	recorder := &alertRecorder{}
+	if err := remote.HandleResourceEnumerationError(err, recorder); err != nil {
+		logrus.WithFields(logrus.Fields{"type": check.Type, "error": err}).Debug("Enumeration failed")
+		check.Status = StatusFailed
+		check.Error = err.Error()
+		return check
+	}
+	check.Status = StatusDenied
+	if recorder.alert != nil {
+		check.Error = recorder.alert.Message()
+	}
+	return check
+}
+
+// DryRunEnumerators returns the enumerators a scan of the remote would run, from the capabilities catalog
+func DryRunEnumerators(remoteName string, ignored TypeFilter) []EnumeratorCheck {
+	permissions := typePermissions(remoteName)
+	checks := make([]EnumeratorCheck, 0, len(permissions))
+	for ty, perms := range permissions {
+		if ignored != nil && ignored(ty) {
+			continue
+		}
+		checks = append(checks, EnumeratorCheck{Type: ty, Status: StatusSkipped, Permissions: perms})
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Type < checks[j].Type
+	})
+	return checks
+}
+
+// typePermissions returns the permissions of each enumerated type of the remote
+func typePermissions(remoteName string) map[string][]string {
+	permissions := make(map[string][]string)
+	remotes, err := capabilities.List(remoteName)
+	if err != nil || len(remotes) == 0 {
+		return permissions
+	}
+	for _, res := range remotes[0].Resources {
+		if res.Enumerated {
+			permissions[res.Type] = res.Permissions
+		}
+	}
+	return permissions
+}
+
+// CheckStates lists the state files of the IaC sources, expanding globs, and opens each of them unless it is a dry
+// run. A source whose files can't be listed is reported as a single failed check.
+func CheckStates(sources []config.SupplierConfig, opts *backend.Options, dryRun bool) []StateCheck {
+	checks := make([]StateCheck, 0, len(sources))
+	for _, source := range sources {
+		paths := []string{source.Path}
+		stateEnumerator, err := enumerator.GetEnumerator(source, opts)
+		if err == nil && stateEnumerator != nil {
+			paths, err = stateEnumerator.Enumerate()
+		}
+		if err != nil {
+			checks = append(checks, StateCheck{Source: source.String(), Status: StatusFailed, Error: err.Error()})
+			continue
+		}
+
+		for _, path := range paths {
+			state := source
+			state.Path = path
+			check := StateCheck{Source: state.String(), Status: StatusSkipped}
+			if !dryRun {
+				check.Status = StatusOK
+				if err := readState(state, opts); err != nil {
+					check.Status = StatusFailed
+					check.Error = err.Error()
+				}
+			}
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// readState reads the first byte of a state file, it is enough to check it is reachable
+func readState(state config.SupplierConfig, opts *backend.Options) error {
+	reader, err := backend.GetBackend(state, opts)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if _, err := reader.Read(make([]byte, 1)); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// alertRecorder keeps the alert sent when an enumeration error is handled
+type alertRecorder struct {
+	alert alerter.Alert
+}
+
+func (r *alertRecorder) SendAlert(_ string, alert alerter.Alert) {
+	r.alert = alert
+}
@@ -0,0 +1,143 @@
+package doctor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+	remoteerror "github.com/khulnasoft-lab/driftctl/enumeration/remote/error"
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/config"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
+)
+
+func TestCheckEnumerators(t *testing.T) {
+	library := common.NewRemoteLibrary()
+
+	enumerators := map[string]error{
+		"aws_sqs_queue": nil,
+		"aws_s3_bucket": remoteerror.NewResourceListingError(errors.New("AccessDenied: not allowed"), "aws_s3_bucket"),
+		"aws_iam_user":  errors.New("connection reset by peer"),
+		"aws_iam_role":  nil,
+	}
+	for ty, err := range enumerators {
+		e := &common.MockEnumerator{}
+		e.On("SupportedType").Return(resource.ResourceType(ty))
+		e.On("Enumerate").Return([]*resource.Resource{}, err)
+		library.AddEnumerator(e)
+	}
+
+	ignored := func(ty string) bool {
+		return ty == "aws_iam_role"
+	}
+
+	checks := CheckEnumerators(common.RemoteAWSTerraform, library, ignored, nil)
+	assert.Equal(t, []EnumeratorCheck{
+		{Type: "aws_iam_user", Status: StatusFailed, Error: "connection reset by peer", Permissions: []string{"iam:ListUsers"}},
+		{Type: "aws_s3_bucket", Status: StatusDenied, Error: "An error occured listing aws_s3_bucket: listing aws_s3_bucket is forbidden: AccessDenied: not allowed", Permissions: []string{"s3:GetBucketLocation", "s3:ListAllMyBuckets"}},
+		{Type: "aws_sqs_queue", Status: StatusOK, Permissions: []string{"sqs:ListQueues"}},
+	}, checks)
+
+	check := RemoteCheck{Name: common.RemoteAWSTerraform, Status: StatusOK, Enumerators: checks}
+	assert.Equal(t, 2, check.Problems())
+	assert.Equal(t, []string{"s3:GetBucketLocation", "s3:ListAllMyBuckets"}, check.MissingPermissions())
+}
+
+// probingEnumerator has no expectation on Enumerate, the mock fails when it is called
+type probingEnumerator struct {
+	*common.MockEnumerator
+	err error
+}
+
+func (e *probingEnumerator) Probe() error {
+	return e.err
+}
+
+func TestCheckEnumerators_Probe(t *testing.T) {
+	library := common.NewRemoteLibrary()
+
+	enumerators := map[string]error{
+		"aws_iam_access_key": nil,
+		"aws_s3_bucket":      remoteerror.NewResourceListingError(errors.New("AccessDenied: not allowed"), "aws_s3_bucket"),
+	}
+	for ty, err := range enumerators {
+		e := &common.MockEnumerator{}
+		e.On("SupportedType").Return(resource.ResourceType(ty))
+		library.AddEnumerator(&probingEnumerator{e, err})
+	}
+
+	checks := CheckEnumerators(common.RemoteAWSTerraform, library, nil, nil)
+	assert.Equal(t, []EnumeratorCheck{
+		{Type: "aws_iam_access_key", Status: StatusOK, Permissions: []string{"iam:ListAccessKeys", "iam:ListUsers"}},
+		{Type: "aws_s3_bucket", Status: StatusDenied, Error: "An error occured listing aws_s3_bucket: listing aws_s3_bucket is forbidden: AccessDenied: not allowed", Permissions: []string{"s3:GetBucketLocation", "s3:ListAllMyBuckets"}},
+	}, checks)
+}
+
+func TestDryRunEnumerators(t *testing.T) {
+	ignored := func(ty string) bool {
+		return ty == "github_team_membership"
+	}
+
+	checks := DryRunEnumerators(common.RemoteGithubTerraform, ignored)
+	assert.Equal(t, []EnumeratorCheck{
+		{Type: "github_branch_protection", Status: StatusSkipped, Permissions: []string{"repo"}},
+		{Type: "github_membership", Status: StatusSkipped, Permissions: []string{"read:org"}},
+		{Type: "github_repository", Status: StatusSkipped, Permissions: []string{"repo"}},
+		{Type: "github_team", Status: StatusSkipped, Permissions: []string{"read:org"}},
+	}, checks)
+}
+
+func TestCheckStates(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	serverPath := strings.TrimPrefix(server.URL, "http://") + "/terraform.tfstate"
+
+	cases := []struct {
+		name     string
+		sources  []config.SupplierConfig
+		dryRun   bool
+		expected []StateCheck
+	}{
+		{
+			name: "test reachable states",
+			sources: []config.SupplierConfig{
+				{Key: "tfstate", Path: "testdata/states/**/*.tfstate"},
+			},
+			expected: []StateCheck{
+				{Source: "tfstate://testdata/states/a.tfstate", Status: StatusOK},
+				{Source: "tfstate://testdata/states/b.tfstate", Status: StatusOK},
+			},
+		},
+		{
+			name: "test dry run does not read states",
+			sources: []config.SupplierConfig{
+				{Key: "tfstate", Path: "testdata/states/a.tfstate"},
+			},
+			dryRun: true,
+			expected: []StateCheck{
+				{Source: "tfstate://testdata/states/a.tfstate", Status: StatusSkipped},
+			},
+		},
+		{
+			name: "test unreachable state",
+			sources: []config.SupplierConfig{
+				{Key: "tfstate", Backend: "http", Path: serverPath},
+			},
+			expected: []StateCheck{
+				{Source: "tfstate+http://" + serverPath, Status: StatusFailed, Error: "error requesting HTTP(s) backend state: status code: 404"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			checks := CheckStates(c.sources, &backend.Options{}, c.dryRun)
+			assert.Equal(t, c.expected, checks)
+		})
+	}
+}
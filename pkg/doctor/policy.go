@@ -0,0 +1,70 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+)
+
+type awsPolicy struct {
+	Version   string
+	Statement []awsStatement
+}
+
+type awsStatement struct {
+	Effect   string
+	Action   []string
+	Resource string
+}
+
+type azureRoleDefinition struct {
+	Name             string
+	IsCustom         bool
+	Actions          []string
+	NotActions       []string
+	AssignableScopes []string
+}
+
+// PolicySnippet returns the smallest grant of the given permissions in the format of the remote: an IAM policy for
+// AWS, a custom role for Google, a role definition for Azure and the scopes of the token for GitHub
+func PolicySnippet(remoteName string, permissions []string) string {
+	if len(permissions) == 0 {
+		return ""
+	}
+
+	switch remoteName {
+	case common.RemoteAWSTerraform:
+		return indentJSON(awsPolicy{
+			Version:   "2012-10-17",
+			Statement: []awsStatement{{Effect: "Allow", Action: permissions, Resource: "*"}},
+		})
+	case common.RemoteGoogleTerraform:
+		var b strings.Builder
+		b.WriteString("title: driftctl\n")
+		b.WriteString("stage: GA\n")
+		b.WriteString("includedPermissions:\n")
+		for _, permission := range permissions {
+			fmt.Fprintf(&b, "- %s\n", permission)
+		}
+		return b.String()
+	case common.RemoteAzureTerraform:
+		return indentJSON(azureRoleDefinition{
+			Name:             "driftctl",
+			IsCustom:         true,
+			Actions:          permissions,
+			NotActions:       []string{},
+			AssignableScopes: []string{"/subscriptions/SUBSCRIPTION_ID"},
+		})
+	case common.RemoteGithubTerraform:
+		return fmt.Sprintf("Grant the following scopes to the token: %s\n", strings.Join(permissions, ", "))
+	default:
+		return strings.Join(permissions, "\n") + "\n"
+	}
+}
+
+func indentJSON(value interface{}) string {
+	content, _ := json.MarshalIndent(value, "", "  ")
+	return string(content) + "\n"
+}
@@ -0,0 +1,83 @@
+package doctor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+)
+
+func TestPolicySnippet(t *testing.T) {
+	cases := []struct {
+		name        string
+		remote      string
+		permissions []string
+		expected    string
+	}{
+		{
+			name:        "test no missing permissions",
+			remote:      common.RemoteAWSTerraform,
+			permissions: []string{},
+			expected:    "",
+		},
+		{
+			name:        "test aws policy",
+			remote:      common.RemoteAWSTerraform,
+			permissions: []string{"s3:GetBucketLocation", "s3:ListAllMyBuckets"},
+			expected: `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": [
+        "s3:GetBucketLocation",
+        "s3:ListAllMyBuckets"
+      ],
+      "Resource": "*"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:        "test google custom role",
+			remote:      common.RemoteGoogleTerraform,
+			permissions: []string{"compute.instances.list"},
+			expected: `title: driftctl
+stage: GA
+includedPermissions:
+- compute.instances.list
+`,
+		},
+		{
+			name:        "test azure role definition",
+			remote:      common.RemoteAzureTerraform,
+			permissions: []string{"Microsoft.Storage/storageAccounts/read"},
+			expected: `{
+  "Name": "driftctl",
+  "IsCustom": true,
+  "Actions": [
+    "Microsoft.Storage/storageAccounts/read"
+  ],
+  "NotActions": [],
+  "AssignableScopes": [
+    "/subscriptions/SUBSCRIPTION_ID"
+  ]
+}
+`,
+		},
+		{
+			name:        "test github scopes",
+			remote:      common.RemoteGithubTerraform,
+			permissions: []string{"read:org", "repo"},
+			expected:    "Grant the following scopes to the token: read:org, repo\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, PolicySnippet(c.remote, c.permissions))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report holds the checks of every cloud provider and state file a scan would use
+type Report struct {
+	DryRun  bool          `json:"dry_run"`
+	Remotes []RemoteCheck `json:"remotes"`
+	States  []StateCheck  `json:"states"`
+}
+
+// Problems returns the number of failed checks of the report
+func (r Report) Problems() int {
+	problems := 0
+	for _, remote := range r.Remotes {
+		problems += remote.Problems()
+	}
+	for _, state := range r.States {
+		if state.Status == StatusFailed {
+			problems++
+		}
+	}
+	return problems
+}
+
+// Write prints the report. Readable resource types are only counted, failed checks are listed with their error and
+// followed by the policy granting the missing permissions.
+func (r Report) Write(w io.Writer) {
+	for _, remote := range r.Remotes {
+		if r.DryRun {
+			fmt.Fprintf(w, "%s: a scan would enumerate %d resource type(s)\n", remote.Name, len(remote.Enumerators))
+			for _, check := range remote.Enumerators {
+				fmt.Fprintf(w, "  - %s (%s)\n", check.Type, strings.Join(check.Permissions, ", "))
+			}
+			continue
+		}
+
+		if remote.Status == StatusFailed {
+			fmt.Fprintf(w, "%s: credentials failed: %s\n", remote.Name, remote.Error)
+			continue
+		}
+		readable := 0
+		for _, check := range remote.Enumerators {
+			if check.Status == StatusOK {
+				readable++
+			}
+		}
+		fmt.Fprintf(w, "%s: credentials ok, %d/%d resource type(s) readable\n", remote.Name, readable, len(remote.Enumerators))
+		for _, check := range remote.Enumerators {
+			switch check.Status {
+			case StatusDenied:
+				fmt.Fprintf(w, "  - %s denied, requires %s\n", check.Type, strings.Join(check.Permissions, ", "))
+			case StatusFailed:
+				fmt.Fprintf(w, "  - %s failed: %s\n", check.Type, check.Error)
+			}
+		}
+		if snippet := PolicySnippet(remote.Name, remote.MissingPermissions()); snippet != "" {
+			fmt.Fprintf(w, "Minimal policy granting the missing permissions of %s:\n%s", remote.Name, snippet)
+		}
+	}
+
+	if r.DryRun {
+		fmt.Fprintln(w, "State files a scan would read:")
+	} else {
+		fmt.Fprintln(w, "State files:")
+	}
+	for _, state := range r.States {
+		switch state.Status {
+		case StatusSkipped:
+			fmt.Fprintf(w, "  - %s\n", state.Source)
+		case StatusFailed:
+			fmt.Fprintf(w, "  - %s unreachable: %s\n", state.Source, state.Error)
+		default:
+			fmt.Fprintf(w, "  - %s reachable\n", state.Source)
+		}
+	}
+}
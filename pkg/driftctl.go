@@ -9,13 +9,17 @@ import (
 	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
 	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
 	"github.com/khulnasoft-lab/driftctl/pkg/cmd/scan/output"
+	"github.com/khulnasoft-lab/driftctl/pkg/codegen"
+	"github.com/khulnasoft-lab/driftctl/pkg/explain"
 	"github.com/khulnasoft-lab/driftctl/pkg/filter"
 	"github.com/khulnasoft-lab/driftctl/pkg/iac/config"
 	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/khulnasoft-lab/driftctl/pkg/memstore"
 	"github.com/khulnasoft-lab/driftctl/pkg/middlewares"
 	globaloutput "github.com/khulnasoft-lab/driftctl/pkg/output"
+	"github.com/khulnasoft-lab/driftctl/pkg/policy"
 	dctlresource "github.com/khulnasoft-lab/driftctl/pkg/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/risk"
 	"github.com/sirupsen/logrus"
 )
 
@@ -23,21 +27,68 @@ type FmtOptions struct {
 	Output output.OutputConfig
 }
 
+type CompareOptions struct {
+	Output output.OutputConfig
+}
+
+type GenImportsOptions struct {
+	InputPath  string
+	OutputPath string
+	Format     string
+	GroupBy    string
+	Modules    []codegen.ModuleMapping
+}
+
+type ResourcesOptions struct {
+	To         string
+	Format     string
+	OutputPath string
+}
+
+type DriftIgnoreLintOptions struct {
+	DriftignorePath string
+	InputPath       string
+	Fix             bool
+}
+
+type DoctorOptions struct {
+	From             []config.SupplierConfig
+	To               []string
+	ProviderVersions map[string]string
+	BackendOptions   *backend.Options
+	ConfigDir        string
+	DriftignorePath  string
+	DryRun           bool
+}
+
+type ServeOptions struct {
+	ConfigPath string
+	Listen     string
+}
+
 type ScanOptions struct {
 	Coverage         bool
 	Detect           bool
 	From             []config.SupplierConfig
-	To               string
+	To               []string
 	Output           []output.OutputConfig
 	Filter           *jmespath.JMESPath
 	Quiet            bool
 	BackendOptions   *backend.Options
 	StrictMode       bool
 	DisableTelemetry bool
-	ProviderVersion  string
+	// ProviderVersions are the provider versions to use keyed by cloud provider, the default version is used for the
+	// missing ones
+	ProviderVersions map[string]string
 	ConfigDir        string
 	DriftignorePath  string
 	Driftignores     []string
+	Deep             bool
+	BaselinePath     string
+	JSONAttributes   bool
+	Policy           *policy.Policy
+	RiskRules        *risk.Ruleset
+	Explain          []explain.Target
 }
 
 type DriftCTL struct {
@@ -51,6 +102,7 @@ type DriftCTL struct {
 	resourceSchemaRepository dctlresource.SchemaRepositoryInterface
 	opts                     *ScanOptions
 	store                    memstore.Store
+	tracer                   *explain.Tracer
 }
 
 func NewDriftCTL(remoteSupplier resource.Supplier,
@@ -74,9 +126,15 @@ func NewDriftCTL(remoteSupplier resource.Supplier,
 		resourceSchemaRepository,
 		opts,
 		store,
+		nil,
 	}
 }
 
+// SetTracer records the decision trail of the traced resources during the next runs
+func (d *DriftCTL) SetTracer(tracer *explain.Tracer) {
+	d.tracer = tracer
+}
+
 func (d DriftCTL) Run() (*analyser.Analysis, error) {
 	start := time.Now()
 	remoteResources, resourcesFromState, err := d.scan()
@@ -84,6 +142,12 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 		return nil, err
 	}
 
+	var observer middlewares.ChainObserver
+	if d.tracer != nil {
+		d.tracer.Scanned(remoteResources, resourcesFromState)
+		observer = d.tracer
+	}
+
 	middleware := middlewares.NewChain(
 		middlewares.NewRoute53RecordIDReconcilier(),
 		middlewares.NewRoute53DefaultZoneRecordSanitizer(),
@@ -135,16 +199,11 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 	)
 
 	if !d.opts.StrictMode {
-		middleware = append(middleware,
-			middlewares.NewAwsDefaults(),
-			middlewares.NewGoogleLegacyBucketIAMMember(),
-			middlewares.NewGoogleDefaultIAMMember(),
-			middlewares.NewAwsDefaultApiGatewayAccount(),
-		)
+		middleware = append(middleware, middlewares.NewNonStrictChain()...)
 	}
 
 	logrus.Debug("Ready to run middlewares")
-	err = middleware.Execute(&remoteResources, &resourcesFromState)
+	err = middleware.ExecuteWithObserver(&remoteResources, &resourcesFromState, observer)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +218,9 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 		if err != nil {
 			return nil, err
 		}
+		if d.tracer != nil {
+			d.tracer.Filtered(remoteResources, resourcesFromState)
+		}
 	}
 
 	analysis, err := d.analyzer.Analyze(remoteResources, resourcesFromState)
@@ -166,7 +228,12 @@ func (d DriftCTL) Run() (*analyser.Analysis, error) {
 		return nil, err
 	}
 
+	if d.tracer != nil {
+		d.tracer.Analyzed(&analysis)
+	}
+
 	analysis.SetIaCSourceCount(d.iacSupplier.SourceCount())
+	analysis.SetEmptyIaCSources(d.iacSupplier.EmptySources())
 	analysis.Duration = time.Since(start)
 	analysis.Date = time.Now()
 
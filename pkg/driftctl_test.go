@@ -63,6 +63,7 @@ func runTest(t *testing.T, cases TestCases) {
 			stateSupplier := &dctlresource.MockIaCSupplier{}
 			stateSupplier.On("Resources").Return(c.stateResources, nil)
 			stateSupplier.On("SourceCount").Return(uint(2))
+			stateSupplier.On("EmptySources").Return([]string{})
 
 			if c.remoteResources == nil {
 				c.remoteResources = []*resource.Resource{}
@@ -92,7 +93,7 @@ func runTest(t *testing.T, cases TestCases) {
 			testFilter := &filter.MockFilter{}
 			testFilter.On("IsTypeIgnored", mock.Anything).Return(false)
 			testFilter.On("IsResourceIgnored", mock.Anything).Return(false)
-			analyzer := analyser.NewAnalyzer(testAlerter, testFilter)
+			analyzer := analyser.NewAnalyzer(testAlerter, analyser.AnalyzerOptions{}, testFilter)
 
 			store := memstore.New()
 			driftctl := pkg.NewDriftCTL(remoteSupplier, stateSupplier, testAlerter, analyzer, resourceFactory, c.options, scanProgress, iacProgress, repo, store)
@@ -1324,11 +1325,12 @@ func TestDriftctlRun_TestResourcesNormalization(t *testing.T) {
 			testFilter.On("IsResourceIgnored", mock.MatchedBy(func(res *resource.Resource) bool {
 				return res.ResourceType() != c.Resource
 			})).Return(true)
-			analyzer := analyser.NewAnalyzer(testAlerter, testFilter)
+			analyzer := analyser.NewAnalyzer(testAlerter, analyser.AnalyzerOptions{}, testFilter)
 
 			stateSupplier := &dctlresource.MockIaCSupplier{}
 			stateSupplier.On("Resources").Return(expectedResources, nil)
 			stateSupplier.On("SourceCount").Return(uint(1))
+			stateSupplier.On("EmptySources").Return([]string{})
 			remoteSupplier := &resource.MockSupplier{}
 			remoteSupplier.On("Resources").Return(inputResources, nil)
 
@@ -0,0 +1,107 @@
+package driftignore
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+// FixResult counts the changes made to a file by Fix
+type FixResult struct {
+	Removed   int
+	Collapsed int
+	Wildcards int
+}
+
+// Fix removes the rules matching nothing in the analysis and replaces the exact rules of a type by a wildcard when
+// they ignore every resource of this type. As for Lint, the analysis must come from a scan run without the file.
+func (f *File) Fix(analysis *analyser.Analysis) (FixResult, error) {
+	result := FixResult{}
+	f.validate()
+	idx := newIndex(analysis)
+
+	unused := f.unused(idx)
+	valid := 0
+	for _, r := range f.rules {
+		if !r.invalid {
+			valid++
+		}
+	}
+	if valid > 0 && len(unused) == valid {
+		return result, errors.Errorf("no rule of %s matches the analysis, make sure it comes from a scan run without the driftignore file (e.g. --driftignore /dev/null)", f.Path)
+	}
+
+	removed := make(map[int]bool)
+	for _, r := range unused {
+		removed[r.line] = true
+	}
+	result.Removed = len(unused)
+
+	for _, group := range f.collapsible(idx, removed) {
+		f.lines[group[0].line] = fmt.Sprintf("%s.*", group[0].segments[0])
+		for _, r := range group[1:] {
+			removed[r.line] = true
+		}
+		result.Collapsed += len(group)
+		result.Wildcards++
+	}
+
+	lines := make([]string, 0, len(f.lines))
+	for i, line := range f.lines {
+		if !removed[i] {
+			lines = append(lines, line)
+		}
+	}
+	f.lines = lines
+	f.parse()
+
+	return result, nil
+}
+
+// collapsible returns the groups of exact rules that can be replaced by a wildcard on their type without changing
+// what is ignored in the analysis: they ignore every resource of the type and no negation applies to the type
+func (f *File) collapsible(idx *index, removed map[int]bool) [][]*rule {
+	groups := make(map[string][]*rule)
+	var types []string
+	for _, r := range f.rules {
+		if removed[r.line] || r.invalid || r.Negated() || !r.literal || len(r.segments) != 2 {
+			continue
+		}
+		ty := unescape(r.segments[0])
+		if _, exist := groups[ty]; !exist {
+			types = append(types, ty)
+		}
+		groups[ty] = append(groups[ty], r)
+	}
+
+	var collapsible [][]*rule
+	for _, ty := range types {
+		group := groups[ty]
+		if len(group) < 2 || !f.collapseIsSafe(ty, group, idx, removed) {
+			continue
+		}
+		collapsible = append(collapsible, group)
+	}
+	return collapsible
+}
+
+func (f *File) collapseIsSafe(ty string, group []*rule, idx *index, removed map[int]bool) bool {
+	for _, r := range f.rules {
+		if !removed[r.line] && !r.invalid && r.Negated() && typeCompatible(r.segments[0], ty) {
+			return false
+		}
+	}
+
+	ignored := make(map[string]bool)
+	for _, r := range group {
+		ignored[unescape(r.body)] = true
+	}
+	for _, path := range idx.resources[ty] {
+		if !ignored[path] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,406 @@
+package driftignore
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/filter"
+	dctlresource "github.com/khulnasoft-lab/driftctl/pkg/resource"
+)
+
+const (
+	IssueInvalidPattern = "invalid-pattern"
+	IssueUnknownType    = "unknown-type"
+	IssueShadowed       = "shadowed"
+	IssueUnused         = "unused"
+)
+
+// Issue is a problem found on a line of a driftignore file
+type Issue struct {
+	Line    int
+	Kind    string
+	Message string
+}
+
+// File is a parsed driftignore file, its lines are kept so it can be rewritten without losing comments
+type File struct {
+	Path  string
+	lines []string
+	rules []*rule
+}
+
+type rule struct {
+	*filter.IgnoreRule
+	// line is the index of the rule in the lines of the file
+	line int
+	// body is the pattern without its negation
+	body     string
+	segments []string
+	literal  bool
+	invalid  bool
+}
+
+// Read parses the driftignore file at the given path
+func Read(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	f := &File{Path: path}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		f.lines = append(f.lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	f.parse()
+	return f, nil
+}
+
+func (f *File) parse() {
+	f.rules = nil
+	for i, line := range f.lines {
+		ignoreRule := filter.ParseIgnoreRule(fmt.Sprintf("%s:%d", f.Path, i+1), line)
+		if ignoreRule == nil {
+			continue
+		}
+		f.rules = append(f.rules, newRule(ignoreRule, i))
+	}
+}
+
+func newRule(ignoreRule *filter.IgnoreRule, line int) *rule {
+	body := strings.TrimPrefix(ignoreRule.Pattern, "!")
+	return &rule{
+		IgnoreRule: ignoreRule,
+		line:       line,
+		body:       body,
+		segments:   splitSegments(body),
+		literal:    !hasWildcard(body),
+	}
+}
+
+// Bytes returns the content of the file
+func (f *File) Bytes() []byte {
+	if len(f.lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(f.lines, "\n") + "\n")
+}
+
+// Lint returns the issues of the file sorted by line. Unused rules are only reported when an analysis is given,
+// it must come from a scan run without the file as ignored resources are left out of analyses.
+func (f *File) Lint(analysis *analyser.Analysis) []Issue {
+	issues := f.validate()
+
+	for i, r := range f.rules {
+		if r.invalid {
+			continue
+		}
+		if message := f.shadowed(i); message != "" {
+			issues = append(issues, Issue{Line: r.line + 1, Kind: IssueShadowed, Message: message})
+		}
+	}
+
+	if analysis != nil {
+		for _, r := range f.unused(newIndex(analysis)) {
+			issues = append(issues, Issue{Line: r.line + 1, Kind: IssueUnused, Message: "matches no resource of the analysis"})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+	return issues
+}
+
+// validate flags the invalid rules, they are left out of the other checks
+func (f *File) validate() []Issue {
+	var issues []Issue
+	for _, r := range f.rules {
+		if issue := validate(r); issue != nil {
+			r.invalid = true
+			issues = append(issues, *issue)
+		}
+	}
+	return issues
+}
+
+func validate(r *rule) *Issue {
+	if _, err := filepath.Match(r.body, ""); err != nil {
+		return &Issue{Line: r.line + 1, Kind: IssueInvalidPattern, Message: fmt.Sprintf("invalid pattern '%s': %s", r.Pattern, err)}
+	}
+	ty := r.segments[0]
+	if ty == "" {
+		return &Issue{Line: r.line + 1, Kind: IssueInvalidPattern, Message: fmt.Sprintf("invalid pattern '%s': missing resource type", r.Pattern)}
+	}
+
+	supportedTypes := dctlresource.GetSupportedTypes()
+	if hasWildcard(ty) {
+		for _, supportedType := range supportedTypes {
+			if typeCompatible(ty, supportedType) {
+				return nil
+			}
+		}
+		return &Issue{Line: r.line + 1, Kind: IssueUnknownType, Message: fmt.Sprintf("'%s' matches no supported resource type", ty)}
+	}
+
+	ty = unescape(ty)
+	if dctlresource.IsResourceTypeSupported(ty) {
+		return nil
+	}
+	message := fmt.Sprintf("unknown resource type '%s'", ty)
+	if suggestion := closest(ty, supportedTypes); suggestion != "" {
+		message += fmt.Sprintf(", did you mean '%s'?", suggestion)
+	}
+	return &Issue{Line: r.line + 1, Kind: IssueUnknownType, Message: message}
+}
+
+// shadowed returns why the i-th rule has no effect, as in gitignore files the last matching rule wins.
+// It is empty when the rule may change whether something is ignored.
+func (f *File) shadowed(i int) string {
+	r := f.rules[i]
+
+	// A later rule matching everything this one does decides instead of it
+	for _, later := range f.rules[i+1:] {
+		if later.invalid || !covers(later, r) {
+			continue
+		}
+		if later.Negated() != r.Negated() {
+			return fmt.Sprintf("overridden by '%s' on line %d, the last matching rule wins", later.Pattern, later.line+1)
+		}
+		// Duplicates are reported on their last occurrence only, by the check below
+		if !covers(r, later) {
+			return fmt.Sprintf("redundant with '%s' on line %d", later.Pattern, later.line+1)
+		}
+	}
+
+	// An earlier rule already gives the same result, unless a rule in between may reverse it
+	for j := i - 1; j >= 0; j-- {
+		earlier := f.rules[j]
+		if earlier.invalid {
+			continue
+		}
+		if earlier.Negated() != r.Negated() {
+			if mayOverlap(earlier, r) {
+				break
+			}
+			continue
+		}
+		if covers(earlier, r) {
+			if r.Negated() {
+				return fmt.Sprintf("already re-included by the negation '%s' on line %d", earlier.Pattern, earlier.line+1)
+			}
+			return fmt.Sprintf("already ignored by '%s' on line %d", earlier.Pattern, earlier.line+1)
+		}
+	}
+
+	// Nothing is ignored before the negation, there is nothing to re-include
+	if r.Negated() {
+		for _, earlier := range f.rules[:i] {
+			if !earlier.invalid && !earlier.Negated() && mayOverlap(earlier, r) {
+				return ""
+			}
+		}
+		return "negation re-includes nothing, no earlier rule ignores what it matches"
+	}
+
+	return ""
+}
+
+func (f *File) unused(idx *index) []*rule {
+	var unused []*rule
+	for _, r := range f.rules {
+		if !r.invalid && !idx.used(r) {
+			unused = append(unused, r)
+		}
+	}
+	return unused
+}
+
+// index groups the resources and drifted fields of an analysis by type
+type index struct {
+	resources map[string][]string
+	fields    map[string][]string
+	types     []string
+}
+
+func newIndex(analysis *analyser.Analysis) *index {
+	idx := &index{resources: map[string][]string{}, fields: map[string][]string{}}
+	for _, resources := range [][]*resource.Resource{analysis.Managed(), analysis.Unmanaged(), analysis.Deleted()} {
+		for _, res := range resources {
+			idx.resources[res.ResourceType()] = append(idx.resources[res.ResourceType()], fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()))
+		}
+	}
+	for _, difference := range analysis.Differences() {
+		for _, change := range difference.Changelog {
+			path := fmt.Sprintf("%s.%s.%s", difference.Res.ResourceType(), difference.Res.ResourceId(), strings.Join(change.Path, "."))
+			idx.fields[difference.Res.ResourceType()] = append(idx.fields[difference.Res.ResourceType()], path)
+		}
+	}
+	for ty := range idx.resources {
+		idx.types = append(idx.types, ty)
+	}
+	sort.Strings(idx.types)
+	return idx
+}
+
+// used returns whether the rule matches a resource or a drifted field. Rules on fields are used as long as their
+// resource exists, as the field may drift again.
+func (idx *index) used(r *rule) bool {
+	var resourcePart *filter.IgnoreRule
+	if len(r.segments) > 2 {
+		resourcePart = filter.ParseIgnoreRule(r.Source, strings.Join(r.segments[:2], "."))
+	}
+
+	for _, ty := range idx.types {
+		if !typeCompatible(r.segments[0], ty) {
+			continue
+		}
+		for _, path := range idx.resources[ty] {
+			if r.Match(path) {
+				return true
+			}
+			if r.literal && strings.HasPrefix(unescape(r.body), path+".") {
+				return true
+			}
+			if resourcePart != nil && resourcePart.Match(path) {
+				return true
+			}
+		}
+		for _, path := range idx.fields[ty] {
+			if r.Match(path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// covers returns whether the rule a matches at least everything b matches
+func covers(a, b *rule) bool {
+	if b.literal {
+		return a.Match(unescape(b.body))
+	}
+	if a.body == "*" || a.body == b.body {
+		return true
+	}
+	prefix := strings.TrimSuffix(a.body, "*")
+	return prefix != a.body && !hasWildcard(prefix) && !strings.HasSuffix(prefix, `\`) && strings.HasPrefix(b.body, prefix)
+}
+
+// mayOverlap returns false when the rules can't match anything in common
+func mayOverlap(a, b *rule) bool {
+	if a.literal && !b.literal {
+		return typeCompatible(b.segments[0], unescape(a.segments[0]))
+	}
+	if !a.literal && b.literal {
+		return typeCompatible(a.segments[0], unescape(b.segments[0]))
+	}
+	if a.literal && b.literal {
+		return a.Match(unescape(b.body)) || b.Match(unescape(a.body))
+	}
+	return true
+}
+
+// typeCompatible returns whether the type segment of a pattern may match the given type. Wildcards may match dots,
+// the segment is only compared up to its first wildcard.
+func typeCompatible(segment, ty string) bool {
+	if !hasWildcard(segment) {
+		return unescape(segment) == ty
+	}
+	return strings.HasPrefix(ty, unescape(segment[:wildcardIndex(segment)]))
+}
+
+// splitSegments splits a pattern on its unescaped dots, escapes are kept in the segments
+func splitSegments(pattern string) []string {
+	var segments []string
+	start := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '.':
+			segments = append(segments, pattern[start:i])
+			start = i + 1
+		}
+	}
+	return append(segments, pattern[start:])
+}
+
+func hasWildcard(pattern string) bool {
+	return wildcardIndex(pattern) < len(pattern)
+}
+
+// wildcardIndex returns the index of the first unescaped wildcard of the pattern, or its length when there is none
+func wildcardIndex(pattern string) int {
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '*', '?', '[':
+			return i
+		}
+	}
+	return len(pattern)
+}
+
+func unescape(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '\\' && i+1 < len(pattern) {
+			i++
+		}
+		b.WriteByte(pattern[i])
+	}
+	return b.String()
+}
+
+// closest returns the candidate nearest to the given type, empty when none is close enough to be a typo
+func closest(ty string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		if distance := levenshtein(ty, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
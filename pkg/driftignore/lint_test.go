@@ -0,0 +1,107 @@
+package driftignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+func fakeAnalysis() *analyser.Analysis {
+	role := &resource.Resource{Id: "my-role", Type: "aws_iam_role"}
+	analysis := analyser.NewAnalysis()
+	analysis.AddManaged(
+		&resource.Resource{Id: "logs", Type: "aws_s3_bucket"},
+		&resource.Resource{Id: "assets", Type: "aws_s3_bucket"},
+		role,
+	)
+	analysis.AddUnmanaged(
+		&resource.Resource{Id: "admin", Type: "aws_iam_user"},
+		&resource.Resource{Id: "bob", Type: "aws_iam_user"},
+		&resource.Resource{Id: "alice", Type: "aws_iam_user"},
+		&resource.Resource{Id: "carol", Type: "aws_iam_user"},
+	)
+	analysis.AddDifference(analyser.Difference{Res: role, Changelog: analyser.Changelog{
+		{Change: diff.Change{Type: diff.UPDATE, Path: []string{"max_session_duration"}, From: 3600, To: 7200}},
+	}})
+	return analysis
+}
+
+func TestFile_Lint(t *testing.T) {
+	syntaxIssues := []Issue{
+		{Line: 4, Kind: IssueUnknownType, Message: "unknown resource type 'aws_s3_buckt', did you mean 'aws_s3_bucket'?"},
+		{Line: 5, Kind: IssueShadowed, Message: "already ignored by 'aws_s3_bucket.logs' on line 2"},
+		{Line: 6, Kind: IssueShadowed, Message: "overridden by 'aws_iam_user.*' on line 7, the last matching rule wins"},
+		{Line: 10, Kind: IssueInvalidPattern, Message: "invalid pattern '\\': syntax error in pattern"},
+		{Line: 11, Kind: IssueInvalidPattern, Message: "invalid pattern '.id': missing resource type"},
+		{Line: 12, Kind: IssueUnknownType, Message: "'aws_foo_*' matches no supported resource type"},
+		{Line: 14, Kind: IssueShadowed, Message: "negation re-includes nothing, no earlier rule ignores what it matches"},
+	}
+
+	cases := []struct {
+		name     string
+		analysis *analyser.Analysis
+		expected []Issue
+	}{
+		{
+			name:     "without analysis",
+			expected: syntaxIssues,
+		},
+		{
+			name:     "with analysis",
+			analysis: fakeAnalysis(),
+			expected: []Issue{
+				syntaxIssues[0],
+				syntaxIssues[1],
+				syntaxIssues[2],
+				{Line: 8, Kind: IssueUnused, Message: "matches no resource of the analysis"},
+				{Line: 9, Kind: IssueUnused, Message: "matches no resource of the analysis"},
+				syntaxIssues[3],
+				syntaxIssues[4],
+				syntaxIssues[5],
+				syntaxIssues[6],
+				{Line: 14, Kind: IssueUnused, Message: "matches no resource of the analysis"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			file, err := Read("testdata/lint.driftignore")
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, file.Lint(c.analysis))
+		})
+	}
+}
+
+func TestFile_Fix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".driftignore")
+	content, err := os.ReadFile("testdata/fix.driftignore")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, content, 0644))
+
+	file, err := Read(path)
+	require.NoError(t, err)
+	result, err := file.Fix(fakeAnalysis())
+	require.NoError(t, err)
+	assert.Equal(t, FixResult{Removed: 1, Collapsed: 2, Wildcards: 1}, result)
+
+	expected, err := os.ReadFile("testdata/fix.golden.driftignore")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(file.Bytes()))
+	assert.Empty(t, file.Lint(fakeAnalysis()))
+}
+
+func TestFile_FixWithoutMatchingRule(t *testing.T) {
+	file, err := Read("testdata/fix.driftignore")
+	require.NoError(t, err)
+
+	_, err = file.Fix(analyser.NewAnalysis())
+	assert.EqualError(t, err, "no rule of testdata/fix.driftignore matches the analysis, make sure it comes from a scan run without the driftignore file (e.g. --driftignore /dev/null)")
+}
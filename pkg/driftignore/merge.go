@@ -0,0 +1,100 @@
+package driftignore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/filter"
+)
+
+// New returns an empty driftignore file to be written at the given path
+func New(path string) *File {
+	return &File{Path: path}
+}
+
+// Empty returns whether the file has no line at all
+func (f *File) Empty() bool {
+	return len(f.lines) == 0
+}
+
+// Comment adds a comment line at the end of the file
+func (f *File) Comment(text string) {
+	f.lines = append(f.lines, fmt.Sprintf("# %s", text))
+}
+
+// Merge adds the rules of the sections the file is missing and returns how many were added. A rule is missing when no
+// rule of the file matches what it ignores, as an existing match either ignores it already or re-includes it on
+// purpose. Rules are added at the end of the section of their type, sections missing from the file at its end.
+func (f *File) Merge(sections []analyser.DriftIgnoreSection) int {
+	added := 0
+	for _, section := range sections {
+		rules := f.missing(section)
+		if len(rules) == 0 {
+			continue
+		}
+		added += len(rules)
+
+		if end, found := f.sectionEnd(section.Type); found {
+			lines := make([]string, 0, len(f.lines)+len(rules))
+			lines = append(lines, f.lines[:end]...)
+			lines = append(lines, rules...)
+			f.lines = append(lines, f.lines[end:]...)
+		} else {
+			f.Comment(section.Type)
+			f.lines = append(f.lines, rules...)
+		}
+		f.parse()
+	}
+	return added
+}
+
+func (f *File) missing(section analyser.DriftIgnoreSection) []string {
+	if section.Wildcard {
+		wildcard := newRule(filter.ParseIgnoreRule("", fmt.Sprintf("%s.*", section.Type)), -1)
+		negated := false
+		for _, r := range f.rules {
+			if covers(r, wildcard) {
+				return nil
+			}
+			negated = negated || (r.Negated() && mayOverlap(r, wildcard))
+		}
+		// Added after them, the wildcard would override the negations of the type
+		if !negated {
+			return []string{wildcard.Pattern}
+		}
+	}
+
+	var missing []string
+	for _, pattern := range section.Rules {
+		path := unescape(pattern)
+		matched := false
+		for _, r := range f.rules {
+			if r.Match(path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			missing = append(missing, pattern)
+		}
+	}
+	return missing
+}
+
+// sectionEnd returns the index following the last rule of the section of the given type. A section starts with a
+// comment holding the type and ends on the next empty line or comment.
+func (f *File) sectionEnd(ty string) (int, bool) {
+	header := fmt.Sprintf("# %s", ty)
+	for i, line := range f.lines {
+		if strings.TrimSpace(line) != header {
+			continue
+		}
+		end := i + 1
+		for end < len(f.lines) && strings.TrimSpace(f.lines[end]) != "" && !strings.HasPrefix(f.lines[end], "#") {
+			end++
+		}
+		return end, true
+	}
+	return 0, false
+}
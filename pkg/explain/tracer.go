@@ -0,0 +1,302 @@
+package explain
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/filter"
+	"github.com/khulnasoft-lab/driftctl/pkg/middlewares"
+)
+
+const (
+	sideRemote = "cloud"
+	sideIaC    = "IaC"
+)
+
+// Target is a resource to explain, written TYPE.ID on the command line
+type Target struct {
+	Type string
+	Id   string
+}
+
+// ParseTarget parses resources written TYPE.ID, the id may contain dots
+func ParseTarget(value string) (Target, error) {
+	parts := strings.SplitN(value, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Target{}, errors.Errorf("invalid resource '%s', expected TYPE.ID (e.g. aws_iam_role.my-role)", value)
+	}
+	return Target{Type: parts[0], Id: parts[1]}, nil
+}
+
+func (t Target) String() string {
+	return fmt.Sprintf("%s.%s", t.Type, t.Id)
+}
+
+// snapshot is the state of a traced resource on one side of the scan
+type snapshot struct {
+	present    bool
+	attributes map[string]interface{}
+}
+
+type trace struct {
+	target Target
+	steps  []analyser.ExplanationStep
+	// seen is true when the resource was present on any side at any stage
+	seen bool
+	// snapshots of the traced resource, by side
+	snapshots map[string]snapshot
+}
+
+func (t *trace) record(stage, format string, args ...interface{}) {
+	t.steps = append(t.steps, analyser.ExplanationStep{Stage: stage, Message: fmt.Sprintf(format, args...)})
+}
+
+// Tracer records the decisions taken on a set of resources at each stage of a scan
+type Tracer struct {
+	traces      []*trace
+	enumerators map[string]string
+	driftIgnore *filter.DriftIgnore
+}
+
+func NewTracer(targets []Target, enumerators []common.Enumerator, driftIgnore *filter.DriftIgnore) *Tracer {
+	t := &Tracer{
+		traces:      make([]*trace, 0, len(targets)),
+		enumerators: make(map[string]string),
+		driftIgnore: driftIgnore,
+	}
+	for _, target := range targets {
+		t.traces = append(t.traces, &trace{target: target, snapshots: make(map[string]snapshot)})
+	}
+	for _, enumerator := range enumerators {
+		t.enumerators[enumerator.SupportedType().String()] = strings.TrimPrefix(fmt.Sprintf("%T", enumerator), "*")
+	}
+	return t
+}
+
+// Scanned records how traced resources were found on the cloud provider and declared in IaC
+func (t *Tracer) Scanned(remoteResources, resourcesFromState []*resource.Resource) {
+	for _, tr := range t.traces {
+		enumerator, supported := t.enumerators[tr.target.Type]
+		switch {
+		case find(remoteResources, tr.target) != nil && !supported:
+			tr.record(analyser.ExplanationStageEnumeration, "found on the cloud provider")
+		case find(remoteResources, tr.target) != nil:
+			tr.record(analyser.ExplanationStageEnumeration, "found on the cloud provider by %s", enumerator)
+		case t.driftIgnore != nil && t.driftIgnore.IsTypeIgnored(resource.ResourceType(tr.target.Type)):
+			tr.record(analyser.ExplanationStageEnumeration, "not enumerated, every %s resource is ignored by the driftignore rules", tr.target.Type)
+		case !supported:
+			tr.record(analyser.ExplanationStageEnumeration, "not enumerated, no enumerator supports %s for this cloud provider", tr.target.Type)
+		default:
+			tr.record(analyser.ExplanationStageEnumeration, "not found on the cloud provider by %s", enumerator)
+		}
+
+		declared := false
+		for _, res := range resourcesFromState {
+			if !matches(res, tr.target) {
+				continue
+			}
+			declared = true
+			if res.Src() == nil {
+				tr.record(analyser.ExplanationStageIaC, "declared in IaC")
+				continue
+			}
+			tr.record(analyser.ExplanationStageIaC, "declared in %s as %s", res.Src().Source(), res.SourceString())
+		}
+		if !declared {
+			tr.record(analyser.ExplanationStageIaC, "not declared in any IaC source")
+		}
+
+		tr.snapshots[sideRemote] = takeSnapshot(remoteResources, tr.target)
+		tr.snapshots[sideIaC] = takeSnapshot(resourcesFromState, tr.target)
+		tr.seen = tr.snapshots[sideRemote].present || tr.snapshots[sideIaC].present
+	}
+}
+
+// MiddlewareExecuted records traced resources created, removed or rewritten by a middleware
+func (t *Tracer) MiddlewareExecuted(middleware middlewares.Middleware, remoteResources, resourcesFromState []*resource.Resource) {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", middleware), "*")
+	for _, tr := range t.traces {
+		for _, side := range []struct {
+			name      string
+			resources []*resource.Resource
+		}{{sideRemote, remoteResources}, {sideIaC, resourcesFromState}} {
+			before := tr.snapshots[side.name]
+			after := takeSnapshot(side.resources, tr.target)
+			tr.snapshots[side.name] = after
+			tr.seen = tr.seen || after.present
+
+			switch {
+			case !before.present && after.present:
+				tr.record(analyser.ExplanationStageMiddleware, "%s created the %s resource", name, side.name)
+			case before.present && !after.present:
+				tr.record(analyser.ExplanationStageMiddleware, "%s removed the %s resource", name, side.name)
+			case before.present && after.present:
+				if changed := changedAttributes(before.attributes, after.attributes); len(changed) > 0 {
+					tr.record(analyser.ExplanationStageMiddleware, "%s rewrote attributes of the %s resource: %s", name, side.name, strings.Join(changed, ", "))
+				}
+			}
+		}
+	}
+}
+
+// Filtered records traced resources excluded by the --filter expression
+func (t *Tracer) Filtered(remoteResources, resourcesFromState []*resource.Resource) {
+	for _, tr := range t.traces {
+		for _, side := range []struct {
+			name      string
+			resources []*resource.Resource
+		}{{sideRemote, remoteResources}, {sideIaC, resourcesFromState}} {
+			after := takeSnapshot(side.resources, tr.target)
+			if tr.snapshots[side.name].present && !after.present {
+				tr.record(analyser.ExplanationStageFilter, "%s resource excluded by the --filter expression", side.name)
+			}
+			tr.snapshots[side.name] = after
+		}
+	}
+}
+
+// Analyzed records the driftignore rules and alerts ignoring traced resources, then the category they are reported in,
+// explanations are attached to the analysis
+func (t *Tracer) Analyzed(analysis *analyser.Analysis) {
+	explanations := make([]analyser.Explanation, 0, len(t.traces))
+	for _, tr := range t.traces {
+		res := &resource.Resource{Type: tr.target.Type, Id: tr.target.Id}
+		present := tr.snapshots[sideRemote].present || tr.snapshots[sideIaC].present
+		ignored := false
+
+		if present && t.driftIgnore != nil {
+			if rule, ignoredByRule := t.driftIgnore.MatchingRule(res); rule != nil {
+				ignored = ignoredByRule
+				if ignoredByRule {
+					tr.record(analyser.ExplanationStageDriftignore, "ignored by %s: %s", rule.Source, rule.Pattern)
+				} else {
+					tr.record(analyser.ExplanationStageDriftignore, "kept by %s: %s", rule.Source, rule.Pattern)
+				}
+			}
+		}
+
+		if present {
+			for _, key := range []string{tr.target.String(), tr.target.Type} {
+				for _, alert := range analysis.Alerts()[key] {
+					if alert.ShouldIgnoreResource() {
+						ignored = true
+						tr.record(analyser.ExplanationStageAlert, "ignored because of the alert: %s", alert.Message())
+					}
+				}
+			}
+		}
+
+		for _, duplicate := range analysis.Duplicates() {
+			if matches(duplicate.Res, tr.target) {
+				tr.record(analyser.ExplanationStageAnalysis, "claimed by %d IaC resources", len(duplicate.Sources))
+			}
+		}
+
+		explanations = append(explanations, analyser.Explanation{
+			Type:    tr.target.Type,
+			Id:      tr.target.Id,
+			Steps:   tr.steps,
+			Outcome: outcome(analysis, tr, present, ignored),
+		})
+	}
+	analysis.SetExplanations(explanations)
+}
+
+func outcome(analysis *analyser.Analysis, tr *trace, present, ignored bool) string {
+	target := tr.target
+	for _, difference := range analysis.Differences() {
+		if matches(difference.Res, target) {
+			return fmt.Sprintf("reported as managed with %d drifted attribute(s)", len(difference.Changelog))
+		}
+	}
+	switch {
+	case find(analysis.Managed(), target) != nil:
+		return "reported as managed, the cloud resource matches its IaC declaration"
+	case find(analysis.Unmanaged(), target) != nil:
+		return "reported as unmanaged, no IaC resource matches the cloud resource"
+	case find(analysis.Deleted(), target) != nil:
+		return "reported as missing, no cloud resource matches the IaC declaration"
+	case !tr.seen:
+		return "not reported, the resource is neither on the cloud provider nor in IaC"
+	case !present:
+		return "not reported, the resource was removed before the analysis"
+	case ignored:
+		return "not reported, the resource is ignored"
+	}
+	return "not reported"
+}
+
+func matches(res *resource.Resource, target Target) bool {
+	return res.ResourceType() == target.Type && res.ResourceId() == target.Id
+}
+
+func find(resources []*resource.Resource, target Target) *resource.Resource {
+	for _, res := range resources {
+		if matches(res, target) {
+			return res
+		}
+	}
+	return nil
+}
+
+func takeSnapshot(resources []*resource.Resource, target Target) snapshot {
+	res := find(resources, target)
+	if res == nil {
+		return snapshot{}
+	}
+	s := snapshot{present: true, attributes: map[string]interface{}{}}
+	if res.Attributes() != nil {
+		for key, value := range *res.Attributes() {
+			s.attributes[key] = deepCopy(value)
+		}
+	}
+	return s
+}
+
+// deepCopy copies nested attributes, middlewares may rewrite them in place
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			c[key] = deepCopy(item)
+		}
+		return c
+	case resource.Attributes:
+		c := make(resource.Attributes, len(v))
+		for key, item := range v {
+			c[key] = deepCopy(item)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, item := range v {
+			c[i] = deepCopy(item)
+		}
+		return c
+	}
+	return value
+}
+
+func changedAttributes(before, after map[string]interface{}) []string {
+	changed := make([]string, 0)
+	for key, value := range before {
+		if other, exist := after[key]; !exist || !reflect.DeepEqual(value, other) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range after {
+		if _, exist := before[key]; !exist {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
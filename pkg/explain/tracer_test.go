@@ -0,0 +1,134 @@
+package explain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/alerter"
+	"github.com/khulnasoft-lab/driftctl/enumeration/remote/common"
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/filter"
+	"github.com/khulnasoft-lab/driftctl/pkg/middlewares"
+)
+
+type removeRemoteRole struct{}
+
+func (m removeRemoteRole) Execute(remoteResources, resourcesFromState *[]*resource.Resource) error {
+	kept := make([]*resource.Resource, 0)
+	for _, res := range *remoteResources {
+		if res.ResourceType() != "aws_iam_role" {
+			kept = append(kept, res)
+		}
+	}
+	*remoteResources = kept
+	return nil
+}
+
+type rewriteBucket struct{}
+
+func (m rewriteBucket) Execute(remoteResources, resourcesFromState *[]*resource.Resource) error {
+	for _, res := range *resourcesFromState {
+		if res.ResourceType() == "aws_s3_bucket" {
+			_ = res.Attributes().SafeSet([]string{"acl"}, "private")
+		}
+	}
+	return nil
+}
+
+func TestParseTarget(t *testing.T) {
+	target, err := ParseTarget("aws_s3_bucket.my.bucket")
+	assert.NoError(t, err)
+	assert.Equal(t, Target{Type: "aws_s3_bucket", Id: "my.bucket"}, target)
+
+	_, err = ParseTarget("aws_s3_bucket.")
+	assert.EqualError(t, err, "invalid resource 'aws_s3_bucket.', expected TYPE.ID (e.g. aws_iam_role.my-role)")
+}
+
+func TestTracer(t *testing.T) {
+	roleEnumerator := &common.MockEnumerator{}
+	roleEnumerator.On("SupportedType").Return(resource.ResourceType("aws_iam_role"))
+	bucketEnumerator := &common.MockEnumerator{}
+	bucketEnumerator.On("SupportedType").Return(resource.ResourceType("aws_s3_bucket"))
+
+	tracer := NewTracer(
+		[]Target{
+			{Type: "aws_iam_role", Id: "admin"},
+			{Type: "aws_s3_bucket", Id: "bucket"},
+			{Type: "aws_s3_bucket", Id: "ignored"},
+			{Type: "aws_sqs_queue", Id: "queue"},
+		},
+		[]common.Enumerator{roleEnumerator, bucketEnumerator},
+		filter.NewDriftIgnore("", "aws_s3_bucket.ignored"),
+	)
+
+	bucket := &resource.Resource{Type: "aws_s3_bucket", Id: "bucket", Attrs: &resource.Attributes{}}
+	stateBucket := &resource.Resource{
+		Type:   "aws_s3_bucket",
+		Id:     "bucket",
+		Attrs:  &resource.Attributes{},
+		Source: resource.NewTerraformStateSource("tfstate://terraform.tfstate", "module.storage", "bucket"),
+	}
+	ignored := &resource.Resource{Type: "aws_s3_bucket", Id: "ignored", Attrs: &resource.Attributes{}}
+	remoteResources := []*resource.Resource{
+		{Type: "aws_iam_role", Id: "admin", Attrs: &resource.Attributes{}},
+		bucket,
+		ignored,
+	}
+	resourcesFromState := []*resource.Resource{stateBucket}
+
+	tracer.Scanned(remoteResources, resourcesFromState)
+	for _, middleware := range []middlewares.Middleware{removeRemoteRole{}, rewriteBucket{}} {
+		assert.NoError(t, middleware.Execute(&remoteResources, &resourcesFromState))
+		tracer.MiddlewareExecuted(middleware, remoteResources, resourcesFromState)
+	}
+	tracer.Filtered(remoteResources, resourcesFromState)
+
+	analysis := analyser.NewAnalysis()
+	analysis.AddManaged(stateBucket)
+	analysis.SetAlerts(alerter.Alerts{})
+	tracer.Analyzed(analysis)
+
+	assert.Equal(t, []analyser.Explanation{
+		{
+			Type: "aws_iam_role",
+			Id:   "admin",
+			Steps: []analyser.ExplanationStep{
+				{Stage: "enumeration", Message: "found on the cloud provider by common.MockEnumerator"},
+				{Stage: "iac", Message: "not declared in any IaC source"},
+				{Stage: "middleware", Message: "explain.removeRemoteRole removed the cloud resource"},
+			},
+			Outcome: "not reported, the resource was removed before the analysis",
+		},
+		{
+			Type: "aws_s3_bucket",
+			Id:   "bucket",
+			Steps: []analyser.ExplanationStep{
+				{Stage: "enumeration", Message: "found on the cloud provider by common.MockEnumerator"},
+				{Stage: "iac", Message: "declared in tfstate://terraform.tfstate as module.storage.aws_s3_bucket.bucket"},
+				{Stage: "middleware", Message: "explain.rewriteBucket rewrote attributes of the IaC resource: acl"},
+			},
+			Outcome: "reported as managed, the cloud resource matches its IaC declaration",
+		},
+		{
+			Type: "aws_s3_bucket",
+			Id:   "ignored",
+			Steps: []analyser.ExplanationStep{
+				{Stage: "enumeration", Message: "found on the cloud provider by common.MockEnumerator"},
+				{Stage: "iac", Message: "not declared in any IaC source"},
+				{Stage: "driftignore", Message: "ignored by --ignore #1: aws_s3_bucket.ignored"},
+			},
+			Outcome: "not reported, the resource is ignored",
+		},
+		{
+			Type: "aws_sqs_queue",
+			Id:   "queue",
+			Steps: []analyser.ExplanationStep{
+				{Stage: "enumeration", Message: "not enumerated, no enumerator supports aws_sqs_queue for this cloud provider"},
+				{Stage: "iac", Message: "not declared in any IaC source"},
+			},
+			Outcome: "not reported, the resource is neither on the cloud provider nor in IaC",
+		},
+	}, analysis.Explanations())
+}
@@ -17,6 +17,15 @@ type DriftIgnore struct {
 	driftignorePath string
 	ignorePatterns  []string
 	matcher         gitignore.Matcher
+	rules           []*IgnoreRule
+}
+
+// IgnoreRule is a line of the driftignore file, or a pattern given with --ignore
+type IgnoreRule struct {
+	// Source locates the rule, e.g. .driftignore:3 or --ignore #2
+	Source   string
+	Pattern  string
+	patterns []gitignore.Pattern
 }
 
 func NewDriftIgnore(path string, ignorePatterns ...string) *DriftIgnore {
@@ -49,7 +58,7 @@ func (r *DriftIgnore) readIgnoreFile() error {
 	scanner := bufio.NewScanner(file)
 	for lineNumber := 1; scanner.Scan(); lineNumber++ {
 		line := scanner.Text()
-		r.parseIgnorePattern(line, &lines)
+		r.parseIgnorePattern(fmt.Sprintf("%s:%d", r.driftignorePath, lineNumber), line, &lines)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -63,28 +72,56 @@ func (r *DriftIgnore) readIgnoreFile() error {
 
 func (r *DriftIgnore) parseIgnorePatterns() error {
 	var lines []gitignore.Pattern
-	for _, p := range r.ignorePatterns {
-		r.parseIgnorePattern(p, &lines)
+	for i, p := range r.ignorePatterns {
+		r.parseIgnorePattern(fmt.Sprintf("--ignore #%d", i+1), p, &lines)
 	}
 	r.matcher = gitignore.NewMatcher(lines)
 	return nil
 }
 
-func (r *DriftIgnore) parseIgnorePattern(line string, patterns *[]gitignore.Pattern) {
+func (r *DriftIgnore) parseIgnorePattern(source, line string, patterns *[]gitignore.Pattern) {
+	rule := ParseIgnoreRule(source, line)
+	if rule == nil {
+		return
+	}
+	*patterns = append(*patterns, rule.patterns...)
+	r.rules = append(r.rules, rule)
+}
+
+// ParseIgnoreRule parses a line of a driftignore file, it returns nil for empty lines and comments
+func ParseIgnoreRule(source, line string) *IgnoreRule {
 	if len(strings.ReplaceAll(line, " ", "")) <= 0 {
-		return // empty
+		return nil // empty
 	}
 
 	if strings.HasPrefix(line, "#") {
-		return // this is a comment
+		return nil // this is a comment
 	}
+	rule := &IgnoreRule{Source: source, Pattern: line}
 	line = strings.ReplaceAll(line, "/", separator)
 
-	*patterns = append(*patterns, gitignore.ParsePattern(line, nil))
+	rule.patterns = append(rule.patterns, gitignore.ParsePattern(line, nil))
 	if !strings.HasSuffix(line, "*") {
 		line := fmt.Sprintf("%s.*", line)
-		*patterns = append(*patterns, gitignore.ParsePattern(line, nil))
+		rule.patterns = append(rule.patterns, gitignore.ParsePattern(line, nil))
 	}
+	return rule
+}
+
+// Negated returns whether the rule re-includes what it matches, i.e. its pattern starts with a `!`
+func (r *IgnoreRule) Negated() bool {
+	return strings.HasPrefix(r.Pattern, "!")
+}
+
+// Match returns whether the rule matches a resource (TYPE.ID) or one of its fields (TYPE.ID.PATH), negated or not
+func (r *IgnoreRule) Match(path string) bool {
+	p := []string{strings.ReplaceAll(path, "/", separator)}
+	for _, pattern := range r.patterns {
+		if pattern.Match(p, false) != gitignore.NoMatch {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *DriftIgnore) isAnyOfChildrenTypesNotIgnored(ty resource.ResourceType) bool {
@@ -125,6 +162,25 @@ func (r *DriftIgnore) IsResourceIgnored(res *resource.Resource) bool {
 	return r.match(fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()))
 }
 
+func (r *DriftIgnore) IsFieldIgnored(res *resource.Resource, path []string) bool {
+	return r.match(fmt.Sprintf("%s.%s.%s", res.ResourceType(), res.ResourceId(), strings.Join(path, ".")))
+}
+
+// MatchingRule returns the rule deciding whether the resource is ignored, as in gitignore files the last matching
+// rule wins. A nil rule means no rule matches the resource.
+func (r *DriftIgnore) MatchingRule(res *resource.Resource) (rule *IgnoreRule, ignored bool) {
+	path := []string{strings.ReplaceAll(fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()), "/", separator)}
+	for _, candidate := range r.rules {
+		for _, pattern := range candidate.patterns {
+			if result := pattern.Match(path, false); result != gitignore.NoMatch {
+				rule = candidate
+				ignored = result == gitignore.Exclude
+			}
+		}
+	}
+	return rule, ignored
+}
+
 func (r *DriftIgnore) match(strRes string) bool {
 	return r.matcher.Match([]string{strings.ReplaceAll(strRes, "/", separator)}, false)
 }
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -491,3 +492,151 @@ func TestDriftIgnore_IsTypeIgnored(t *testing.T) {
 		})
 	}
 }
+
+func TestDriftIgnore_IsFieldIgnored(t *testing.T) {
+
+	type Args struct {
+		Res  *resource.Resource
+		Path []string
+		Want bool
+	}
+
+	tests := []struct {
+		name string
+		args []Args
+		path string
+	}{
+		{
+			name: "drift_ignore_no_file",
+			args: []Args{
+				{
+					Res:  &resource.Resource{Type: "type1", Id: "id1"},
+					Path: []string{"Id"},
+					Want: false,
+				},
+			},
+			path: "testdata/drift_ignore_no_file/.driftignore",
+		},
+		{
+			name: "drift_ignore_fields",
+			args: []Args{
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "full_drift_ignored"},
+					Path: []string{"json"},
+					Want: true,
+				},
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "full_drift_ignored"},
+					Path: []string{"foobar"},
+					Want: true,
+				},
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "partial_drift_ignored"},
+					Path: []string{"json"},
+					Want: false,
+				},
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "partial_drift_ignored"},
+					Path: []string{"foobar"},
+					Want: true,
+				},
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "wildcard_drift_ignored"},
+					Path: []string{"struct", "baz"},
+					Want: true,
+				},
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "wildcard_drift_ignored"},
+					Path: []string{"struct", "bar"},
+					Want: false,
+				},
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "endofpath_drift_ignored"},
+					Path: []string{"struct", "baz"},
+					Want: true,
+				},
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "endofpath_drift_ignored"},
+					Path: []string{"struct", "bar"},
+					Want: true,
+				},
+				{
+					Res:  &resource.Resource{Type: "resource_type", Id: "id.with.dots"},
+					Path: []string{"json"},
+					Want: true,
+				},
+				{
+					Res:  &resource.Resource{Type: "resource_type", Id: "id.with.dots"},
+					Path: []string{"foobar"},
+					Want: false,
+				},
+				{
+					Res:  &resource.Resource{Type: "resource_type", Id: "idwith\\"},
+					Path: []string{"json"},
+					Want: true,
+				},
+				{
+					Res:  &resource.Resource{Type: "resource_type", Id: "idwith\\backslashes"},
+					Path: []string{"foobar"},
+					Want: true,
+				},
+				{
+					Res:  &resource.Resource{Type: "resource_type", Id: "idwith\\backslashes"},
+					Path: []string{"json"},
+					Want: false,
+				},
+			},
+			path: "testdata/drift_ignore_fields/.driftignore",
+		},
+		{
+			name: "drift_ignore_all_exclude_field",
+			args: []Args{
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "id"},
+					Path: []string{"bar"},
+					Want: false,
+				},
+				{
+					Res:  &resource.Resource{Type: "res_type", Id: "id"},
+					Path: []string{"baz"},
+					Want: true,
+				},
+			},
+			path: "testdata/drift_ignore_all_exclude_field/.driftignore",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewDriftIgnore(tt.path)
+			for _, arg := range tt.args {
+				got := r.IsFieldIgnored(arg.Res, arg.Path)
+				if arg.Want != got {
+					t.Errorf("%s.%s.%s expected %v got %v", arg.Res.ResourceType(), arg.Res.ResourceId(), strings.Join(arg.Path, "."), arg.Want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDriftIgnore_MatchingRule(t *testing.T) {
+	r := NewDriftIgnore("testdata/drift_ignore_all_exclude/.driftignore")
+
+	rule, ignored := r.MatchingRule(&resource.Resource{Type: "aws_s3_bucket", Id: "bucket"})
+	assert.True(t, ignored)
+	assert.Equal(t, "testdata/drift_ignore_all_exclude/.driftignore:1", rule.Source)
+	assert.Equal(t, "*", rule.Pattern)
+
+	rule, ignored = r.MatchingRule(&resource.Resource{Type: "iam_user", Id: "user"})
+	assert.False(t, ignored)
+	assert.Equal(t, "testdata/drift_ignore_all_exclude/.driftignore:2", rule.Source)
+	assert.Equal(t, "!iam_user.*", rule.Pattern)
+
+	r = NewDriftIgnore("", "aws_iam_role.admin")
+	rule, ignored = r.MatchingRule(&resource.Resource{Type: "aws_iam_role", Id: "admin"})
+	assert.True(t, ignored)
+	assert.Equal(t, "--ignore #1", rule.Source)
+
+	rule, ignored = r.MatchingRule(&resource.Resource{Type: "aws_iam_role", Id: "other"})
+	assert.Nil(t, rule)
+	assert.False(t, ignored)
+}
@@ -33,7 +33,10 @@ func (e *FilterEngine) Run(resources []*resource.Resource) ([]*resource.Resource
 		// We need to serialize all attributes to untyped interface from JMESPath to work
 		// map[string]string and map[string]SomeThing will not work without it
 		// https://github.com/jmespath/go-jmespath/issues/22
-		var attrs map[string]interface{} = *res.Attributes()
+		attrs := map[string]interface{}{}
+		if res.Attributes() != nil {
+			attrs = *res.Attributes()
+		}
 
 		f := filtrableResource{
 			Attr: attrs,
@@ -12,6 +12,20 @@ type MockFilter struct {
 	mock.Mock
 }
 
+// IsFieldIgnored provides a mock function with given fields: res, path
+func (_m *MockFilter) IsFieldIgnored(res *resource.Resource, path []string) bool {
+	ret := _m.Called(res, path)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*resource.Resource, []string) bool); ok {
+		r0 = rf(res, path)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // IsResourceIgnored provides a mock function with given fields: res
 func (_m *MockFilter) IsResourceIgnored(res *resource.Resource) bool {
 	ret := _m.Called(res)
@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var statusColors = map[Status]string{
+	StatusManaged:   "#c8e6c9",
+	StatusUnmanaged: "#ffe0b2",
+	StatusMissing:   "#ffcdd2",
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language, nodes are colored according to their status
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph driftctl {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\"];\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [label=\"%s\\n%s\", tooltip=%s, fillcolor=%s];\n",
+			quote(node.Key),
+			escape(node.Type),
+			escape(node.Id),
+			quote(string(node.Status)),
+			quote(statusColors[node.Status]),
+		)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", quote(edge.From), quote(edge.To), quote(edge.Attribute))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func quote(s string) string {
+	return fmt.Sprintf("\"%s\"", escape(s))
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,225 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+type Status string
+
+const (
+	StatusManaged   Status = "managed"
+	StatusUnmanaged Status = "unmanaged"
+	StatusMissing   Status = "missing"
+)
+
+// referenceAttributes are well known attributes holding the id, or the ARN, of another resource
+var referenceAttributes = map[string][]string{
+	"allocation_id":            {"aws_eip"},
+	"bucket":                   {"aws_s3_bucket"},
+	"function_name":            {"aws_lambda_function"},
+	"gateway_id":               {"aws_internet_gateway", "aws_nat_gateway"},
+	"group":                    {"aws_iam_group"},
+	"instance":                 {"aws_instance"},
+	"instance_id":              {"aws_instance"},
+	"kms_key_id":               {"aws_kms_key"},
+	"load_balancer_arn":        {"aws_lb", "aws_alb"},
+	"nat_gateway_id":           {"aws_nat_gateway"},
+	"network_acl_id":           {"aws_network_acl", "aws_default_network_acl"},
+	"policy_arn":               {"aws_iam_policy"},
+	"role":                     {"aws_iam_role"},
+	"roles":                    {"aws_iam_role"},
+	"route_table_id":           {"aws_route_table", "aws_default_route_table"},
+	"security_group_id":        {"aws_security_group", "aws_default_security_group"},
+	"security_groups":          {"aws_security_group", "aws_default_security_group"},
+	"source_security_group_id": {"aws_security_group", "aws_default_security_group"},
+	"subnet_id":                {"aws_subnet", "aws_default_subnet"},
+	"subnet_ids":               {"aws_subnet", "aws_default_subnet"},
+	"target_key_id":            {"aws_kms_key"},
+	"user":                     {"aws_iam_user"},
+	"users":                    {"aws_iam_user"},
+	"vpc_id":                   {"aws_vpc", "aws_default_vpc"},
+	"vpc_security_group_ids":   {"aws_security_group", "aws_default_security_group"},
+	"zone_id":                  {"aws_route53_zone"},
+}
+
+// Graph holds every scanned resource and the relationships between them
+type Graph struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []*Edge `json:"edges"`
+}
+
+type Node struct {
+	Key    string `json:"key"`
+	Id     string `json:"id"`
+	Type   string `json:"type"`
+	Status Status `json:"status"`
+	Source string `json:"source,omitempty"`
+}
+
+// Edge goes from a parent resource to a resource referencing it.
+// Attribute is the attribute of the child holding the reference.
+type Edge struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Attribute string `json:"attribute"`
+}
+
+type builder struct {
+	graph *Graph
+	// index nodes by type, then by id and ARN
+	index map[string]map[string]*Node
+	edges map[[2]string]struct{}
+}
+
+// Build creates the relationship graph of the resources of the analysis.
+// Edges are derived from the children types of resource metadata and from well known reference attributes.
+func Build(analysis *analyser.Analysis) *Graph {
+	b := &builder{
+		graph: &Graph{Nodes: []*Node{}, Edges: []*Edge{}},
+		index: make(map[string]map[string]*Node),
+		edges: make(map[[2]string]struct{}),
+	}
+
+	resources := make([]*resource.Resource, 0)
+	groups := []struct {
+		resources []*resource.Resource
+		status    Status
+	}{
+		{analysis.Managed(), StatusManaged},
+		{analysis.Unmanaged(), StatusUnmanaged},
+		{analysis.Deleted(), StatusMissing},
+	}
+	for _, group := range groups {
+		for _, res := range group.resources {
+			b.addNode(res, group.status)
+			resources = append(resources, res)
+		}
+	}
+
+	parents := parentTypes(resources)
+	for _, res := range resources {
+		b.addEdges(res, parents[res.ResourceType()])
+	}
+
+	sort.Slice(b.graph.Nodes, func(i, j int) bool {
+		return b.graph.Nodes[i].Key < b.graph.Nodes[j].Key
+	})
+	sort.Slice(b.graph.Edges, func(i, j int) bool {
+		if b.graph.Edges[i].From != b.graph.Edges[j].From {
+			return b.graph.Edges[i].From < b.graph.Edges[j].From
+		}
+		return b.graph.Edges[i].To < b.graph.Edges[j].To
+	})
+
+	return b.graph
+}
+
+func (b *builder) addNode(res *resource.Resource, status Status) {
+	node := &Node{
+		Key:    nodeKey(res),
+		Id:     res.ResourceId(),
+		Type:   res.ResourceType(),
+		Status: status,
+	}
+	if res.Src() != nil {
+		node.Source = res.Src().Source()
+	}
+	b.graph.Nodes = append(b.graph.Nodes, node)
+
+	if _, exist := b.index[node.Type]; !exist {
+		b.index[node.Type] = make(map[string]*Node)
+	}
+	b.index[node.Type][node.Id] = node
+	if res.Attributes() != nil {
+		if arn, exist := res.Attributes().Get("arn"); exist {
+			if arn, ok := arn.(string); ok && arn != "" {
+				b.index[node.Type][arn] = node
+			}
+		}
+	}
+}
+
+func (b *builder) addEdges(res *resource.Resource, parents []string) {
+	if res.Attributes() == nil {
+		return
+	}
+	key := nodeKey(res)
+
+	attributes := make([]string, 0, len(*res.Attributes()))
+	for attribute := range *res.Attributes() {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	for _, attribute := range attributes {
+		candidates := append(append([]string{}, referenceAttributes[attribute]...), parents...)
+		if len(candidates) == 0 {
+			continue
+		}
+		for _, value := range stringValues((*res.Attributes())[attribute]) {
+			for _, ty := range candidates {
+				parent, exist := b.index[ty][value]
+				if !exist || parent.Key == key {
+					continue
+				}
+				b.addEdge(parent.Key, key, attribute)
+			}
+		}
+	}
+}
+
+func (b *builder) addEdge(from, to, attribute string) {
+	if _, exist := b.edges[[2]string{from, to}]; exist {
+		return
+	}
+	b.edges[[2]string{from, to}] = struct{}{}
+	b.graph.Edges = append(b.graph.Edges, &Edge{From: from, To: to, Attribute: attribute})
+}
+
+// parentTypes returns, for each scanned resource type, the scanned types declaring it as a child in their metadata
+func parentTypes(resources []*resource.Resource) map[string][]string {
+	types := make(map[string]struct{})
+	for _, res := range resources {
+		types[res.ResourceType()] = struct{}{}
+	}
+
+	parents := make(map[string][]string)
+	for ty := range types {
+		for _, child := range resource.GetMeta(resource.ResourceType(ty)).GetChildrenTypes() {
+			parents[child.String()] = append(parents[child.String()], ty)
+		}
+	}
+	for child := range parents {
+		sort.Strings(parents[child])
+	}
+	return parents
+}
+
+func stringValues(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if item, ok := item.(string); ok && item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	case []string:
+		return v
+	}
+	return nil
+}
+
+func nodeKey(res *resource.Resource) string {
+	return fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId())
+}
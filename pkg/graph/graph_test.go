@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+func fakeAnalysis() *analyser.Analysis {
+	a := &analyser.Analysis{}
+	a.AddManaged(
+		&resource.Resource{Id: "vpc-1", Type: "aws_vpc", Attrs: &resource.Attributes{}, Source: &resource.TerraformStateSource{State: "tfstate://terraform.tfstate", Name: "vpc"}},
+		&resource.Resource{Id: "role", Type: "aws_iam_role", Attrs: &resource.Attributes{"arn": "arn:aws:iam::123456789012:role/role"}},
+		&resource.Resource{Id: "i-1", Type: "aws_instance", Attrs: &resource.Attributes{"subnet_id": "subnet-1"}},
+	)
+	a.AddUnmanaged(
+		&resource.Resource{Id: "subnet-1", Type: "aws_subnet", Attrs: &resource.Attributes{"vpc_id": "vpc-1"}},
+		&resource.Resource{Id: "role:policy", Type: "aws_iam_role_policy", Attrs: &resource.Attributes{"role": "role"}},
+		&resource.Resource{Id: "attachment", Type: "aws_iam_policy_attachment", Attrs: &resource.Attributes{
+			"roles":      []interface{}{"role", "unknown"},
+			"policy_arn": "arn:aws:iam::123456789012:policy/policy",
+		}},
+		&resource.Resource{Id: "vol-1", Type: "aws_ebs_volume", Attrs: &resource.Attributes{"attached_to": "i-1"}},
+		&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"},
+	)
+	a.AddDeleted(
+		&resource.Resource{Id: "policy", Type: "aws_iam_policy", Attrs: &resource.Attributes{"arn": "arn:aws:iam::123456789012:policy/policy"}},
+	)
+	return a
+}
+
+func TestBuild(t *testing.T) {
+	g := Build(fakeAnalysis())
+
+	assert.Equal(t, []*Node{
+		{Key: "aws_ebs_volume.vol-1", Id: "vol-1", Type: "aws_ebs_volume", Status: StatusUnmanaged},
+		{Key: "aws_iam_policy.policy", Id: "policy", Type: "aws_iam_policy", Status: StatusMissing},
+		{Key: "aws_iam_policy_attachment.attachment", Id: "attachment", Type: "aws_iam_policy_attachment", Status: StatusUnmanaged},
+		{Key: "aws_iam_role.role", Id: "role", Type: "aws_iam_role", Status: StatusManaged},
+		{Key: "aws_iam_role_policy.role:policy", Id: "role:policy", Type: "aws_iam_role_policy", Status: StatusUnmanaged},
+		{Key: "aws_instance.i-1", Id: "i-1", Type: "aws_instance", Status: StatusManaged},
+		{Key: "aws_s3_bucket.bucket", Id: "bucket", Type: "aws_s3_bucket", Status: StatusUnmanaged},
+		{Key: "aws_subnet.subnet-1", Id: "subnet-1", Type: "aws_subnet", Status: StatusUnmanaged},
+		{Key: "aws_vpc.vpc-1", Id: "vpc-1", Type: "aws_vpc", Status: StatusManaged, Source: "tfstate://terraform.tfstate"},
+	}, g.Nodes)
+
+	assert.Equal(t, []*Edge{
+		{From: "aws_iam_policy.policy", To: "aws_iam_policy_attachment.attachment", Attribute: "policy_arn"},
+		{From: "aws_iam_role.role", To: "aws_iam_policy_attachment.attachment", Attribute: "roles"},
+		{From: "aws_iam_role.role", To: "aws_iam_role_policy.role:policy", Attribute: "role"},
+		// aws_instance declares aws_ebs_volume as a child type, any attribute can reference it
+		{From: "aws_instance.i-1", To: "aws_ebs_volume.vol-1", Attribute: "attached_to"},
+		{From: "aws_subnet.subnet-1", To: "aws_instance.i-1", Attribute: "subnet_id"},
+		{From: "aws_vpc.vpc-1", To: "aws_subnet.subnet-1", Attribute: "vpc_id"},
+	}, g.Edges)
+}
+
+func TestBuild_Empty(t *testing.T) {
+	g := Build(&analyser.Analysis{})
+	assert.Equal(t, &Graph{Nodes: []*Node{}, Edges: []*Edge{}}, g)
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	g := &Graph{
+		Nodes: []*Node{
+			{Key: "aws_vpc.vpc-1", Id: "vpc-1", Type: "aws_vpc", Status: StatusManaged},
+			{Key: `aws_subnet.sub"net`, Id: `sub"net`, Type: "aws_subnet", Status: StatusUnmanaged},
+		},
+		Edges: []*Edge{
+			{From: "aws_vpc.vpc-1", To: `aws_subnet.sub"net`, Attribute: "vpc_id"},
+		},
+	}
+
+	var out bytes.Buffer
+	assert.NoError(t, g.WriteDOT(&out))
+	assert.Equal(t, `digraph driftctl {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled"];
+  "aws_vpc.vpc-1" [label="aws_vpc\nvpc-1", tooltip="managed", fillcolor="#c8e6c9"];
+  "aws_subnet.sub\"net" [label="aws_subnet\nsub\"net", tooltip="unmanaged", fillcolor="#ffe0b2"];
+  "aws_vpc.vpc-1" -> "aws_subnet.sub\"net" [label="vpc_id"];
+}
+`, out.String())
+}
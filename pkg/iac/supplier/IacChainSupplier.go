@@ -30,6 +30,14 @@ func (r *IacChainSupplier) SourceCount() uint {
 	return count
 }
 
+func (r *IacChainSupplier) EmptySources() []string {
+	sources := make([]string, 0)
+	for _, supplier := range r.suppliers {
+		sources = append(sources, supplier.EmptySources()...)
+	}
+	return sources
+}
+
 func (r *IacChainSupplier) AddSupplier(supplier resource2.IaCSupplier) {
 	r.suppliers = append(r.suppliers, supplier)
 }
@@ -0,0 +1,66 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend/options"
+	"github.com/pkg/errors"
+)
+
+// AzureRMUploader writes block blobs with a single upload call, so a blob is never visible partially written
+type AzureRMUploader struct {
+	storageClient azblob.BlockBlobClient
+}
+
+func NewAzureRMUploader(path string, opts options.AzureRMBackendOptions) (*AzureRMUploader, error) {
+	bucketPath := strings.Split(path, "/")
+	if len(bucketPath) < 2 || bucketPath[0] == "" || bucketPath[len(bucketPath)-1] == "" {
+		return nil, errors.Errorf("Unable to parse azurerm backend storage path: %s. Must be CONTAINER/PATH/TO/OBJECT", path)
+	}
+	if opts.StorageAccount == "" || opts.StorageKey == "" {
+		return nil, errors.New("Azure storage account and key are required to write to azurerm, use --azurerm-storage-account and --azurerm-account-key or AZURE_STORAGE_ACCOUNT and AZURE_STORAGE_KEY")
+	}
+	containerName := bucketPath[0]
+	objectPath := strings.Join(bucketPath[1:], "/")
+
+	credential, err := azblob.NewSharedKeyCredential(opts.StorageAccount, opts.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	blobClient, err := azblob.NewBlockBlobClientWithSharedKey(
+		fmt.Sprintf(
+			"https://%s.blob.core.windows.net/%s/%s",
+			credential.AccountName(),
+			containerName,
+			objectPath,
+		),
+		credential,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureRMUploader{
+		storageClient: blobClient,
+	}, nil
+}
+
+func (s *AzureRMUploader) Upload(content io.ReadSeeker) error {
+	_, err := s.storageClient.Upload(context.Background(), readSeekNopCloser{content}, nil)
+	return err
+}
+
+// readSeekNopCloser lets the blob client close the content, which is owned by the caller
+type readSeekNopCloser struct {
+	io.ReadSeeker
+}
+
+func (readSeekNopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,50 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend/options"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAzureRMUploader(t *testing.T) {
+	credentials := options.AzureRMBackendOptions{
+		StorageAccount: "account",
+		StorageKey:     "Zm9vYmFy",
+	}
+
+	tests := []struct {
+		name    string
+		options options.AzureRMBackendOptions
+		path    string
+		wantErr string
+	}{
+		{
+			name:    "invalid path",
+			options: credentials,
+			path:    "containerName/",
+			wantErr: "Unable to parse azurerm backend storage path: containerName/. Must be CONTAINER/PATH/TO/OBJECT",
+		},
+		{
+			name:    "missing credentials",
+			path:    "containerName/scan.json",
+			wantErr: "Azure storage account and key are required to write to azurerm, use --azurerm-storage-account and --azurerm-account-key or AZURE_STORAGE_ACCOUNT and AZURE_STORAGE_KEY",
+		},
+		// Like the reader, this is not supposed to do any network call during azure client init
+		{
+			name:    "valid",
+			options: credentials,
+			path:    "containerName/path/to/scan.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAzureRMUploader(tt.path, tt.options)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -3,7 +3,9 @@ package backend
 import (
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"path"
 
 	"github.com/khulnasoft-lab/driftctl/pkg/iac/config"
 	"github.com/khulnasoft-lab/driftctl/pkg/iac/terraform/state/backend/options"
@@ -20,8 +22,20 @@ var supportedBackends = []string{
 	BackendKeyAzureRM,
 }
 
+// supportedUploaderBackends are the backends outputs can be written to
+var supportedUploaderBackends = []string{
+	BackendKeyS3,
+	BackendKeyGS,
+	BackendKeyAzureRM,
+}
+
 type Backend io.ReadCloser
 
+// Uploader writes content to a backend, the content is only visible at the destination once fully uploaded
+type Uploader interface {
+	Upload(content io.ReadSeeker) error
+}
+
 type Options struct {
 	Headers         map[string]string
 	TFCloudToken    string
@@ -69,3 +83,36 @@ func GetBackend(config config.SupplierConfig, opts *Options) (Backend, error) {
 func GetSupportedBackends() []string {
 	return supportedBackends[1:]
 }
+
+func IsUploaderSupported(backend string) bool {
+	for _, b := range supportedUploaderBackends {
+		if b == backend {
+			return true
+		}
+	}
+
+	return false
+}
+
+func GetUploader(backend, path string, opts *Options) (Uploader, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	switch backend {
+	case BackendKeyS3:
+		return NewS3Uploader(path)
+	case BackendKeyGS:
+		return NewGSUploader(path)
+	case BackendKeyAzureRM:
+		return NewAzureRMUploader(path, opts.AzureRMBackendOptions)
+	default:
+		return nil, errors.Errorf("Unsupported backend '%s' for outputs", backend)
+	}
+}
+
+// contentTypeOf guesses the content type of an object from its extension, browsers then display html reports stored
+// in buckets instead of downloading them
+func contentTypeOf(key string) string {
+	return mime.TypeByExtension(path.Ext(key))
+}
@@ -0,0 +1,56 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+)
+
+// GSUploader streams objects to Google Storage, an object is only created once its writer is closed
+type GSUploader struct {
+	bucketName    string
+	path          string
+	storageClient *storage.Client
+}
+
+func NewGSUploader(path string) (*GSUploader, error) {
+	bucketPath := strings.Split(path, "/")
+	if len(bucketPath) < 2 || bucketPath[0] == "" || bucketPath[len(bucketPath)-1] == "" {
+		return nil, errors.Errorf("Unable to parse Google Storage path: %s. Must be BUCKET_NAME/PATH/TO/OBJECT", path)
+	}
+
+	return &GSUploader{
+		bucketName: bucketPath[0],
+		path:       strings.Join(bucketPath[1:], "/"),
+	}, nil
+}
+
+func (s *GSUploader) Upload(content io.ReadSeeker) error {
+	if s.storageClient == nil {
+		client, err := storage.NewClient(context.Background())
+		if err != nil {
+			return err
+		}
+		s.storageClient = client
+		defer func() {
+			s.storageClient.Close()
+			s.storageClient = nil
+		}()
+	}
+
+	// Cancelling the context aborts the upload, the previous version of the object is then left untouched
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := s.storageClient.Bucket(s.bucketName).Object(s.path).NewWriter(ctx)
+	w.ContentType = contentTypeOf(s.path)
+	if _, err := io.Copy(w, content); err != nil {
+		cancel()
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGSUploader(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    *GSUploader
+		wantErr string
+	}{
+		{
+			name: "valid path",
+			path: "bucket-1/path/to/report.html",
+			want: &GSUploader{
+				bucketName: "bucket-1",
+				path:       "path/to/report.html",
+			},
+		},
+		{
+			name:    "invalid path",
+			path:    "foobar",
+			wantErr: "Unable to parse Google Storage path: foobar. Must be BUCKET_NAME/PATH/TO/OBJECT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGSUploader(tt.path)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
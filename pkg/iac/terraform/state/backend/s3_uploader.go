@@ -0,0 +1,64 @@
+package backend
+
+import (
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/khulnasoft-lab/driftctl/pkg/envproxy"
+	"github.com/pkg/errors"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// S3Uploader writes objects with a single PutObject call, so an object is never visible partially written
+type S3Uploader struct {
+	bucket   string
+	key      string
+	S3Client s3iface.S3API
+}
+
+func NewS3Uploader(path string) (*S3Uploader, error) {
+	bucketPath := strings.Split(path, "/")
+	if len(bucketPath) < 2 || bucketPath[0] == "" || bucketPath[len(bucketPath)-1] == "" {
+		return nil, errors.Errorf("Unable to parse S3 path: %s. Must be BUCKET_NAME/PATH/TO/OBJECT", path)
+	}
+
+	envProxy := envproxy.NewEnvProxy("DCTL_S3_", "AWS_")
+	envProxy.Apply()
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	envProxy.Restore()
+
+	return &S3Uploader{
+		bucket:   bucketPath[0],
+		key:      strings.Join(bucketPath[1:], "/"),
+		S3Client: s3.New(sess),
+	}, nil
+}
+
+func (s *S3Uploader) Upload(content io.ReadSeeker) error {
+	input := &s3.PutObjectInput{
+		Bucket: &s.bucket,
+		Key:    &s.key,
+		Body:   content,
+	}
+	if contentType := contentTypeOf(s.key); contentType != "" {
+		input.ContentType = &contentType
+	}
+	if _, err := s.S3Client.PutObject(input); err != nil {
+		requestFailure, ok := err.(s3.RequestFailure)
+		if ok {
+			return errors.Errorf(
+				"Error writing '%s' to s3 bucket '%s': %s",
+				s.key,
+				s.bucket,
+				requestFailure.Message(),
+			)
+		}
+		return err
+	}
+	return nil
+}
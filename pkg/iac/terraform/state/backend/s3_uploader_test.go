@@ -0,0 +1,79 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	awstest "github.com/khulnasoft-lab/driftctl/test/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewS3UploaderInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{
+			name:    "missing key",
+			path:    "foobar",
+			wantErr: "Unable to parse S3 path: foobar. Must be BUCKET_NAME/PATH/TO/OBJECT",
+		},
+		{
+			name:    "missing bucket",
+			path:    "/path/to/scan.json",
+			wantErr: "Unable to parse S3 path: /path/to/scan.json. Must be BUCKET_NAME/PATH/TO/OBJECT",
+		},
+		{
+			name:    "directory",
+			path:    "foobar/path/",
+			wantErr: "Unable to parse S3 path: foobar/path/. Must be BUCKET_NAME/PATH/TO/OBJECT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewS3Uploader(tt.path)
+			assert.Nil(t, got)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestS3Uploader_Upload(t *testing.T) {
+	content := strings.NewReader(`{"summary": {}}`)
+	fakeS3 := &awstest.MockFakeS3{}
+	fakeS3.On("PutObject", &s3.PutObjectInput{
+		Bucket:      aws.String("foobar"),
+		Key:         aws.String("path/to/scan.json"),
+		Body:        content,
+		ContentType: aws.String("application/json"),
+	}).Return(&s3.PutObjectOutput{}, nil).Once()
+
+	uploader, err := NewS3Uploader("foobar/path/to/scan.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploader.S3Client = fakeS3
+
+	assert.Nil(t, uploader.Upload(content))
+	fakeS3.AssertExpectations(t)
+}
+
+func TestS3Uploader_UploadWithError(t *testing.T) {
+	fakeS3 := &awstest.MockFakeS3{}
+	fakeErr := &awstest.MockFakeRequestFailure{}
+	fakeErr.On("Message").Return("Access Denied")
+	fakeS3.On("PutObject", mock.Anything).Return(nil, fakeErr)
+
+	uploader, err := NewS3Uploader("foobar/path/to/scan.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploader.S3Client = fakeS3
+
+	err = uploader.Upload(strings.NewReader(""))
+	assert.EqualError(t, err, "Error writing 'path/to/scan.json' to s3 bucket 'foobar': Access Denied")
+}
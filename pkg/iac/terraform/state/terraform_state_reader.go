@@ -44,6 +44,8 @@ type TerraformStateReader struct {
 	filter         filter.Filter
 	alerter        *alerter.Alerter
 	sourceCount    uint
+	// emptySources are the states read without any resource
+	emptySources []string
 }
 
 func (r *TerraformStateReader) initReader() error {
@@ -234,6 +236,10 @@ func (r *TerraformStateReader) SourceCount() uint {
 	return r.sourceCount
 }
 
+func (r *TerraformStateReader) EmptySources() []string {
+	return r.emptySources
+}
+
 func (r *TerraformStateReader) retrieveForState(path string) ([]*resource.Resource, error) {
 	r.config.Path = path
 	r.sourceCount += 1
@@ -247,6 +253,9 @@ func (r *TerraformStateReader) retrieveForState(path string) ([]*resource.Resour
 		return nil, errors.Wrap(err, r.config.String())
 	}
 	decode, err := r.decode(values)
+	if err == nil && len(decode) == 0 {
+		r.emptySources = append(r.emptySources, r.config.String())
+	}
 	return decode, errors.Wrap(err, r.config.String())
 }
 
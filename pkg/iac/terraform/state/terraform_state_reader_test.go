@@ -81,6 +81,7 @@ func TestTerraformStateReader_Source(t *testing.T) {
 	got, err := r.Resources()
 	assert.Nil(t, err)
 	assert.Len(t, got, 2)
+	assert.Empty(t, r.EmptySources())
 	for _, res := range got {
 		if res.ResourceType() == resourceaws.AwsS3BucketResourceType {
 			assert.Equal(t, &resource.TerraformStateSource{
@@ -99,6 +100,31 @@ func TestTerraformStateReader_Source(t *testing.T) {
 	}
 }
 
+func TestTerraformStateReader_EmptySources(t *testing.T) {
+	progress := &output.MockProgress{}
+	progress.On("Inc").Return().Times(1)
+	progress.On("Stop").Return().Times(1)
+
+	repo := testresource.InitFakeSchemaRepository(terraform.AWS, "3.19.0")
+	factory := dctlresource.NewDriftctlResourceFactory(repo)
+
+	r := &TerraformStateReader{
+		config: config.SupplierConfig{
+			Key:  "tfstate",
+			Path: path.Join(goldenfile.GoldenFilePath, "empty", "terraform.tfstate"),
+		},
+		library:      terraform.NewProviderLibrary(),
+		progress:     progress,
+		deserializer: resource.NewDeserializer(factory),
+	}
+
+	got, err := r.Resources()
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+	assert.Equal(t, uint(1), r.SourceCount())
+	assert.Equal(t, []string{"tfstate://test/empty/terraform.tfstate"}, r.EmptySources())
+}
+
 func TestTerraformStateReader_AWS_Resources(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -9,11 +9,20 @@ import (
 
 type Chain []Middleware
 
+// ChainObserver is notified after the execution of each middleware of a chain
+type ChainObserver interface {
+	MiddlewareExecuted(middleware Middleware, remoteResources, resourcesFromState []*resource.Resource)
+}
+
 func NewChain(middlewares ...Middleware) Chain {
 	return middlewares
 }
 
 func (c Chain) Execute(remoteResources, resourcesFromState *[]*resource.Resource) error {
+	return c.ExecuteWithObserver(remoteResources, resourcesFromState, nil)
+}
+
+func (c Chain) ExecuteWithObserver(remoteResources, resourcesFromState *[]*resource.Resource, observer ChainObserver) error {
 	for _, middleware := range c {
 		logrus.WithFields(logrus.Fields{
 			"middleware": fmt.Sprintf("%T", middleware),
@@ -22,6 +31,19 @@ func (c Chain) Execute(remoteResources, resourcesFromState *[]*resource.Resource
 		if err != nil {
 			return err
 		}
+		if observer != nil {
+			observer.MiddlewareExecuted(middleware, *remoteResources, *resourcesFromState)
+		}
 	}
 	return nil
 }
+
+// NewNonStrictChain returns the middlewares ignoring default resources, they only run when strict mode is disabled
+func NewNonStrictChain() Chain {
+	return NewChain(
+		NewAwsDefaults(),
+		NewGoogleLegacyBucketIAMMember(),
+		NewGoogleDefaultIAMMember(),
+		NewAwsDefaultApiGatewayAccount(),
+	)
+}
@@ -83,3 +83,30 @@ func TestChainMiddlewareErrorShouldStopExecution(t *testing.T) {
 	}
 
 }
+
+type fakeObserver struct {
+	executed []string
+}
+
+func (o *fakeObserver) MiddlewareExecuted(middleware Middleware, remoteResources, resourcesFromState []*resource.Resource) {
+	o.executed = append(o.executed, middleware.(FakeMiddleware).Name)
+}
+
+func TestChainMiddlewareObserver(t *testing.T) {
+
+	callCounters = make(map[string]int)
+
+	middleware := NewChain(FakeMiddleware{Name: "1"}, FakeMiddleware{Name: "2", Err: errors.New("Test error")}, FakeMiddleware{Name: "3"})
+	remoteResources := []*resource.Resource{}
+	stateResources := []*resource.Resource{}
+	observer := &fakeObserver{}
+	err := middleware.ExecuteWithObserver(&remoteResources, &stateResources, observer)
+
+	if err == nil {
+		t.Error("Middleware 2 should have returned an error")
+	}
+
+	if len(observer.executed) != 1 || observer.executed[0] != "1" {
+		t.Errorf("Observer should only be notified of middleware 1, got %v", observer.executed)
+	}
+}
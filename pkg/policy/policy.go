@@ -0,0 +1,191 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/jmespath/go-jmespath"
+	"github.com/pkg/errors"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/filter"
+)
+
+// Policy decides whether a scan should fail, every rule is evaluated and the scan exits with the code of the first
+// violated rule
+type Policy struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Rule is a set of thresholds applied to the resources selected by its filter, or to every resource when there is
+// no filter. A rule without any threshold is always satisfied.
+type Rule struct {
+	Name string `json:"name"`
+	// Filter is a JMESPath expression, with the same syntax as the scan --filter flag
+	Filter        string `json:"filter,omitempty"`
+	MinCoverage   *int   `json:"min_coverage,omitempty"`
+	MaxUnmanaged  *int   `json:"max_unmanaged,omitempty"`
+	MaxMissing    *int   `json:"max_missing,omitempty"`
+	MaxChanged    *int   `json:"max_changed,omitempty"`
+	MaxDuplicated *int   `json:"max_duplicated,omitempty"`
+	ExitCode      int    `json:"exit_code,omitempty"`
+
+	expr *jmespath.JMESPath
+}
+
+// Read loads a policy from a YAML or JSON file
+func Read(path string) (*Policy, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &Policy{}
+	if err := yaml.Unmarshal(content, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Compile validates every rule of the policy and parses their filters, it must be called before Evaluate.
+// Rules without exit code use defaultExitCode, errorExitCode is reserved for the errors of the calling command.
+func (p *Policy) Compile(defaultExitCode, errorExitCode int) error {
+	names := make(map[string]struct{}, len(p.Rules))
+	for i, rule := range p.Rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if _, exist := names[rule.Name]; exist {
+			return errors.Errorf("policy rule %s is defined more than once", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+
+		if rule.ExitCode == 0 {
+			rule.ExitCode = defaultExitCode
+		}
+		if rule.ExitCode < 0 || rule.ExitCode > 125 || rule.ExitCode == errorExitCode {
+			return errors.Errorf("invalid exit code %d for policy rule %s, exit code must be between 1 and 125 and %d is reserved for errors", rule.ExitCode, rule.Name, errorExitCode)
+		}
+		if rule.MinCoverage != nil && (*rule.MinCoverage < 0 || *rule.MinCoverage > 100) {
+			return errors.Errorf("invalid minimum coverage %d for policy rule %s, expected a percentage", *rule.MinCoverage, rule.Name)
+		}
+
+		if rule.Filter != "" {
+			expr, err := filter.BuildExpression(rule.Filter)
+			if err != nil {
+				return errors.Wrapf(err, "unable to parse filter expression of policy rule %s", rule.Name)
+			}
+			rule.expr = expr
+		}
+	}
+	return nil
+}
+
+// Evaluate checks every rule against the analysis.
+// When a baseline has been applied to the analysis only new findings are counted.
+func (p *Policy) Evaluate(analysis *analyser.Analysis) (*analyser.PolicyEvaluation, error) {
+	evaluation := &analyser.PolicyEvaluation{
+		Rules: make([]analyser.RuleEvaluation, 0, len(p.Rules)),
+	}
+	for _, rule := range p.Rules {
+		result, err := rule.evaluate(analysis)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to evaluate policy rule %s", rule.Name)
+		}
+		if result.Violated && evaluation.ExitCode == 0 {
+			evaluation.ExitCode = result.ExitCode
+		}
+		evaluation.Rules = append(evaluation.Rules, result)
+	}
+	return evaluation, nil
+}
+
+func (r *Rule) evaluate(analysis *analyser.Analysis) (analyser.RuleEvaluation, error) {
+	result := analyser.RuleEvaluation{
+		Name:     r.Name,
+		ExitCode: r.ExitCode,
+	}
+
+	findings := analyser.Findings{
+		Unmanaged:   analysis.Unmanaged(),
+		Deleted:     analysis.Deleted(),
+		Differences: analysis.Differences(),
+		Duplicates:  analysis.Duplicates(),
+	}
+	if analysis.Baseline() != nil {
+		findings = analysis.Baseline().New
+	}
+
+	checks := []struct {
+		max       *int
+		resources []*resource.Resource
+		kind      string
+	}{
+		{r.MaxUnmanaged, findings.Unmanaged, "unmanaged"},
+		{r.MaxMissing, findings.Deleted, "missing"},
+		{r.MaxChanged, differencesResources(findings.Differences), "changed"},
+		{r.MaxDuplicated, duplicatesResources(findings.Duplicates), "duplicated"},
+	}
+	for _, check := range checks {
+		if check.max == nil {
+			continue
+		}
+		selected, err := r.selectResources(check.resources)
+		if err != nil {
+			return result, err
+		}
+		if len(selected) > *check.max {
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%d %s resource(s), expected at most %d", len(selected), check.kind, *check.max))
+		}
+	}
+
+	if r.MinCoverage != nil {
+		managed, err := r.selectResources(analysis.Managed())
+		if err != nil {
+			return result, err
+		}
+		unmanaged, err := r.selectResources(analysis.Unmanaged())
+		if err != nil {
+			return result, err
+		}
+		deleted, err := r.selectResources(analysis.Deleted())
+		if err != nil {
+			return result, err
+		}
+		// Nothing to cover, the threshold can't be violated
+		total := len(managed) + len(unmanaged) + len(deleted)
+		if total > 0 {
+			coverage := int((float32(len(managed)) / float32(total)) * 100.0)
+			if coverage < *r.MinCoverage {
+				result.Reasons = append(result.Reasons, fmt.Sprintf("coverage is %d%%, expected at least %d%%", coverage, *r.MinCoverage))
+			}
+		}
+	}
+
+	result.Violated = len(result.Reasons) > 0
+	return result, nil
+}
+
+func (r *Rule) selectResources(resources []*resource.Resource) ([]*resource.Resource, error) {
+	if r.expr == nil || len(resources) == 0 {
+		return resources, nil
+	}
+	return filter.NewFilterEngine(r.expr).Run(resources)
+}
+
+func differencesResources(differences []analyser.Difference) []*resource.Resource {
+	resources := make([]*resource.Resource, 0, len(differences))
+	for _, difference := range differences {
+		resources = append(resources, difference.Res)
+	}
+	return resources
+}
+
+func duplicatesResources(duplicates []analyser.Duplicate) []*resource.Resource {
+	resources := make([]*resource.Resource, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		resources = append(resources, duplicate.Res)
+	}
+	return resources
+}
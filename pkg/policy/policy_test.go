@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+// Exit codes of the scan command
+const (
+	exitNotInSync = 1
+	exitError     = 2
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func fakeAnalysis() *analyser.Analysis {
+	a := &analyser.Analysis{}
+	a.AddManaged(
+		&resource.Resource{Id: "bucket", Type: "aws_s3_bucket", Attrs: &resource.Attributes{}},
+		&resource.Resource{Id: "sg", Type: "aws_security_group", Attrs: &resource.Attributes{}},
+	)
+	a.AddUnmanaged(
+		&resource.Resource{Id: "role", Type: "aws_iam_role", Attrs: &resource.Attributes{}},
+		&resource.Resource{Id: "other-bucket", Type: "aws_s3_bucket", Attrs: &resource.Attributes{}},
+	)
+	a.AddDeleted(
+		&resource.Resource{Id: "user", Type: "aws_iam_user", Attrs: &resource.Attributes{}},
+	)
+	return a
+}
+
+func TestRead(t *testing.T) {
+	p, err := Read("testdata/policy.yml")
+	require.NoError(t, err)
+	require.NoError(t, p.Compile(exitNotInSync, exitError))
+
+	require.Len(t, p.Rules, 3)
+	assert.Equal(t, "security", p.Rules[0].Name)
+	assert.Equal(t, 10, p.Rules[0].ExitCode)
+	assert.Equal(t, intPtr(0), p.Rules[0].MaxUnmanaged)
+	assert.NotNil(t, p.Rules[0].expr)
+	assert.Equal(t, "rule-2", p.Rules[1].Name)
+	assert.Equal(t, 1, p.Rules[1].ExitCode)
+	assert.Equal(t, intPtr(85), p.Rules[2].MinCoverage)
+}
+
+func TestPolicy_Compile(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *Policy
+		err    string
+	}{
+		{
+			name:   "valid policy",
+			policy: &Policy{Rules: []*Rule{{Name: "foo", MaxMissing: intPtr(0)}}},
+		},
+		{
+			name:   "duplicated rule name",
+			policy: &Policy{Rules: []*Rule{{Name: "foo"}, {Name: "foo"}}},
+			err:    "policy rule foo is defined more than once",
+		},
+		{
+			name:   "reserved exit code",
+			policy: &Policy{Rules: []*Rule{{Name: "foo", ExitCode: 2}}},
+			err:    "invalid exit code 2 for policy rule foo, exit code must be between 1 and 125 and 2 is reserved for errors",
+		},
+		{
+			name:   "invalid coverage",
+			policy: &Policy{Rules: []*Rule{{Name: "foo", MinCoverage: intPtr(150)}}},
+			err:    "invalid minimum coverage 150 for policy rule foo, expected a percentage",
+		},
+		{
+			name:   "invalid filter",
+			policy: &Policy{Rules: []*Rule{{Name: "foo", Filter: "Type='test'"}}},
+			err:    "unable to parse filter expression of policy rule foo: SyntaxError: Expected tRbracket, received: tUnknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Compile(exitNotInSync, exitError)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *Policy
+		analysis func() *analyser.Analysis
+		expected *analyser.PolicyEvaluation
+	}{
+		{
+			name:     "fail only on missing resources",
+			policy:   &Policy{Rules: []*Rule{{Name: "missing", MaxMissing: intPtr(0)}}},
+			analysis: fakeAnalysis,
+			expected: &analyser.PolicyEvaluation{
+				ExitCode: 1,
+				Rules: []analyser.RuleEvaluation{
+					{Name: "missing", Violated: true, ExitCode: 1, Reasons: []string{"1 missing resource(s), expected at most 0"}},
+				},
+			},
+		},
+		{
+			name:     "threshold not reached",
+			policy:   &Policy{Rules: []*Rule{{Name: "unmanaged", MaxUnmanaged: intPtr(2)}}},
+			analysis: fakeAnalysis,
+			expected: &analyser.PolicyEvaluation{
+				Rules: []analyser.RuleEvaluation{
+					{Name: "unmanaged", ExitCode: 1},
+				},
+			},
+		},
+		{
+			name: "exit code of the first violated rule",
+			policy: &Policy{Rules: []*Rule{
+				{Name: "changed", MaxChanged: intPtr(0), ExitCode: 5},
+				{Name: "coverage", MinCoverage: intPtr(85), ExitCode: 3},
+				{Name: "unmanaged", MaxUnmanaged: intPtr(0), ExitCode: 4},
+			}},
+			analysis: fakeAnalysis,
+			expected: &analyser.PolicyEvaluation{
+				ExitCode: 3,
+				Rules: []analyser.RuleEvaluation{
+					{Name: "changed", ExitCode: 5},
+					{Name: "coverage", Violated: true, ExitCode: 3, Reasons: []string{"coverage is 40%, expected at least 85%"}},
+					{Name: "unmanaged", Violated: true, ExitCode: 4, Reasons: []string{"2 unmanaged resource(s), expected at most 0"}},
+				},
+			},
+		},
+		{
+			name: "rules restricted by a filter",
+			policy: &Policy{Rules: []*Rule{
+				{Name: "security", Filter: "Type=='aws_iam_role' || Type=='aws_security_group'", MaxUnmanaged: intPtr(0), MinCoverage: intPtr(50)},
+				{Name: "s3", Filter: "Type=='aws_s3_bucket'", MaxMissing: intPtr(0), MinCoverage: intPtr(50)},
+			}},
+			analysis: fakeAnalysis,
+			expected: &analyser.PolicyEvaluation{
+				ExitCode: 1,
+				Rules: []analyser.RuleEvaluation{
+					{Name: "security", Violated: true, ExitCode: 1, Reasons: []string{"1 unmanaged resource(s), expected at most 0"}},
+					{Name: "s3", ExitCode: 1},
+				},
+			},
+		},
+		{
+			name:   "only new findings are counted with a baseline",
+			policy: &Policy{Rules: []*Rule{{Name: "unmanaged", MaxUnmanaged: intPtr(0)}}},
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysis()
+				a.ApplyBaseline(fakeAnalysis())
+				return a
+			},
+			expected: &analyser.PolicyEvaluation{
+				Rules: []analyser.RuleEvaluation{
+					{Name: "unmanaged", ExitCode: 1},
+				},
+			},
+		},
+		{
+			name:     "no resources to cover",
+			policy:   &Policy{Rules: []*Rule{{Name: "coverage", MinCoverage: intPtr(100)}}},
+			analysis: func() *analyser.Analysis { return &analyser.Analysis{} },
+			expected: &analyser.PolicyEvaluation{
+				Rules: []analyser.RuleEvaluation{
+					{Name: "coverage", ExitCode: 1},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.policy.Compile(exitNotInSync, exitError))
+			got, err := tt.policy.Evaluate(tt.analysis())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -12,6 +12,22 @@ type MockIaCSupplier struct {
 	mock.Mock
 }
 
+// EmptySources provides a mock function with given fields:
+func (_m *MockIaCSupplier) EmptySources() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // Resources provides a mock function with given fields:
 func (_m *MockIaCSupplier) Resources() ([]*enumerationresource.Resource, error) {
 	ret := _m.Called()
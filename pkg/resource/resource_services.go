@@ -0,0 +1,183 @@
+package resource
+
+// services maps each supported resource type to the provider service exposing it, a service is named after the
+// provider and the API the resource belongs to, e.g. every VPC, security group or EBS resource is part of aws_ec2
+var services = map[string]string{
+	"aws_alb":                               "aws_elb",
+	"aws_alb_listener":                      "aws_elb",
+	"aws_ami":                               "aws_ec2",
+	"aws_api_gateway_account":               "aws_apigateway",
+	"aws_api_gateway_api_key":               "aws_apigateway",
+	"aws_api_gateway_authorizer":            "aws_apigateway",
+	"aws_api_gateway_base_path_mapping":     "aws_apigateway",
+	"aws_api_gateway_deployment":            "aws_apigateway",
+	"aws_api_gateway_domain_name":           "aws_apigateway",
+	"aws_api_gateway_gateway_response":      "aws_apigateway",
+	"aws_api_gateway_integration":           "aws_apigateway",
+	"aws_api_gateway_integration_response":  "aws_apigateway",
+	"aws_api_gateway_method":                "aws_apigateway",
+	"aws_api_gateway_method_response":       "aws_apigateway",
+	"aws_api_gateway_method_settings":       "aws_apigateway",
+	"aws_api_gateway_model":                 "aws_apigateway",
+	"aws_api_gateway_request_validator":     "aws_apigateway",
+	"aws_api_gateway_resource":              "aws_apigateway",
+	"aws_api_gateway_rest_api":              "aws_apigateway",
+	"aws_api_gateway_rest_api_policy":       "aws_apigateway",
+	"aws_api_gateway_stage":                 "aws_apigateway",
+	"aws_api_gateway_vpc_link":              "aws_apigateway",
+	"aws_apigatewayv2_api":                  "aws_apigateway",
+	"aws_apigatewayv2_api_mapping":          "aws_apigateway",
+	"aws_apigatewayv2_authorizer":           "aws_apigateway",
+	"aws_apigatewayv2_deployment":           "aws_apigateway",
+	"aws_apigatewayv2_domain_name":          "aws_apigateway",
+	"aws_apigatewayv2_integration":          "aws_apigateway",
+	"aws_apigatewayv2_integration_response": "aws_apigateway",
+	"aws_apigatewayv2_model":                "aws_apigateway",
+	"aws_apigatewayv2_route":                "aws_apigateway",
+	"aws_apigatewayv2_route_response":       "aws_apigateway",
+	"aws_apigatewayv2_stage":                "aws_apigateway",
+	"aws_apigatewayv2_vpc_link":             "aws_apigateway",
+	"aws_appautoscaling_policy":             "aws_applicationautoscaling",
+	"aws_appautoscaling_scheduled_action":   "aws_applicationautoscaling",
+	"aws_appautoscaling_target":             "aws_applicationautoscaling",
+	"aws_cloudformation_stack":              "aws_cloudformation",
+	"aws_cloudfront_distribution":           "aws_cloudfront",
+	"aws_cloudtrail":                        "aws_cloudtrail",
+	"aws_db_instance":                       "aws_rds",
+	"aws_db_subnet_group":                   "aws_rds",
+	"aws_default_network_acl":               "aws_ec2",
+	"aws_default_route_table":               "aws_ec2",
+	"aws_default_security_group":            "aws_ec2",
+	"aws_default_subnet":                    "aws_ec2",
+	"aws_default_vpc":                       "aws_ec2",
+	"aws_dynamodb_table":                    "aws_dynamodb",
+	"aws_ebs_encryption_by_default":         "aws_ec2",
+	"aws_ebs_snapshot":                      "aws_ec2",
+	"aws_ebs_volume":                        "aws_ec2",
+	"aws_ecr_repository":                    "aws_ecr",
+	"aws_ecr_repository_policy":             "aws_ecr",
+	"aws_eip":                               "aws_ec2",
+	"aws_eip_association":                   "aws_ec2",
+	"aws_elasticache_cluster":               "aws_elasticache",
+	"aws_elb":                               "aws_elb",
+	"aws_iam_access_key":                    "aws_iam",
+	"aws_iam_group":                         "aws_iam",
+	"aws_iam_group_policy":                  "aws_iam",
+	"aws_iam_group_policy_attachment":       "aws_iam",
+	"aws_iam_policy":                        "aws_iam",
+	"aws_iam_policy_attachment":             "aws_iam",
+	"aws_iam_role":                          "aws_iam",
+	"aws_iam_role_policy":                   "aws_iam",
+	"aws_iam_role_policy_attachment":        "aws_iam",
+	"aws_iam_user":                          "aws_iam",
+	"aws_iam_user_policy":                   "aws_iam",
+	"aws_iam_user_policy_attachment":        "aws_iam",
+	"aws_instance":                          "aws_ec2",
+	"aws_internet_gateway":                  "aws_ec2",
+	"aws_key_pair":                          "aws_ec2",
+	"aws_kms_alias":                         "aws_kms",
+	"aws_kms_key":                           "aws_kms",
+	"aws_lambda_event_source_mapping":       "aws_lambda",
+	"aws_lambda_function":                   "aws_lambda",
+	"aws_launch_configuration":              "aws_autoscaling",
+	"aws_launch_template":                   "aws_ec2",
+	"aws_lb":                                "aws_elb",
+	"aws_lb_listener":                       "aws_elb",
+	"aws_nat_gateway":                       "aws_ec2",
+	"aws_network_acl":                       "aws_ec2",
+	"aws_network_acl_rule":                  "aws_ec2",
+	"aws_rds_cluster":                       "aws_rds",
+	"aws_rds_cluster_instance":              "aws_rds",
+	"aws_route":                             "aws_ec2",
+	"aws_route53_health_check":              "aws_route53",
+	"aws_route53_record":                    "aws_route53",
+	"aws_route53_zone":                      "aws_route53",
+	"aws_route_table":                       "aws_ec2",
+	"aws_route_table_association":           "aws_ec2",
+	"aws_s3_account_public_access_block":    "aws_s3",
+	"aws_s3_bucket":                         "aws_s3",
+	"aws_s3_bucket_analytics_configuration": "aws_s3",
+	"aws_s3_bucket_inventory":               "aws_s3",
+	"aws_s3_bucket_metric":                  "aws_s3",
+	"aws_s3_bucket_notification":            "aws_s3",
+	"aws_s3_bucket_policy":                  "aws_s3",
+	"aws_s3_bucket_public_access_block":     "aws_s3",
+	"aws_security_group":                    "aws_ec2",
+	"aws_security_group_rule":               "aws_ec2",
+	"aws_sns_topic":                         "aws_sns",
+	"aws_sns_topic_policy":                  "aws_sns",
+	"aws_sns_topic_subscription":            "aws_sns",
+	"aws_sqs_queue":                         "aws_sqs",
+	"aws_sqs_queue_policy":                  "aws_sqs",
+	"aws_subnet":                            "aws_ec2",
+	"aws_vpc":                               "aws_ec2",
+
+	"azurerm_container_registry":       "azurerm_containerregistry",
+	"azurerm_firewall":                 "azurerm_network",
+	"azurerm_image":                    "azurerm_compute",
+	"azurerm_lb":                       "azurerm_network",
+	"azurerm_lb_rule":                  "azurerm_network",
+	"azurerm_network_security_group":   "azurerm_network",
+	"azurerm_postgresql_database":      "azurerm_postgresql",
+	"azurerm_postgresql_server":        "azurerm_postgresql",
+	"azurerm_private_dns_a_record":     "azurerm_privatedns",
+	"azurerm_private_dns_aaaa_record":  "azurerm_privatedns",
+	"azurerm_private_dns_cname_record": "azurerm_privatedns",
+	"azurerm_private_dns_mx_record":    "azurerm_privatedns",
+	"azurerm_private_dns_ptr_record":   "azurerm_privatedns",
+	"azurerm_private_dns_srv_record":   "azurerm_privatedns",
+	"azurerm_private_dns_txt_record":   "azurerm_privatedns",
+	"azurerm_private_dns_zone":         "azurerm_privatedns",
+	"azurerm_public_ip":                "azurerm_network",
+	"azurerm_resource_group":           "azurerm_resources",
+	"azurerm_route":                    "azurerm_network",
+	"azurerm_route_table":              "azurerm_network",
+	"azurerm_ssh_public_key":           "azurerm_compute",
+	"azurerm_storage_account":          "azurerm_storage",
+	"azurerm_storage_container":        "azurerm_storage",
+	"azurerm_subnet":                   "azurerm_network",
+	"azurerm_virtual_network":          "azurerm_network",
+
+	"github_branch_protection": "github_repository",
+	"github_membership":        "github_organization",
+	"github_repository":        "github_repository",
+	"github_team":              "github_organization",
+	"github_team_membership":   "github_organization",
+
+	"google_bigquery_dataset":               "google_bigquery",
+	"google_bigquery_table":                 "google_bigquery",
+	"google_bigtable_instance":              "google_bigtable",
+	"google_bigtable_table":                 "google_bigtable",
+	"google_cloud_run_service":              "google_cloudrun",
+	"google_cloudfunctions_function":        "google_cloudfunctions",
+	"google_compute_address":                "google_compute",
+	"google_compute_disk":                   "google_compute",
+	"google_compute_firewall":               "google_compute",
+	"google_compute_forwarding_rule":        "google_compute",
+	"google_compute_global_address":         "google_compute",
+	"google_compute_global_forwarding_rule": "google_compute",
+	"google_compute_health_check":           "google_compute",
+	"google_compute_image":                  "google_compute",
+	"google_compute_instance":               "google_compute",
+	"google_compute_instance_group":         "google_compute",
+	"google_compute_instance_group_manager": "google_compute",
+	"google_compute_network":                "google_compute",
+	"google_compute_node_group":             "google_compute",
+	"google_compute_router":                 "google_compute",
+	"google_compute_ssl_certificate":        "google_compute",
+	"google_compute_subnetwork":             "google_compute",
+	"google_dns_managed_zone":               "google_dns",
+	"google_project_iam_binding":            "google_iam",
+	"google_project_iam_member":             "google_iam",
+	"google_project_iam_policy":             "google_iam",
+	"google_sql_database_instance":          "google_sql",
+	"google_storage_bucket":                 "google_storage",
+	"google_storage_bucket_iam_binding":     "google_storage",
+	"google_storage_bucket_iam_member":      "google_storage",
+	"google_storage_bucket_iam_policy":      "google_storage",
+}
+
+// GetService returns the provider service of a resource type, or an empty string for unsupported types
+func GetService(ty string) string {
+	return services[ty]
+}
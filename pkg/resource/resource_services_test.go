@@ -0,0 +1,41 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetService(t *testing.T) {
+	tests := []struct {
+		ty       string
+		expected string
+	}{
+		{ty: "aws_s3_bucket_policy", expected: "aws_s3"},
+		{ty: "aws_instance", expected: "aws_ec2"},
+		{ty: "aws_default_security_group", expected: "aws_ec2"},
+		{ty: "aws_ebs_volume", expected: "aws_ec2"},
+		{ty: "aws_route_table_association", expected: "aws_ec2"},
+		{ty: "aws_db_instance", expected: "aws_rds"},
+		{ty: "aws_apigatewayv2_api", expected: "aws_apigateway"},
+		{ty: "aws_api_gateway_rest_api", expected: "aws_apigateway"},
+		{ty: "azurerm_private_dns_cname_record", expected: "azurerm_privatedns"},
+		{ty: "google_compute_instance_group_manager", expected: "google_compute"},
+		{ty: "google_storage_bucket_iam_member", expected: "google_storage"},
+		{ty: "FakeResource", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ty, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetService(tt.ty))
+		})
+	}
+}
+
+func TestGetService_SupportedTypes(t *testing.T) {
+	for _, ty := range GetSupportedTypes() {
+		assert.NotEmpty(t, GetService(ty), "resource type %s has no service", ty)
+	}
+	for ty := range services {
+		assert.True(t, IsResourceTypeSupported(ty), "service of unsupported resource type %s", ty)
+	}
+}
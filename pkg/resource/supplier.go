@@ -6,4 +6,6 @@ import "github.com/khulnasoft-lab/driftctl/enumeration/resource"
 type IaCSupplier interface {
 	resource.Supplier
 	SourceCount() uint
+	// EmptySources returns the IaC sources read without any resource
+	EmptySources() []string
 }
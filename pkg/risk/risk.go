@@ -0,0 +1,235 @@
+package risk
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+//go:embed rules.yml
+var defaultRules []byte
+
+// Ruleset is a list of rules used to classify unmanaged and missing resources by security risk
+type Ruleset struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Rule assigns a severity to resources of the given types matching every condition.
+// A rule without condition matches every resource of its types.
+type Rule struct {
+	Id          string            `json:"id"`
+	Description string            `json:"description,omitempty"`
+	Severity    analyser.Severity `json:"severity"`
+	Types       []string          `json:"types"`
+	Match       []*Condition      `json:"match,omitempty"`
+	types       map[string]struct{}
+}
+
+// Condition matches a resource attribute, nested attributes are separated by dots.
+// When the attribute holds a list, the condition matches if any of its values does.
+type Condition struct {
+	Attribute string      `json:"attribute"`
+	Equals    interface{} `json:"equals,omitempty"`
+	Matches   string      `json:"matches,omitempty"`
+	regexp    *regexp.Regexp
+}
+
+// Default returns the ruleset shipped with driftctl
+func Default() (*Ruleset, error) {
+	return parse(defaultRules)
+}
+
+// Read loads a ruleset from a YAML or JSON file
+func Read(path string) (*Ruleset, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parse(content)
+}
+
+func parse(content []byte) (*Ruleset, error) {
+	r := &Ruleset{}
+	if err := yaml.Unmarshal(content, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Extend adds the rules of other to the ruleset, a rule with the same id as an existing one replaces it
+func (r *Ruleset) Extend(other *Ruleset) {
+	for _, rule := range other.Rules {
+		replaced := false
+		for i, existing := range r.Rules {
+			if existing.Id == rule.Id {
+				r.Rules[i] = rule
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			r.Rules = append(r.Rules, rule)
+		}
+	}
+}
+
+// Compile validates every rule of the ruleset and parses their conditions, it must be called before Classify
+func (r *Ruleset) Compile() error {
+	ids := make(map[string]struct{}, len(r.Rules))
+	for i, rule := range r.Rules {
+		if rule.Id == "" {
+			return errors.Errorf("risk rule #%d has no id", i+1)
+		}
+		if _, exist := ids[rule.Id]; exist {
+			return errors.Errorf("risk rule %s is defined more than once", rule.Id)
+		}
+		ids[rule.Id] = struct{}{}
+
+		if rule.Severity.Rank() == 0 {
+			return errors.Errorf("invalid severity '%s' for risk rule %s, expected one of %s", rule.Severity, rule.Id, severities())
+		}
+		if len(rule.Types) == 0 {
+			return errors.Errorf("risk rule %s does not apply to any resource type", rule.Id)
+		}
+		rule.types = make(map[string]struct{}, len(rule.Types))
+		for _, ty := range rule.Types {
+			rule.types[ty] = struct{}{}
+		}
+
+		for _, condition := range rule.Match {
+			if condition.Attribute == "" {
+				return errors.Errorf("a condition of risk rule %s has no attribute", rule.Id)
+			}
+			if (condition.Equals == nil) == (condition.Matches == "") {
+				return errors.Errorf("condition on attribute %s of risk rule %s must define either equals or matches", condition.Attribute, rule.Id)
+			}
+			if condition.Matches != "" {
+				exp, err := regexp.Compile(condition.Matches)
+				if err != nil {
+					return errors.Wrapf(err, "invalid condition on attribute %s of risk rule %s", condition.Attribute, rule.Id)
+				}
+				condition.regexp = exp
+			}
+		}
+	}
+	return nil
+}
+
+type Classifier struct {
+	ruleset *Ruleset
+}
+
+func NewClassifier(ruleset *Ruleset) *Classifier {
+	return &Classifier{ruleset}
+}
+
+// Classify returns the risks of every unmanaged and missing resource of the analysis.
+// When several rules match a resource the most severe one is kept, resources matching no rule are not reported.
+func (c *Classifier) Classify(analysis *analyser.Analysis) []analyser.Risk {
+	risks := make([]analyser.Risk, 0)
+	for _, res := range analysis.Unmanaged() {
+		if risk, found := c.classify(res, analyser.RiskKindUnmanaged); found {
+			risks = append(risks, risk)
+		}
+	}
+	for _, res := range analysis.Deleted() {
+		if risk, found := c.classify(res, analyser.RiskKindMissing); found {
+			risks = append(risks, risk)
+		}
+	}
+	return risks
+}
+
+func (c *Classifier) classify(res *resource.Resource, kind string) (analyser.Risk, bool) {
+	var matched *Rule
+	for _, rule := range c.ruleset.Rules {
+		if !rule.match(res) {
+			continue
+		}
+		if matched == nil || rule.Severity.Rank() > matched.Severity.Rank() {
+			matched = rule
+		}
+	}
+	if matched == nil {
+		return analyser.Risk{}, false
+	}
+	return analyser.Risk{
+		Res:         res,
+		Kind:        kind,
+		Severity:    matched.Severity,
+		Rule:        matched.Id,
+		Description: matched.Description,
+	}, true
+}
+
+func (r *Rule) match(res *resource.Resource) bool {
+	if _, exist := r.types[res.ResourceType()]; !exist {
+		return false
+	}
+	for _, condition := range r.Match {
+		if !condition.match(res.Attributes()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Condition) match(attrs *resource.Attributes) bool {
+	if attrs == nil {
+		return false
+	}
+	for _, value := range lookup(map[string]interface{}(*attrs), strings.Split(c.Attribute, ".")) {
+		if value == nil {
+			continue
+		}
+		if c.regexp != nil && c.regexp.MatchString(fmt.Sprint(value)) {
+			return true
+		}
+		if c.Equals != nil && fmt.Sprint(c.Equals) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns every value found at the given path, lists are flattened
+func lookup(value interface{}, path []string) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			values = append(values, lookup(item, path)...)
+		}
+		return values
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return []interface{}{v}
+		}
+		child, exist := v[path[0]]
+		if !exist {
+			return nil
+		}
+		return lookup(child, path[1:])
+	default:
+		if len(path) > 0 {
+			return nil
+		}
+		return []interface{}{v}
+	}
+}
+
+func severities() string {
+	values := make([]string, 0, len(analyser.Severities))
+	for _, severity := range analyser.Severities {
+		values = append(values, string(severity))
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,195 @@
+package risk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+func TestDefault(t *testing.T) {
+	ruleset, err := Default()
+	require.NoError(t, err)
+	assert.NoError(t, ruleset.Compile())
+	assert.NotEmpty(t, ruleset.Rules)
+}
+
+func TestRuleset_Extend(t *testing.T) {
+	ruleset, err := Default()
+	require.NoError(t, err)
+	count := len(ruleset.Rules)
+
+	rules, err := Read("testdata/rules.yml")
+	require.NoError(t, err)
+	ruleset.Extend(rules)
+	require.NoError(t, ruleset.Compile())
+
+	assert.Len(t, ruleset.Rules, count+1)
+	for _, rule := range ruleset.Rules {
+		if rule.Id == "aws-kms-key" {
+			assert.Equal(t, analyser.SeverityLow, rule.Severity)
+		}
+	}
+	assert.Equal(t, "aws-ebs-snapshot-public", ruleset.Rules[len(ruleset.Rules)-1].Id)
+}
+
+func TestRuleset_Compile(t *testing.T) {
+	tests := []struct {
+		name    string
+		ruleset *Ruleset
+		err     string
+	}{
+		{
+			name:    "valid ruleset",
+			ruleset: &Ruleset{Rules: []*Rule{{Id: "foo", Severity: analyser.SeverityLow, Types: []string{"aws_s3_bucket"}}}},
+		},
+		{
+			name:    "missing id",
+			ruleset: &Ruleset{Rules: []*Rule{{Severity: analyser.SeverityLow, Types: []string{"aws_s3_bucket"}}}},
+			err:     "risk rule #1 has no id",
+		},
+		{
+			name: "duplicated id",
+			ruleset: &Ruleset{Rules: []*Rule{
+				{Id: "foo", Severity: analyser.SeverityLow, Types: []string{"aws_s3_bucket"}},
+				{Id: "foo", Severity: analyser.SeverityLow, Types: []string{"aws_s3_bucket"}},
+			}},
+			err: "risk rule foo is defined more than once",
+		},
+		{
+			name:    "invalid severity",
+			ruleset: &Ruleset{Rules: []*Rule{{Id: "foo", Severity: "urgent", Types: []string{"aws_s3_bucket"}}}},
+			err:     "invalid severity 'urgent' for risk rule foo, expected one of critical,high,medium,low",
+		},
+		{
+			name:    "no types",
+			ruleset: &Ruleset{Rules: []*Rule{{Id: "foo", Severity: analyser.SeverityLow}}},
+			err:     "risk rule foo does not apply to any resource type",
+		},
+		{
+			name: "condition without operator",
+			ruleset: &Ruleset{Rules: []*Rule{{Id: "foo", Severity: analyser.SeverityLow, Types: []string{"aws_s3_bucket"}, Match: []*Condition{
+				{Attribute: "acl"},
+			}}}},
+			err: "condition on attribute acl of risk rule foo must define either equals or matches",
+		},
+		{
+			name: "invalid regexp",
+			ruleset: &Ruleset{Rules: []*Rule{{Id: "foo", Severity: analyser.SeverityLow, Types: []string{"aws_s3_bucket"}, Match: []*Condition{
+				{Attribute: "acl", Matches: "("},
+			}}}},
+			err: "invalid condition on attribute acl of risk rule foo: error parsing regexp: missing closing ): `(`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ruleset.Compile()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestClassifier_Classify(t *testing.T) {
+	openRule := &resource.Resource{Id: "sgrule-open", Type: "aws_security_group_rule", Attrs: &resource.Attributes{
+		"type":        "ingress",
+		"cidr_blocks": []interface{}{"10.0.0.0/8", "0.0.0.0/0"},
+	}}
+	privateRule := &resource.Resource{Id: "sgrule-private", Type: "aws_security_group_rule", Attrs: &resource.Attributes{
+		"type":        "ingress",
+		"cidr_blocks": []interface{}{"10.0.0.0/8"},
+	}}
+	adminPolicy := &resource.Resource{Id: "admin", Type: "aws_iam_policy", Attrs: &resource.Attributes{
+		"policy": `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`,
+	}}
+	s3Policy := &resource.Resource{Id: "s3", Type: "aws_iam_policy", Attrs: &resource.Attributes{
+		"policy": `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":"*"}]}`,
+	}}
+	publicBucket := &resource.Resource{Id: "public", Type: "aws_s3_bucket", Attrs: &resource.Attributes{
+		"acl": "public-read-write",
+	}}
+	privateBucket := &resource.Resource{Id: "private", Type: "aws_s3_bucket", Attrs: &resource.Attributes{
+		"acl": "private",
+	}}
+	nsg := &resource.Resource{Id: "nsg", Type: "azurerm_network_security_group", Attrs: &resource.Attributes{
+		"security_rule": []interface{}{
+			map[string]interface{}{"direction": "Inbound", "source_address_prefix": "*"},
+		},
+	}}
+	key := &resource.Resource{Id: "key", Type: "aws_iam_access_key"}
+	snapshot := &resource.Resource{Id: "snapshot", Type: "aws_ebs_snapshot"}
+
+	a := &analyser.Analysis{}
+	a.AddUnmanaged(openRule, privateRule, adminPolicy, s3Policy, publicBucket, privateBucket, nsg, snapshot)
+	a.AddDeleted(key)
+
+	ruleset, err := Default()
+	require.NoError(t, err)
+	require.NoError(t, ruleset.Compile())
+
+	risks := NewClassifier(ruleset).Classify(a)
+	a.SetRisks(risks)
+
+	assert.Equal(t, []analyser.Risk{
+		{Res: key, Kind: analyser.RiskKindMissing, Severity: analyser.SeverityCritical, Rule: "aws-iam-access-key", Description: "IAM access key not managed by IaC"},
+		{Res: adminPolicy, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityCritical, Rule: "aws-iam-wildcard-action", Description: "IAM policy allowing every action"},
+		{Res: publicBucket, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityCritical, Rule: "aws-s3-bucket-public-acl", Description: "Bucket readable or writable by anyone"},
+		{Res: openRule, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityCritical, Rule: "aws-security-group-rule-open-ingress", Description: "Ingress rule open to the whole internet"},
+		{Res: nsg, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityCritical, Rule: "azurerm-network-security-group-open-ingress", Description: "Network security rule open to the whole internet"},
+		{Res: s3Policy, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityHigh, Rule: "aws-iam", Description: "IAM identity or permission not managed by IaC"},
+		{Res: privateRule, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityHigh, Rule: "aws-security-group", Description: "Network access control not managed by IaC"},
+		{Res: privateBucket, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityMedium, Rule: "aws-s3-bucket", Description: "Bucket not managed by IaC"},
+	}, a.Risks())
+}
+
+// Without --deep, unmanaged resources only hold the attributes set by their enumerator, they must still be reported
+// by the rule of their type
+func TestClassifier_Classify_EnumeratedResources(t *testing.T) {
+	bucket := &resource.Resource{Id: "public", Type: "aws_s3_bucket", Attrs: &resource.Attributes{
+		"alias": "eu-west-3",
+	}}
+	bucketPolicy := &resource.Resource{Id: "public", Type: "aws_s3_bucket_policy", Attrs: &resource.Attributes{
+		"alias": "eu-west-3",
+	}}
+	policy := &resource.Resource{Id: "arn:aws:iam::123456789012:policy/admin", Type: "aws_iam_policy", Attrs: &resource.Attributes{}}
+	rolePolicy := &resource.Resource{Id: "admin:admin", Type: "aws_iam_role_policy", Attrs: &resource.Attributes{
+		"role": "admin",
+	}}
+	firewall := &resource.Resource{Id: "projects/driftctl/global/firewalls/open", Type: "google_compute_firewall", Attrs: &resource.Attributes{
+		"name":    "open",
+		"project": "driftctl",
+	}}
+	nsg := &resource.Resource{Id: "nsg", Type: "azurerm_network_security_group", Attrs: &resource.Attributes{
+		"name": "nsg",
+	}}
+	// Missing resources are read from the IaC and hold every attribute
+	missingBucket := &resource.Resource{Id: "missing", Type: "aws_s3_bucket", Attrs: &resource.Attributes{
+		"acl": "public-read",
+	}}
+
+	a := &analyser.Analysis{}
+	a.AddUnmanaged(bucket, bucketPolicy, policy, rolePolicy, firewall, nsg)
+	a.AddDeleted(missingBucket)
+
+	ruleset, err := Default()
+	require.NoError(t, err)
+	require.NoError(t, ruleset.Compile())
+
+	a.SetRisks(NewClassifier(ruleset).Classify(a))
+
+	assert.Equal(t, []analyser.Risk{
+		{Res: missingBucket, Kind: analyser.RiskKindMissing, Severity: analyser.SeverityCritical, Rule: "aws-s3-bucket-public-acl", Description: "Bucket readable or writable by anyone"},
+		{Res: policy, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityHigh, Rule: "aws-iam", Description: "IAM identity or permission not managed by IaC"},
+		{Res: rolePolicy, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityHigh, Rule: "aws-iam", Description: "IAM identity or permission not managed by IaC"},
+		{Res: nsg, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityHigh, Rule: "azurerm-network-security-group", Description: "Network access control not managed by IaC"},
+		{Res: firewall, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityHigh, Rule: "google-compute-firewall", Description: "Firewall rule not managed by IaC"},
+		{Res: bucket, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityMedium, Rule: "aws-s3-bucket", Description: "Bucket not managed by IaC"},
+		{Res: bucketPolicy, Kind: analyser.RiskKindUnmanaged, Severity: analyser.SeverityMedium, Rule: "aws-s3-bucket-policy", Description: "Bucket policy not managed by IaC"},
+	}, a.Risks())
+}
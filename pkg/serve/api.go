@@ -0,0 +1,279 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+	"github.com/khulnasoft-lab/driftctl/pkg/cmd/scan/output"
+)
+
+const (
+	StatusManaged   = "managed"
+	StatusChanged   = "changed"
+	StatusUnmanaged = "unmanaged"
+	StatusMissing   = "missing"
+)
+
+// contentTypes are the formats an analysis is served in. Other outputs need options or more than a path, e.g. the
+// template output, and are rejected.
+var contentTypes = map[string]string{
+	output.JSONOutputType:     "application/json",
+	output.PlanOutputType:     "application/json",
+	output.GraphOutputType:    "application/json",
+	output.HTMLOutputType:     "text/html; charset=utf-8",
+	output.DOTOutputType:      "text/vnd.graphviz; charset=utf-8",
+	output.MarkdownOutputType: "text/markdown; charset=utf-8",
+	output.SARIFOutputType:    "application/sarif+json",
+	output.JUnitOutputType:    "application/xml",
+}
+
+// TargetStatus is the state of a target, the summary and coverage are those of its latest analysis
+type TargetStatus struct {
+	Name      string            `json:"name"`
+	Interval  string            `json:"interval"`
+	Running   bool              `json:"running"`
+	Scans     int               `json:"scans"`
+	Failures  int               `json:"failures"`
+	LastScan  *time.Time        `json:"last_scan,omitempty"`
+	LastError string            `json:"last_error,omitempty"`
+	Coverage  *int              `json:"coverage,omitempty"`
+	Summary   *analyser.Summary `json:"summary,omitempty"`
+}
+
+// ResourceStatus is the category a resource is reported in by the latest analysis of a target
+type ResourceStatus struct {
+	Type   string `json:"type"`
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// Handler returns the HTTP API of the server:
+//
+//	GET  /targets                       status of every target
+//	GET  /targets/NAME                  status of a target
+//	POST /targets/NAME/scan             trigger a scan of a target
+//	GET  /targets/NAME/analysis         latest analysis, ?format=json|html|plan|markdown|sarif|junit|graph|dot and
+//	                                    ?analysis=previous
+//	GET  /targets/NAME/resources        resources of the latest analysis, filtered by ?type=, ?id= and ?status=
+//	GET  /metrics                       coverage and resource counts in the Prometheus text format
+//	GET  /healthz                       liveness probe
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok\n")
+	})
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/targets", s.handleTargets)
+	mux.HandleFunc("/targets/", s.handleTarget)
+	return mux
+}
+
+func (s *Server) handleTargets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+		return
+	}
+	statuses := make([]TargetStatus, 0, len(s.targets))
+	for _, t := range s.targets {
+		statuses = append(statuses, t.status())
+	}
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+func (s *Server) handleTarget(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/targets/"), "/"), "/")
+	t := s.target(parts[0])
+	if t == nil {
+		writeError(w, http.StatusNotFound, "unknown target '%s'", parts[0])
+		return
+	}
+
+	action := ""
+	if len(parts) > 1 {
+		action = strings.Join(parts[1:], "/")
+	}
+	method := http.MethodGet
+	if action == "scan" {
+		method = http.MethodPost
+	}
+	if r.Method != method {
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+		return
+	}
+
+	switch action {
+	case "":
+		writeJSON(w, http.StatusOK, t.status())
+	case "scan":
+		if err := s.Trigger(t.Name); err != nil {
+			writeError(w, http.StatusConflict, "%s", err)
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]string{"message": "scan started"})
+	case "analysis":
+		s.handleAnalysis(w, r, t)
+	case "resources":
+		s.handleResources(w, r, t)
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint '%s'", r.URL.Path)
+	}
+}
+
+func (s *Server) handleAnalysis(w http.ResponseWriter, r *http.Request, t *target) {
+	analysis, ok := selectAnalysis(w, r, t)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = output.JSONOutputType
+	}
+	contentType, exist := contentTypes[format]
+	if !exist {
+		writeError(w, http.StatusBadRequest, "unsupported format '%s'", format)
+		return
+	}
+
+	// Outputs write to files, render to a temporary one and serve its content
+	file, err := os.CreateTemp("", "driftctl-serve-*")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%s", err)
+		return
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+
+	if err := output.GetOutput(output.OutputConfig{Key: format, Path: file.Name()}, nil).Write(analysis); err != nil {
+		writeError(w, http.StatusInternalServerError, "unable to render the analysis: %s", err)
+		return
+	}
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%s", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(content)
+}
+
+func (s *Server) handleResources(w http.ResponseWriter, r *http.Request, t *target) {
+	analysis, ok := selectAnalysis(w, r, t)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	resources := make([]ResourceStatus, 0)
+	for _, res := range Statuses(analysis) {
+		if (query.Get("type") != "" && res.Type != query.Get("type")) ||
+			(query.Get("id") != "" && res.Id != query.Get("id")) ||
+			(query.Get("status") != "" && res.Status != query.Get("status")) {
+			continue
+		}
+		resources = append(resources, res)
+	}
+	writeJSON(w, http.StatusOK, resources)
+}
+
+// selectAnalysis returns the analysis requested with ?analysis=latest|previous, writing an error when there is none
+func selectAnalysis(w http.ResponseWriter, r *http.Request, t *target) (*analyser.Analysis, bool) {
+	latest, previous := t.analyses()
+	which := r.URL.Query().Get("analysis")
+	var analysis *analyser.Analysis
+	switch which {
+	case "", "latest":
+		which = "latest"
+		analysis = latest
+	case "previous":
+		analysis = previous
+	default:
+		writeError(w, http.StatusBadRequest, "invalid analysis '%s', expected latest or previous", which)
+		return nil, false
+	}
+	if analysis == nil {
+		writeError(w, http.StatusNotFound, "no %s analysis for target '%s'", which, t.Name)
+		return nil, false
+	}
+	return analysis, true
+}
+
+// Statuses returns the status of every resource of an analysis, sorted by type and id
+func Statuses(analysis *analyser.Analysis) []ResourceStatus {
+	changed := make(map[string]struct{})
+	for _, difference := range analysis.Differences() {
+		changed[difference.Res.ResourceType()+"."+difference.Res.ResourceId()] = struct{}{}
+	}
+
+	statuses := make([]ResourceStatus, 0)
+	for _, res := range analysis.Managed() {
+		status := StatusManaged
+		if _, exist := changed[res.ResourceType()+"."+res.ResourceId()]; exist {
+			status = StatusChanged
+		}
+		statuses = append(statuses, ResourceStatus{res.ResourceType(), res.ResourceId(), status})
+	}
+	for _, res := range analysis.Unmanaged() {
+		statuses = append(statuses, ResourceStatus{res.ResourceType(), res.ResourceId(), StatusUnmanaged})
+	}
+	for _, res := range analysis.Deleted() {
+		statuses = append(statuses, ResourceStatus{res.ResourceType(), res.ResourceId(), StatusMissing})
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].Type != statuses[j].Type {
+			return statuses[i].Type < statuses[j].Type
+		}
+		return statuses[i].Id < statuses[j].Id
+	})
+	return statuses
+}
+
+func (t *target) status() TargetStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	status := TargetStatus{
+		Name:     t.Name,
+		Interval: t.Interval.String(),
+		Running:  t.running,
+		Scans:    t.scans,
+		Failures: t.failures,
+	}
+	if !t.lastScan.IsZero() {
+		lastScan := t.lastScan
+		status.LastScan = &lastScan
+	}
+	if t.lastError != nil {
+		status.LastError = t.lastError.Error()
+	}
+	if t.latest != nil {
+		coverage := t.latest.Coverage()
+		summary := t.latest.Summary()
+		status.Coverage = &coverage
+		status.Summary = &summary
+	}
+	return status
+}
+
+func writeJSON(w http.ResponseWriter, code int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(value); err != nil {
+		logrus.WithField("error", err).Debug("Unable to write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, format string, args ...interface{}) {
+	writeJSON(w, code, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
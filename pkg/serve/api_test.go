@@ -0,0 +1,186 @@
+package serve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+func TestServer_Handler(t *testing.T) {
+	server := NewServer([]Target{
+		{Name: "production", Interval: time.Hour, Scan: sequence(fakeAnalysis(), fakeAnalysis())},
+		{Name: "staging", Interval: 30 * time.Minute, Scan: sequence(fakeAnalysis())},
+	}, "")
+	server.now = func() time.Time {
+		return time.Date(2023, 1, 1, 0, 5, 0, 0, time.UTC)
+	}
+	require.NoError(t, server.Trigger("production"))
+	server.wg.Wait()
+
+	cases := []struct {
+		name        string
+		method      string
+		url         string
+		code        int
+		contentType string
+		body        string
+	}{
+		{
+			name:   "test resources filtered by status",
+			method: http.MethodGet,
+			url:    "/targets/production/resources?status=changed",
+			code:   http.StatusOK,
+			body:   "[\n\t{\n\t\t\"type\": \"aws_s3_bucket\",\n\t\t\"id\": \"bucket\",\n\t\t\"status\": \"changed\"\n\t}\n]\n",
+		},
+		{
+			name:   "test resources filtered by type and id",
+			method: http.MethodGet,
+			url:    "/targets/production/resources?type=aws_iam_user&id=user",
+			code:   http.StatusOK,
+			body:   "[\n\t{\n\t\t\"type\": \"aws_iam_user\",\n\t\t\"id\": \"user\",\n\t\t\"status\": \"unmanaged\"\n\t}\n]\n",
+		},
+		{
+			name:   "test no previous analysis",
+			method: http.MethodGet,
+			url:    "/targets/production/analysis?analysis=previous",
+			code:   http.StatusNotFound,
+			body:   "{\n\t\"error\": \"no previous analysis for target 'production'\"\n}\n",
+		},
+		{
+			name:   "test no analysis before the first scan",
+			method: http.MethodGet,
+			url:    "/targets/staging/resources",
+			code:   http.StatusNotFound,
+			body:   "{\n\t\"error\": \"no latest analysis for target 'staging'\"\n}\n",
+		},
+		{
+			name:   "test unsupported format",
+			method: http.MethodGet,
+			url:    "/targets/production/analysis?format=console",
+			code:   http.StatusBadRequest,
+			body:   "{\n\t\"error\": \"unsupported format 'console'\"\n}\n",
+		},
+		{
+			name:   "test template format needs a template",
+			method: http.MethodGet,
+			url:    "/targets/production/analysis?format=template",
+			code:   http.StatusBadRequest,
+			body:   "{\n\t\"error\": \"unsupported format 'template'\"\n}\n",
+		},
+		{
+			name:   "test unknown target",
+			method: http.MethodGet,
+			url:    "/targets/development",
+			code:   http.StatusNotFound,
+			body:   "{\n\t\"error\": \"unknown target 'development'\"\n}\n",
+		},
+		{
+			name:   "test scan requires post",
+			method: http.MethodGet,
+			url:    "/targets/production/scan",
+			code:   http.StatusMethodNotAllowed,
+			body:   "{\n\t\"error\": \"method GET not allowed\"\n}\n",
+		},
+		{
+			name:        "test metrics",
+			method:      http.MethodGet,
+			url:         "/metrics",
+			code:        http.StatusOK,
+			contentType: "text/plain; version=0.0.4; charset=utf-8",
+			body: `# HELP driftctl_coverage_percent Percentage of cloud resources managed by IaC in the latest analysis
+# TYPE driftctl_coverage_percent gauge
+driftctl_coverage_percent{target="production"} 50
+# HELP driftctl_resources Number of resources in the latest analysis, by category
+# TYPE driftctl_resources gauge
+driftctl_resources{target="production",category="managed"} 2
+driftctl_resources{target="production",category="unmanaged"} 1
+driftctl_resources{target="production",category="missing"} 1
+driftctl_resources{target="production",category="changed"} 1
+driftctl_resources{target="production",category="duplicated"} 1
+# HELP driftctl_scans_total Number of scans run since the server started
+# TYPE driftctl_scans_total counter
+driftctl_scans_total{target="production"} 1
+driftctl_scans_total{target="staging"} 0
+# HELP driftctl_scan_failures_total Number of failed scans since the server started
+# TYPE driftctl_scan_failures_total counter
+driftctl_scan_failures_total{target="production"} 0
+driftctl_scan_failures_total{target="staging"} 0
+# HELP driftctl_last_scan_success Whether the last scan succeeded
+# TYPE driftctl_last_scan_success gauge
+driftctl_last_scan_success{target="production"} 1
+# HELP driftctl_last_scan_timestamp_seconds Unix time of the last scan
+# TYPE driftctl_last_scan_timestamp_seconds gauge
+driftctl_last_scan_timestamp_seconds{target="production"} 1672531500
+# HELP driftctl_scan_duration_seconds Duration of the scan of the latest analysis
+# TYPE driftctl_scan_duration_seconds gauge
+driftctl_scan_duration_seconds{target="production"} 2
+`,
+		},
+		{
+			name:   "test trigger scan",
+			method: http.MethodPost,
+			url:    "/targets/production/scan",
+			code:   http.StatusAccepted,
+			body:   "{\n\t\"message\": \"scan started\"\n}\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, httptest.NewRequest(c.method, c.url, nil))
+			server.wg.Wait()
+
+			assert.Equal(t, c.code, recorder.Code)
+			if c.contentType != "" {
+				assert.Equal(t, c.contentType, recorder.Header().Get("Content-Type"))
+			}
+			assert.Equal(t, c.body, recorder.Body.String())
+		})
+	}
+}
+
+func TestServer_HandlerAnalysis(t *testing.T) {
+	server := NewServer([]Target{
+		{Name: "production", Interval: time.Hour, Scan: sequence(fakeAnalysis())},
+	}, "")
+	require.NoError(t, server.Trigger("production"))
+	server.wg.Wait()
+
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/targets/production/analysis", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	analysis := &analyser.Analysis{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), analysis))
+	assert.Equal(t, 50, analysis.Coverage())
+
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/targets/production/analysis?format=html", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.HasPrefix(recorder.Body.String(), "<!doctype html>"))
+
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/targets/production/analysis?format=sarif", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/sarif+json", recorder.Header().Get("Content-Type"))
+
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/targets", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	statuses := []TargetStatus{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &statuses))
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "production", statuses[0].Name)
+	assert.Equal(t, "1h0m0s", statuses[0].Interval)
+	assert.Equal(t, 50, *statuses[0].Coverage)
+	assert.Equal(t, 1, statuses[0].Summary.TotalUnmanaged)
+}
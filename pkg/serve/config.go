@@ -0,0 +1,78 @@
+package serve
+
+import (
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+const DefaultListen = ":8080"
+
+var targetNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Config is the configuration of the serve command
+type Config struct {
+	// Listen is the address of the HTTP API, e.g. :8080
+	Listen string `json:"listen"`
+	// DataDir persists the latest and previous analyses of each target so they survive restarts, optional
+	DataDir string         `json:"data_dir"`
+	Targets []TargetConfig `json:"targets"`
+}
+
+// TargetConfig is a scan scheduled at a regular interval, Args are the flags of the scan command
+type TargetConfig struct {
+	Name     string   `json:"name"`
+	Interval string   `json:"interval"`
+	Args     []string `json:"args"`
+}
+
+// ReadConfig reads and validates a serve configuration file
+func ReadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse %s", path)
+	}
+	if config.Listen == "" {
+		config.Listen = DefaultListen
+	}
+
+	if len(config.Targets) == 0 {
+		return nil, errors.Errorf("%s: at least one target is required", path)
+	}
+	names := make(map[string]struct{}, len(config.Targets))
+	for i, target := range config.Targets {
+		if !targetNameRegexp.MatchString(target.Name) {
+			return nil, errors.Errorf("%s: invalid name '%s' for target #%d, expected letters, digits, '-' or '_'", path, target.Name, i+1)
+		}
+		if _, exist := names[target.Name]; exist {
+			return nil, errors.Errorf("%s: duplicate target '%s'", path, target.Name)
+		}
+		names[target.Name] = struct{}{}
+
+		if _, err := target.ParseInterval(); err != nil {
+			return nil, errors.Wrapf(err, "%s: target '%s'", path, target.Name)
+		}
+	}
+
+	return config, nil
+}
+
+// ParseInterval parses the scan interval of the target, written as a duration (e.g. 30m, 6h)
+func (t TargetConfig) ParseInterval() (time.Duration, error) {
+	interval, err := time.ParseDuration(t.Interval)
+	if err != nil {
+		return 0, errors.Errorf("invalid interval '%s', expected a duration (e.g. 30m, 6h)", t.Interval)
+	}
+	if interval < time.Minute {
+		return 0, errors.Errorf("invalid interval '%s', scans can't run more than once a minute", t.Interval)
+	}
+	return interval, nil
+}
@@ -0,0 +1,74 @@
+package serve
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		expected *Config
+		err      string
+	}{
+		{
+			name: "valid config",
+			path: "testdata/valid.yml",
+			expected: &Config{
+				Listen:  DefaultListen,
+				DataDir: "/var/lib/driftctl",
+				Targets: []TargetConfig{
+					{
+						Name:     "production",
+						Interval: "6h",
+						Args:     []string{"--from", "tfstate+s3://bucket/prod.tfstate", "--to", "aws+tf"},
+					},
+					{
+						Name:     "staging",
+						Interval: "30m",
+					},
+				},
+			},
+		},
+		{
+			name: "interval too short",
+			path: "testdata/invalid_interval.yml",
+			err:  "testdata/invalid_interval.yml: target 'production': invalid interval '10s', scans can't run more than once a minute",
+		},
+		{
+			name: "duplicate target",
+			path: "testdata/duplicate.yml",
+			err:  "testdata/duplicate.yml: duplicate target 'production'",
+		},
+		{
+			name: "invalid target name",
+			path: "testdata/invalid_name.yml",
+			err:  "testdata/invalid_name.yml: invalid name 'prod env' for target #1, expected letters, digits, '-' or '_'",
+		},
+		{
+			name: "no target",
+			path: "testdata/no_targets.yml",
+			err:  "testdata/no_targets.yml: at least one target is required",
+		},
+		{
+			name: "missing file",
+			path: "testdata/doesnotexist.yml",
+			err:  "open testdata/doesnotexist.yml: no such file or directory",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, err := ReadConfig(c.path)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, config)
+		})
+	}
+}
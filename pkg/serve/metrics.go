@@ -0,0 +1,135 @@
+package serve
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// categoryDuplicated is the category of the resources metric counting duplicated resources, it is not a status of
+// resources as they are managed too
+const categoryDuplicated = "duplicated"
+
+type metric struct {
+	name  string
+	help  string
+	kind  string
+	value func(t *target) (float64, bool)
+	// categories splits the metric by resource category, the value function is called once per category
+	categories bool
+}
+
+var metrics = []metric{
+	{
+		name: "driftctl_coverage_percent",
+		help: "Percentage of cloud resources managed by IaC in the latest analysis",
+		kind: "gauge",
+		value: func(t *target) (float64, bool) {
+			if t.latest == nil {
+				return 0, false
+			}
+			return float64(t.latest.Coverage()), true
+		},
+	},
+	{
+		name:       "driftctl_resources",
+		help:       "Number of resources in the latest analysis, by category",
+		kind:       "gauge",
+		categories: true,
+	},
+	{
+		name: "driftctl_scans_total",
+		help: "Number of scans run since the server started",
+		kind: "counter",
+		value: func(t *target) (float64, bool) {
+			return float64(t.scans), true
+		},
+	},
+	{
+		name: "driftctl_scan_failures_total",
+		help: "Number of failed scans since the server started",
+		kind: "counter",
+		value: func(t *target) (float64, bool) {
+			return float64(t.failures), true
+		},
+	},
+	{
+		name: "driftctl_last_scan_success",
+		help: "Whether the last scan succeeded",
+		kind: "gauge",
+		value: func(t *target) (float64, bool) {
+			if t.lastScan.IsZero() {
+				return 0, false
+			}
+			if t.lastError != nil {
+				return 0, true
+			}
+			return 1, true
+		},
+	},
+	{
+		name: "driftctl_last_scan_timestamp_seconds",
+		help: "Unix time of the last scan",
+		kind: "gauge",
+		value: func(t *target) (float64, bool) {
+			if t.lastScan.IsZero() {
+				return 0, false
+			}
+			return float64(t.lastScan.Unix()), true
+		},
+	},
+	{
+		name: "driftctl_scan_duration_seconds",
+		help: "Duration of the scan of the latest analysis",
+		kind: "gauge",
+		value: func(t *target) (float64, bool) {
+			if t.latest == nil {
+				return 0, false
+			}
+			return t.latest.Duration.Seconds(), true
+		},
+	},
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = s.WriteMetrics(w)
+}
+
+// WriteMetrics writes the metrics of every target in the Prometheus text format
+func (s *Server) WriteMetrics(w io.Writer) error {
+	var b strings.Builder
+	for _, m := range metrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+		for _, t := range s.targets {
+			t.mu.Lock()
+			if m.categories {
+				writeCategories(&b, m.name, t)
+			} else if value, ok := m.value(t); ok {
+				fmt.Fprintf(&b, "%s{target=%q} %s\n", m.name, t.Name, strconv.FormatFloat(value, 'f', -1, 64))
+			}
+			t.mu.Unlock()
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeCategories(b *strings.Builder, name string, t *target) {
+	if t.latest == nil {
+		return
+	}
+	counts := map[string]int{}
+	for _, res := range Statuses(t.latest) {
+		counts[res.Status]++
+	}
+	// Changed resources are managed too, the managed count matches the one of the summary
+	counts[StatusManaged] += counts[StatusChanged]
+	// Duplicated resources are managed resources declared by several Terraform states, they are counted apart
+	counts[categoryDuplicated] = len(t.latest.Duplicates())
+	for _, category := range []string{StatusManaged, StatusUnmanaged, StatusMissing, StatusChanged, categoryDuplicated} {
+		fmt.Fprintf(b, "%s{target=%q,category=%q} %d\n", name, t.Name, category, counts[category])
+	}
+}
@@ -0,0 +1,243 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+var (
+	ErrUnknownTarget = errors.New("unknown target")
+	ErrScanRunning   = errors.New("a scan is already running for this target")
+)
+
+// ScanFunc runs a scan and returns its analysis
+type ScanFunc func() (*analyser.Analysis, error)
+
+// Target is a scan run by the server
+type Target struct {
+	Name     string
+	Interval time.Duration
+	Scan     ScanFunc
+}
+
+type target struct {
+	Target
+
+	mu        sync.Mutex
+	running   bool
+	latest    *analyser.Analysis
+	previous  *analyser.Analysis
+	lastScan  time.Time
+	lastError error
+	scans     int
+	failures  int
+}
+
+// Server runs scheduled scans, keeps the latest and previous analysis of each target and serves them over HTTP
+type Server struct {
+	targets []*target
+	dataDir string
+	// scans run one at a time, providers and outputs rely on process wide state
+	scanLock sync.Mutex
+	wg       sync.WaitGroup
+	now      func() time.Time
+}
+
+// NewServer creates a server for the given targets, analyses persisted in dataDir by a previous run are loaded back
+func NewServer(targets []Target, dataDir string) *Server {
+	s := &Server{
+		targets: make([]*target, 0, len(targets)),
+		dataDir: dataDir,
+		now:     time.Now,
+	}
+	for _, t := range targets {
+		tr := &target{Target: t}
+		if dataDir != "" {
+			tr.latest = s.load(t.Name, "latest")
+			tr.previous = s.load(t.Name, "previous")
+			if tr.latest != nil {
+				tr.lastScan = tr.latest.Date
+			}
+		}
+		s.targets = append(s.targets, tr)
+	}
+	return s
+}
+
+// Run scans every target right away then at its interval, until the context is done
+func (s *Server) Run(ctx context.Context) {
+	for _, t := range s.targets {
+		s.wg.Add(1)
+		go func(t *target) {
+			defer s.wg.Done()
+			s.schedule(ctx, t)
+		}(t)
+	}
+	<-ctx.Done()
+	s.wg.Wait()
+}
+
+func (s *Server) schedule(ctx context.Context, t *target) {
+	ticker := time.NewTicker(t.Interval)
+	defer ticker.Stop()
+	for {
+		if t.start() {
+			s.scan(t)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Trigger starts a scan of the given target in background
+func (s *Server) Trigger(name string) error {
+	t := s.target(name)
+	if t == nil {
+		return ErrUnknownTarget
+	}
+	if !t.start() {
+		return ErrScanRunning
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.scan(t)
+	}()
+	return nil
+}
+
+func (s *Server) target(name string) *target {
+	for _, t := range s.targets {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// start marks the target as running, it returns false when a scan is already running
+func (t *target) start() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.running {
+		return false
+	}
+	t.running = true
+	return true
+}
+
+func (s *Server) scan(t *target) {
+	s.scanLock.Lock()
+	defer s.scanLock.Unlock()
+
+	logrus.WithField("target", t.Name).Info("Starting scheduled scan")
+	analysis, err := t.Scan()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.running = false
+	t.scans++
+	t.lastScan = s.now()
+	t.lastError = err
+	if err != nil {
+		t.failures++
+		logrus.WithFields(logrus.Fields{"target": t.Name, "error": err}).Error("Scheduled scan failed")
+		return
+	}
+	t.previous, t.latest = t.latest, analysis
+	logrus.WithFields(logrus.Fields{"target": t.Name, "coverage": analysis.Coverage()}).Info("Scheduled scan done")
+
+	if s.dataDir != "" {
+		if err := s.persist(t); err != nil {
+			logrus.WithFields(logrus.Fields{"target": t.Name, "error": err}).Warn("Unable to persist analysis")
+		}
+	}
+}
+
+// analyses returns the latest and previous analyses of the target
+func (t *target) analyses() (latest, previous *analyser.Analysis) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.latest, t.previous
+}
+
+func (s *Server) persist(t *target) error {
+	dir := filepath.Join(s.dataDir, t.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	analyses := []struct {
+		name     string
+		analysis *analyser.Analysis
+	}{
+		{"previous", t.previous},
+		{"latest", t.latest},
+	}
+	for _, a := range analyses {
+		if a.analysis == nil {
+			continue
+		}
+		content, err := json.Marshal(a.analysis)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(dir, a.name+".json"), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile replaces a file without ever leaving it truncated: the content is written to a temporary file of the
+// same directory, then renamed over the file
+func writeFile(path string, content []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *Server) load(name, which string) *analyser.Analysis {
+	content, err := os.ReadFile(filepath.Join(s.dataDir, name, which+".json"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.WithFields(logrus.Fields{"target": name, "error": err}).Warn("Unable to read persisted analysis")
+		}
+		return nil
+	}
+	analysis := &analyser.Analysis{}
+	if err := json.Unmarshal(content, analysis); err != nil {
+		logrus.WithFields(logrus.Fields{"target": name, "error": err}).Warn("Unable to parse persisted analysis")
+		return nil
+	}
+	return analysis
+}
@@ -0,0 +1,132 @@
+package serve
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/khulnasoft-lab/driftctl/enumeration/resource"
+	"github.com/khulnasoft-lab/driftctl/pkg/analyser"
+)
+
+func fakeAnalysis() *analyser.Analysis {
+	bucket := &resource.Resource{Id: "bucket", Type: "aws_s3_bucket"}
+	analysis := analyser.NewAnalysis()
+	analysis.AddManaged(
+		bucket,
+		&resource.Resource{Id: "role", Type: "aws_iam_role"},
+	)
+	analysis.AddUnmanaged(&resource.Resource{Id: "user", Type: "aws_iam_user"})
+	analysis.AddDeleted(&resource.Resource{Id: "queue", Type: "aws_sqs_queue"})
+	analysis.AddDuplicate(analyser.Duplicate{Res: &resource.Resource{Id: "role", Type: "aws_iam_role"}})
+	analysis.AddDifference(analyser.Difference{Res: bucket, Changelog: analyser.Changelog{
+		{Change: diff.Change{Type: "update", Path: []string{"acl"}, From: "private", To: "public-read"}},
+	}})
+	analysis.Duration = 2 * time.Second
+	analysis.Date = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	return analysis
+}
+
+// sequence returns a scan function returning the given results in order
+func sequence(results ...interface{}) ScanFunc {
+	i := 0
+	return func() (*analyser.Analysis, error) {
+		result := results[i]
+		i++
+		if err, ok := result.(error); ok {
+			return nil, err
+		}
+		return result.(*analyser.Analysis), nil
+	}
+}
+
+func TestServer_Trigger(t *testing.T) {
+	first, second := fakeAnalysis(), analyser.NewAnalysis()
+	server := NewServer([]Target{
+		{Name: "production", Interval: time.Hour, Scan: sequence(first, second, errors.New("access denied"))},
+	}, "")
+
+	assert.Equal(t, ErrUnknownTarget, server.Trigger("staging"))
+
+	require.NoError(t, server.Trigger("production"))
+	server.wg.Wait()
+	latest, previous := server.target("production").analyses()
+	assert.Same(t, first, latest)
+	assert.Nil(t, previous)
+
+	require.NoError(t, server.Trigger("production"))
+	server.wg.Wait()
+	latest, previous = server.target("production").analyses()
+	assert.Same(t, second, latest)
+	assert.Same(t, first, previous)
+
+	// Failed scans keep the previous analyses
+	require.NoError(t, server.Trigger("production"))
+	server.wg.Wait()
+	latest, previous = server.target("production").analyses()
+	assert.Same(t, second, latest)
+	assert.Same(t, first, previous)
+
+	status := server.target("production").status()
+	assert.Equal(t, 3, status.Scans)
+	assert.Equal(t, 1, status.Failures)
+	assert.Equal(t, "access denied", status.LastError)
+}
+
+func TestServer_TriggerWhileRunning(t *testing.T) {
+	release := make(chan struct{})
+	server := NewServer([]Target{
+		{Name: "production", Interval: time.Hour, Scan: func() (*analyser.Analysis, error) {
+			<-release
+			return analyser.NewAnalysis(), nil
+		}},
+	}, "")
+
+	require.NoError(t, server.Trigger("production"))
+	assert.Equal(t, ErrScanRunning, server.Trigger("production"))
+	assert.True(t, server.target("production").status().Running)
+
+	close(release)
+	server.wg.Wait()
+	assert.False(t, server.target("production").status().Running)
+}
+
+func TestServer_Persistence(t *testing.T) {
+	dir := t.TempDir()
+	server := NewServer([]Target{
+		{Name: "production", Interval: time.Hour, Scan: sequence(fakeAnalysis(), fakeAnalysis())},
+	}, dir)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, server.Trigger("production"))
+		server.wg.Wait()
+	}
+
+	restarted := NewServer([]Target{{Name: "production", Interval: time.Hour}}, dir)
+	latest, previous := restarted.target("production").analyses()
+	require.NotNil(t, latest)
+	require.NotNil(t, previous)
+	assert.Equal(t, 50, latest.Coverage())
+	assert.Len(t, latest.Unmanaged(), 1)
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), restarted.target("production").status().LastScan.UTC())
+}
+
+func Test_writeFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "latest.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"old":true}`), 0644))
+
+	require.NoError(t, writeFile(path, []byte(`{"new":true}`), 0644))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"new":true}`, string(content))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files must not be left behind")
+}